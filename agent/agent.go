@@ -3,6 +3,7 @@ package agent
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -31,8 +32,25 @@ type Agent struct {
 
 	// Agent Tools
 
-	Tools         []tools.ToolKit // Tools are functions the model may generate JSON inputs for
-	ShowToolCalls bool            // Show tool calls in Agent response
+	Tools         []tools.ToolKit          // Tools are functions the model may generate JSON inputs for
+	ShowToolCalls bool                     // Show tool calls in Agent response
+	ToolTimeout   time.Duration            // Default maximum duration of a tool call, for tools without Timeout; 0 means no timeout
+	ToolTimeouts  map[string]time.Duration // Maximum duration of calls by tool name, overriding the tool's Timeout and ToolTimeout
+	ToolCache     tools.Cache              // Optional cache of tool results, used for tools with a CacheTTL or an entry in ToolCacheTTLs
+	ToolCacheTTLs map[string]time.Duration // Cache TTLs by tool name, overriding the tool's CacheTTL; 0 disables caching of the tool
+
+	// Audio settings
+
+	// Transcriber, if set, transcribes audio attached to user messages and adds the transcript to the message text.
+	// Useful for models without audio input support (e.g., Claude). The audio is not sent to the model.
+	// Audio is sent as is to models reporting audio support through models.CapabilityReporter.
+	Transcriber models.Transcriber
+
+	// Image settings
+
+	// ImageDescriber, if set, is a vision model used to describe images attached to user messages
+	// when the agent's Model reports no vision support. The description is added to the message text.
+	ImageDescriber models.Model
 
 	// Logger related settings
 
@@ -40,8 +58,10 @@ type Agent struct {
 
 	// Internal fields
 
-	isInit bool         // Internal flag to track initialization
-	_tools []tools.Tool // Internal list of tools. This is a flat list of tools from the ToolKits using `GetAllTools()`
+	isInit   bool                     // Internal flag to track initialization
+	_tools   []tools.Tool             // Internal list of tools. This is a flat list of tools from the ToolKits using `GetAllTools()`
+	_schemas map[string]*tools.Schema // Internal compiled parameter schemas of the tools by name, used to validate tool calls
+	_version uint64                   // Internal sum of the versions of the DynamicToolKits when the tools were loaded
 }
 
 // Init initializes the Agent with required settings and the system message.
@@ -58,6 +78,12 @@ func (agent *Agent) Init() {
 
 	// Initialize the model
 	agent.Model.Init()
+	if agent.Transcriber != nil {
+		agent.Transcriber.Init()
+	}
+	if agent.ImageDescriber != nil {
+		agent.ImageDescriber.Init()
+	}
 	// Add tools to the model
 	agent.addToolToModel()
 
@@ -78,10 +104,51 @@ func (agent *Agent) GetAllTools() []tools.Tool {
 	if len(agent._tools) > 0 {
 		return agent._tools
 	}
+	agent._version = agent.toolsVersion()
 	agent._tools = agent.processTools()
+	agent._schemas = compileToolSchemas(agent._tools)
 	return agent._tools
 }
 
+// toolsVersion returns the sum of the versions of the agent's DynamicToolKits, which increases when any of them changes.
+func (agent *Agent) toolsVersion() uint64 {
+	var version uint64
+	for _, toolkit := range agent.Tools {
+		if dynamic, ok := toolkit.(tools.DynamicToolKit); ok {
+			version += dynamic.ToolsVersion()
+		}
+	}
+	return version
+}
+
+// refreshTools reloads the tools and sends them to the model if the tools of a DynamicToolKit changed.
+func (agent *Agent) refreshTools() {
+	if agent.toolsVersion() == agent._version {
+		return
+	}
+	utils.Logger.Debug("Tools changed, reloading tools")
+	agent._tools = nil
+	agent.addToolToModel()
+}
+
+// compileToolSchemas compiles the parameter schemas of the tools once, to validate the arguments of tool calls.
+// Tools with an invalid schema are logged and their arguments are not validated.
+func compileToolSchemas(toolList []tools.Tool) map[string]*tools.Schema {
+	schemas := make(map[string]*tools.Schema, len(toolList))
+	for _, tool := range toolList {
+		if tool.Parameters == nil {
+			continue
+		}
+		schema, err := tools.CompileSchema(tool.Parameters)
+		if err != nil {
+			utils.Logger.Warn("Invalid tool parameters schema; arguments will not be validated", "tool", tool.Name, "error", err)
+			continue
+		}
+		schemas[tool.Name] = schema
+	}
+	return schemas
+}
+
 // processTools processes the agent's tools and returns a flat list of tools.
 func (agent *Agent) processTools() []tools.Tool {
 	if len(agent.Tools) == 0 {
@@ -191,6 +258,98 @@ func (agent *Agent) AddMessage(role, content string, media []models.Media) {
 	agent.Messages = append(agent.Messages, models.Message{Role: role, Content: content, Images: images, Audios: audio})
 }
 
+// imageDescriptionPrompt is the prompt sent to the ImageDescriber with each image to describe.
+const imageDescriptionPrompt = "Describe this image in detail, including any visible text, so that someone who cannot see it can answer questions about it."
+
+// capabilities returns the capabilities of the agent's model, and false if the model does not report them.
+func (agent *Agent) capabilities() (models.Capabilities, bool) {
+	reporter, ok := agent.Model.(models.CapabilityReporter)
+	if !ok {
+		return models.Capabilities{}, false
+	}
+	return reporter.Capabilities(), true
+}
+
+// prepareInput validates the tools and the media of a user message against the capabilities of the model.
+// Unsupported audio is transcribed with the Transcriber and unsupported images are described with the ImageDescriber,
+// adding the results to the user message. It returns the updated user message and the media to send to the model,
+// or an error if the model does not support an input and no fallback is configured.
+// Models which do not implement models.CapabilityReporter are assumed to support all inputs,
+// except that audio is still transcribed if a Transcriber is set.
+func (agent *Agent) prepareInput(ctx context.Context, userMessage string, media []models.Media, stream bool) (string, []models.Media, error) {
+	caps, reported := agent.capabilities()
+	if reported {
+		if stream && !caps.Streaming {
+			return "", nil, fmt.Errorf("model does not support streaming; use Run instead")
+		}
+		if len(agent.GetAllTools()) > 0 {
+			if !caps.Tools {
+				return "", nil, fmt.Errorf("model does not support tool calling; remove the agent's tools or use another model")
+			}
+			if stream && !caps.StreamingToolCalls {
+				return "", nil, fmt.Errorf("model does not support tool calls when streaming; use Run instead")
+			}
+		}
+	}
+
+	remaining := make([]models.Media, 0, len(media))
+	for _, m := range media {
+		switch m := m.(type) {
+		case *models.Audio:
+			if reported && caps.Audio {
+				remaining = append(remaining, m)
+				continue
+			}
+			if agent.Transcriber == nil {
+				if reported {
+					return "", nil, fmt.Errorf("model does not support audio input; set Agent.Transcriber to transcribe it")
+				}
+				remaining = append(remaining, m)
+				continue
+			}
+			utils.Logger.Debug("Transcribing audio input")
+			transcription, err := agent.Transcriber.Transcribe(ctx, m)
+			if err != nil {
+				return "", nil, fmt.Errorf("failed to transcribe audio: %w", err)
+			}
+			utils.Logger.Debug("Audio transcribed", "transcript", transcription.Text)
+			userMessage += fmt.Sprintf("\n\n<audio_transcript>\n%s\n</audio_transcript>", transcription.Text)
+		case *models.Image:
+			if !reported || caps.Vision {
+				remaining = append(remaining, m)
+				continue
+			}
+			if agent.ImageDescriber == nil {
+				return "", nil, fmt.Errorf("model does not support image input; set Agent.ImageDescriber to describe it")
+			}
+			utils.Logger.Debug("Describing image input")
+			description, err := agent.describeImage(ctx, m)
+			if err != nil {
+				return "", nil, fmt.Errorf("failed to describe image: %w", err)
+			}
+			utils.Logger.Debug("Image described", "description", description)
+			userMessage += fmt.Sprintf("\n\n<image_description>\n%s\n</image_description>", description)
+		default:
+			remaining = append(remaining, m)
+		}
+	}
+	return strings.TrimLeft(userMessage, "\n"), remaining, nil
+}
+
+// describeImage returns a text description of the image generated by the agent's ImageDescriber.
+func (agent *Agent) describeImage(ctx context.Context, image *models.Image) (string, error) {
+	response, err := agent.ImageDescriber.ChatCompletion(ctx, []models.Message{
+		{Role: "user", Content: imageDescriptionPrompt, Images: []*models.Image{image}},
+	})
+	if err != nil {
+		return "", err
+	}
+	if response.Event != "complete" {
+		return "", fmt.Errorf("unexpected event type: %s", response.Event)
+	}
+	return response.Data, nil
+}
+
 func findTool(tools []tools.Tool, name string) (*tools.Tool, error) {
 	for _, tool := range tools {
 		if tool.Name == name {
@@ -200,22 +359,96 @@ func findTool(tools []tools.Tool, name string) (*tools.Tool, error) {
 	return nil, fmt.Errorf("tool %s not found", name)
 }
 
+// executeToolCall validates the arguments of a tool call against the tool's parameters schema and executes the tool.
+// It returns the result to send back to the model, and false if the tool does not exist.
+// Errors, including invalid arguments, timeouts and panics, are returned as the result so that the model can react.
+// Results of cacheable tools are read from and stored in ToolCache; toolCall.Cached is set on cache hits.
+func (agent *Agent) executeToolCall(ctx context.Context, toolCall *tools.ToolCall) (string, bool) {
+	tool, err := findTool(agent.GetAllTools(), toolCall.Name)
+	if err != nil {
+		utils.Logger.Error("Tool not found", "name", toolCall.Name, "error", err)
+		return fmt.Sprintf("Error: tool %s not found", toolCall.Name), false
+	}
+	if schema, ok := agent._schemas[tool.Name]; ok {
+		if err := schema.ValidateJSON(toolCall.Arguments); err != nil {
+			var validationErr *tools.ValidationError
+			if errors.As(err, &validationErr) {
+				validationErr.Tool = tool.Name
+			}
+			utils.Logger.Warn("Invalid tool arguments", "name", toolCall.Name, "arguments", toolCall.Arguments, "error", err)
+			return fmt.Sprintf("Error: %v", err), true
+		}
+	}
+	if timeout, ok := agent.ToolTimeouts[tool.Name]; ok {
+		tool.Timeout = timeout
+	}
+
+	// Only successful results are cached, so that failed calls are retried
+	var cacheKey string
+	cacheTTL := tool.CacheTTL
+	if ttl, ok := agent.ToolCacheTTLs[tool.Name]; ok {
+		cacheTTL = ttl
+	}
+	if agent.ToolCache != nil && cacheTTL > 0 {
+		if key, err := tools.CacheKey(tool.Name, toolCall.Arguments); err == nil {
+			cacheKey = key
+			if result, ok := agent.ToolCache.Get(cacheKey); ok {
+				utils.Logger.Debug("Tool cache hit", "name", toolCall.Name)
+				toolCall.Cached = true
+				return result, true
+			}
+		}
+	}
+
+	utils.Logger.Debug("Executing tool", "name", toolCall.Name)
+	result, err := tool.Call(ctx, toolCall.Arguments, agent.ToolTimeout)
+	if err != nil {
+		utils.Logger.Error("Tool execution failed", "name", toolCall.Name, "error", err)
+		return fmt.Sprintf("Error: %v", err), true
+	}
+	if cacheKey != "" {
+		agent.ToolCache.Set(cacheKey, result, cacheTTL)
+	}
+	utils.Logger.Debug("Tool execution complete", "name", toolCall.Name, "result", result)
+	return result, true
+}
+
+// countToolCalls adds the tool calls and cache hits of toolCalls to metrics.
+func countToolCalls(metrics *models.Metrics, toolCalls []tools.ToolCall) {
+	for _, toolCall := range toolCalls {
+		metrics.ToolCalls++
+		if toolCall.Cached {
+			metrics.ToolCacheHits++
+		}
+	}
+}
+
 // Run processes a user message synchronously and returns the model's response.
 // It adds the user message to the history, invokes ChatCompletion on the Model, appends the assistant’s response,
 // and returns the result. Returns an error if the model fails or no messages exist.
+// Inputs the model reports no support for (see models.CapabilityReporter) are transcribed, described or rejected with an error.
 func (agent *Agent) Run(ctx context.Context, userMessage string, media ...models.Media) (models.ModelResponse, error) {
 	agent.Init() // Ensure the agent is initialized
 	utils.Logger.Debug("Agent Run Start")
+	userMessage, media, err := agent.prepareInput(ctx, userMessage, media, false)
+	if err != nil {
+		return models.ModelResponse{}, err
+	}
 	agent.AddMessage("user", userMessage, media)
 
 	if len(agent.Messages) == 0 {
 		return models.ModelResponse{}, fmt.Errorf("no messages available for chat completion")
 	}
 
+	// Collect media produced by tools (e.g., generated images) to return with the response
+	ctx, artifacts := tools.WithArtifacts(ctx)
+
 	// Save all the tool calls made by the assistant here. This will be returned in response
 	var toolCalls []tools.ToolCall
+	metrics := &models.Metrics{}
 
 	for {
+		agent.refreshTools()
 		response, err := agent.Model.ChatCompletion(ctx, agent.Messages)
 		if err != nil {
 			return models.ModelResponse{}, err
@@ -229,35 +462,27 @@ func (agent *Agent) Run(ctx context.Context, userMessage string, media ...models
 			assistantMessage.ToolCalls = response.ToolCalls
 			agent.Messages = append(agent.Messages, assistantMessage)
 
-			for _, toolCall := range response.ToolCalls {
-				tool, err := findTool(agent.GetAllTools(), toolCall.Name)
-				if err != nil {
-					utils.Logger.Error("Tool not found", "name", toolCall.Name, "error", err)
-					agent.Messages = append(agent.Messages, models.Message{
-						Role:       "tool",
-						Content:    fmt.Sprintf("Error: tool %s not found", toolCall.Name),
-						ToolCallID: toolCall.ID,
-					})
-					continue
-				}
-				utils.Logger.Debug("Executing tool", "name", toolCall.Name)
-				result, err := tool.Execute(ctx, toolCall.Arguments)
-				if err != nil {
-					utils.Logger.Error("Tool execution failed", "name", toolCall.Name, "error", err)
-					result = fmt.Sprintf("Error: %s", err.Error())
-				}
-				utils.Logger.Debug("Tool execution complete", "name", toolCall.Name, "result", result)
+			executed := make([]tools.ToolCall, len(response.ToolCalls))
+			for i, toolCall := range response.ToolCalls {
+				result, found := agent.executeToolCall(ctx, &toolCall)
+				executed[i] = toolCall
 				agent.Messages = append(agent.Messages, models.Message{
 					Role:       "tool",
 					Content:    result,
 					ToolCallID: toolCall.ID,
 				})
-				toolCalls = append(toolCalls, toolCall)
+				if found {
+					toolCalls = append(toolCalls, toolCall)
+				}
 			}
+			// Every requested call is counted, including calls to unknown tools that are left out of the response
+			countToolCalls(metrics, executed)
 
 		} else if response.Event == "complete" {
 			agent.Messages = append(agent.Messages, assistantMessage)
 			response.ToolCalls = toolCalls
+			response.Images = artifacts.Images()
+			response.Metrics = metrics
 			utils.Logger.Debug("Agent Run End")
 			return response, nil
 		} else {
@@ -273,18 +498,27 @@ func (agent *Agent) Run(ctx context.Context, userMessage string, media ...models
 func (agent *Agent) RunStream(ctx context.Context, userMessage string, media ...models.Media) (chan models.ModelResponse, error) {
 	agent.Init() // Ensure the agent is initialized
 	utils.Logger.Debug("Agent RunStream Start")
+	userMessage, media, err := agent.prepareInput(ctx, userMessage, media, true)
+	if err != nil {
+		return nil, err
+	}
 	agent.AddMessage("user", userMessage, media)
 
 	if len(agent.Messages) == 0 {
 		return nil, fmt.Errorf("no messages available for chat completion")
 	}
 
+	// Collect media produced by tools (e.g., generated images) to return with the end event
+	ctx, artifacts := tools.WithArtifacts(ctx)
+
 	// Accumulate response in the background for history.
 	// TODO: Look into a better way to handle this, as it may not be ideal for large responses.
 	ch := make(chan models.ModelResponse)
 	go func() {
 		defer close(ch)
+		metrics := &models.Metrics{}
 		for {
+			agent.refreshTools()
 			respCh, err := agent.Model.ChatCompletionStream(ctx, agent.Messages)
 			if err != nil {
 				ch <- models.ModelResponse{
@@ -296,11 +530,18 @@ func (agent *Agent) RunStream(ctx context.Context, userMessage string, media ...
 			}
 
 			fullResponse := ""
+			audioTranscript := ""
 			var toolCalls []tools.ToolCall
 			for resp := range respCh {
 				if resp.Event == "chunk" {
 					fullResponse += resp.Data
 					ch <- resp // Forward content to the user
+				} else if resp.Event == "audio_chunk" {
+					// Keep the transcript in the history in place of text content
+					if resp.Audio != nil {
+						audioTranscript += resp.Audio.Transcript
+					}
+					ch <- resp // Forward audio to the user
 				} else if resp.Event == "tool_call" {
 					toolCalls = resp.ToolCalls
 					if resp.Data != "" {
@@ -311,12 +552,6 @@ func (agent *Agent) RunStream(ctx context.Context, userMessage string, media ...
 							CreatedAt: time.Now(),
 						}
 					}
-					// Send a separate event for tool calls
-					ch <- models.ModelResponse{
-						Event:     "tool_call",
-						ToolCalls: resp.ToolCalls,
-						CreatedAt: time.Now(),
-					}
 				} else if resp.Event == "end" {
 					// Break from the loop and handle the logic outside the response channel loop
 					break
@@ -324,7 +559,7 @@ func (agent *Agent) RunStream(ctx context.Context, userMessage string, media ...
 			}
 			assistantMessage := models.Message{
 				Role:    "assistant",
-				Content: fullResponse,
+				Content: utils.FirstNonEmpty(fullResponse, audioTranscript),
 			}
 
 			if len(toolCalls) > 0 {
@@ -333,36 +568,32 @@ func (agent *Agent) RunStream(ctx context.Context, userMessage string, media ...
 				agent.Messages = append(agent.Messages, assistantMessage)
 
 				// Execute tools and add results in Messages
-				for _, toolCall := range toolCalls {
-					tool, err := findTool(agent.GetAllTools(), toolCall.Name)
-					if err != nil {
-						utils.Logger.Error("Tool not found", "name", toolCall.Name, "error", err)
-						agent.Messages = append(agent.Messages, models.Message{
-							Role:       "tool",
-							Content:    fmt.Sprintf("Error: tool %s not found", toolCall.Name),
-							ToolCallID: toolCall.ID,
-						})
-						continue
-					}
-					utils.Logger.Debug("Executing tool", "name", toolCall.Name)
-					result, err := tool.Execute(ctx, toolCall.Arguments)
-					if err != nil {
-						utils.Logger.Error("Tool execution failed", "name", toolCall.Name, "error", err)
-						result = fmt.Sprintf("Error: %v", err)
-					}
-					utils.Logger.Debug("Tool execution complete", "name", toolCall.Name, "result", result)
+				executed := make([]tools.ToolCall, len(toolCalls))
+				for i, toolCall := range toolCalls {
+					result, _ := agent.executeToolCall(ctx, &toolCall)
+					executed[i] = toolCall
 					agent.Messages = append(agent.Messages, models.Message{
 						Role:       "tool",
 						Content:    result,
 						ToolCallID: toolCall.ID,
 					})
 				}
+				countToolCalls(metrics, executed)
+
+				// Send a separate event for tool calls once executed, so that cache hits are marked
+				ch <- models.ModelResponse{
+					Event:     "tool_call",
+					ToolCalls: executed,
+					CreatedAt: time.Now(),
+				}
 			} else {
 				// Add assistant message without tool call
 				agent.Messages = append(agent.Messages, assistantMessage)
 				// Send the end event to the channel
 				ch <- models.ModelResponse{
 					Event:     "end",
+					Images:    artifacts.Images(),
+					Metrics:   metrics,
 					CreatedAt: time.Now(),
 				}
 				break
@@ -439,6 +670,12 @@ func (agent *Agent) PrintResponse(ctx context.Context, userMessage string, strea
 				tp.response += resp.Data
 				tp.logs = logBuffer.String()
 				area.Update(tp.buildContent())
+			case "audio_chunk":
+				if resp.Audio != nil && resp.Audio.Transcript != "" {
+					tp.response += resp.Audio.Transcript
+					tp.logs = logBuffer.String()
+					area.Update(tp.buildContent())
+				}
 			case "tool_call":
 				tp.toolCalls = append(tp.toolCalls, resp.ToolCalls...)
 				tp.logs = logBuffer.String()
@@ -37,6 +37,64 @@ func (m *MockModel) ChatCompletionStream(ctx context.Context, messages []models.
 	return ch, nil
 }
 
+// MockToolCallModel is a mock Model which requests the given tool call once, then completes.
+type MockToolCallModel struct {
+	MockModel
+	toolCall tools.ToolCall
+	called   bool
+}
+
+func (m *MockToolCallModel) ChatCompletion(ctx context.Context, messages []models.Message) (models.ModelResponse, error) {
+	if m.called {
+		return m.MockModel.ChatCompletion(ctx, messages)
+	}
+	m.called = true
+	return models.ModelResponse{
+		Event:     "tool_call",
+		ToolCalls: []tools.ToolCall{m.toolCall},
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (m *MockToolCallModel) ChatCompletionStream(ctx context.Context, messages []models.Message) (chan models.ModelResponse, error) {
+	if m.called {
+		return m.MockModel.ChatCompletionStream(ctx, messages)
+	}
+	m.called = true
+	ch := make(chan models.ModelResponse, 2)
+	ch <- models.ModelResponse{Event: "tool_call", ToolCalls: []tools.ToolCall{m.toolCall}, CreatedAt: time.Now()}
+	ch <- models.ModelResponse{Event: "end", CreatedAt: time.Now()}
+	close(ch)
+	return ch, nil
+}
+
+// MockTranscriber is a mock implementation of the Transcriber interface for testing.
+type MockTranscriber struct {
+	isInit bool
+	err    error
+}
+
+func (m *MockTranscriber) Init() { m.isInit = true }
+func (m *MockTranscriber) Transcribe(ctx context.Context, audio *models.Audio) (models.Transcription, error) {
+	if m.err != nil {
+		return models.Transcription{}, m.err
+	}
+	return models.Transcription{Text: "Transcript of " + audio.URL}, nil
+}
+
+// MockCapableModel is a mock Model which reports its capabilities.
+type MockCapableModel struct {
+	MockModel
+	caps     models.Capabilities
+	messages []models.Message
+}
+
+func (m *MockCapableModel) Capabilities() models.Capabilities { return m.caps }
+func (m *MockCapableModel) ChatCompletion(ctx context.Context, messages []models.Message) (models.ModelResponse, error) {
+	m.messages = messages
+	return m.MockModel.ChatCompletion(ctx, messages)
+}
+
 // MockTool is a simple implementation of the Tool interface for testing
 func createMockTool(name string) tools.Tool {
 	return tools.Tool{
@@ -188,6 +246,53 @@ func TestRun(t *testing.T) {
 	}
 }
 
+func TestRunWithTranscriber(t *testing.T) {
+	transcriber := &MockTranscriber{}
+	agent := Agent{Model: &MockModel{}, Transcriber: transcriber}
+	agent.Init()
+	assert.True(t, transcriber.isInit, "Transcriber should be initialized with the agent")
+
+	image := &models.Image{URL: "http://example.com/image.jpg"}
+	audio := &models.Audio{URL: "http://example.com/audio.mp3"}
+	_, err := agent.Run(context.Background(), "Answer this", image, audio)
+	assert.NoError(t, err)
+
+	userMessage := agent.Messages[0]
+	assert.Equal(t, "user", userMessage.Role)
+	assert.Equal(t, "Answer this\n\n<audio_transcript>\nTranscript of http://example.com/audio.mp3\n</audio_transcript>", userMessage.Content)
+	assert.Len(t, userMessage.Images, 1, "Images should still be attached")
+	assert.Empty(t, userMessage.Audios, "Transcribed audio should not be attached")
+
+	// Audio only message
+	_, err = agent.Run(context.Background(), "", audio)
+	assert.NoError(t, err)
+	assert.Equal(t, "<audio_transcript>\nTranscript of http://example.com/audio.mp3\n</audio_transcript>", agent.Messages[2].Content)
+
+	// Transcription errors are returned
+	transcriber.err = assert.AnError
+	_, err = agent.Run(context.Background(), "Answer this", audio)
+	assert.ErrorIs(t, err, assert.AnError)
+	_, err = agent.RunStream(context.Background(), "Answer this", audio)
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestRunWithToolArtifacts(t *testing.T) {
+	image := &models.Image{URL: "http://example.com/generated.png"}
+	imageTool := tools.NewTool("generate", "Generates an image", map[string]interface{}{}, func(ctx context.Context, args string) (string, error) {
+		tools.AddImages(ctx, image)
+		return "Generated 1 image", nil
+	})
+	agent := Agent{
+		Model: &MockToolCallModel{toolCall: tools.ToolCall{ID: "call-1", Name: "generate", Arguments: "{}"}},
+		Tools: []tools.ToolKit{imageTool},
+	}
+	resp, err := agent.Run(context.Background(), "Draw a cat")
+	assert.NoError(t, err)
+	assert.Equal(t, "Mock response", resp.Data)
+	assert.Len(t, resp.ToolCalls, 1)
+	assert.Equal(t, []*models.Image{image}, resp.Images)
+}
+
 func TestRunStream(t *testing.T) {
 	agent := Agent{Model: &MockModel{}}
 	agent.Init()
@@ -385,3 +490,191 @@ func TestAddToolToModel(t *testing.T) {
 		})
 	}
 }
+
+func TestRunWithCapabilities(t *testing.T) {
+	image := &models.Image{URL: "http://example.com/image.jpg"}
+	audio := &models.Audio{URL: "http://example.com/audio.mp3"}
+
+	// Supported inputs are sent to the model as is, even with fallbacks configured
+	model := &MockCapableModel{caps: models.Capabilities{Vision: true, Audio: true, Tools: true, Streaming: true}}
+	agent := Agent{Model: model, Transcriber: &MockTranscriber{}}
+	_, err := agent.Run(context.Background(), "Answer this", image, audio)
+	assert.NoError(t, err)
+	assert.Equal(t, "Answer this", agent.Messages[0].Content)
+	assert.Len(t, agent.Messages[0].Images, 1)
+	assert.Len(t, agent.Messages[0].Audios, 1)
+
+	// Unsupported inputs without fallbacks are rejected before calling the model
+	model = &MockCapableModel{}
+	agent = Agent{Model: model}
+	_, err = agent.Run(context.Background(), "Answer this", audio)
+	assert.ErrorContains(t, err, "does not support audio input")
+	_, err = agent.Run(context.Background(), "Answer this", image)
+	assert.ErrorContains(t, err, "does not support image input")
+	_, err = agent.RunStream(context.Background(), "Answer this")
+	assert.ErrorContains(t, err, "does not support streaming")
+	assert.Nil(t, model.messages, "Model should not be called")
+	assert.Empty(t, agent.Messages, "Rejected messages should not be added to the history")
+
+	// Unsupported tools are rejected
+	agent = Agent{Model: &MockCapableModel{caps: models.Capabilities{Streaming: true}}, Tools: []tools.ToolKit{createMockTool("tool")}}
+	_, err = agent.Run(context.Background(), "Use the tool")
+	assert.ErrorContains(t, err, "does not support tool calling")
+	agent = Agent{Model: &MockCapableModel{caps: models.Capabilities{Tools: true, Streaming: true}}, Tools: []tools.ToolKit{createMockTool("tool")}}
+	_, err = agent.RunStream(context.Background(), "Use the tool")
+	assert.ErrorContains(t, err, "does not support tool calls when streaming")
+
+	// Fallbacks are applied to unsupported inputs
+	describer := &MockCapableModel{caps: models.Capabilities{Vision: true}}
+	model = &MockCapableModel{}
+	agent = Agent{Model: model, Transcriber: &MockTranscriber{}, ImageDescriber: describer}
+	_, err = agent.Run(context.Background(), "Answer this", image, audio)
+	assert.NoError(t, err)
+	assert.Equal(t, "Answer this\n\n<image_description>\nMock response\n</image_description>\n\n<audio_transcript>\nTranscript of http://example.com/audio.mp3\n</audio_transcript>", agent.Messages[0].Content)
+	assert.Empty(t, agent.Messages[0].Images, "Described images should not be attached")
+	assert.Empty(t, agent.Messages[0].Audios, "Transcribed audio should not be attached")
+	assert.Len(t, describer.messages, 1)
+	assert.Equal(t, []*models.Image{image}, describer.messages[0].Images)
+}
+
+func TestRunWithInvalidToolArguments(t *testing.T) {
+	executed := false
+	parameters := map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"count": map[string]interface{}{"type": "integer", "minimum": 1}},
+		"required":   []string{"count"},
+	}
+	countTool := tools.NewTool("count", "Counts", parameters, func(ctx context.Context, args string) (string, error) {
+		executed = true
+		return "counted", nil
+	})
+	agent := Agent{
+		Model: &MockToolCallModel{toolCall: tools.ToolCall{ID: "call-1", Name: "count", Arguments: `{"count": 0}`}},
+		Tools: []tools.ToolKit{countTool},
+	}
+	_, err := agent.Run(context.Background(), "Count")
+	assert.NoError(t, err)
+	assert.False(t, executed, "Tool should not be executed with invalid arguments")
+
+	toolMessage := agent.Messages[2]
+	assert.Equal(t, "tool", toolMessage.Role)
+	assert.Equal(t, "call-1", toolMessage.ToolCallID)
+	assert.Equal(t, "Error: invalid arguments for tool count:\n- count: must be >= 1, got 0\nFix the arguments and call the tool again.", toolMessage.Content)
+}
+
+func TestRunWithFailingTools(t *testing.T) {
+	panicTool := tools.NewTool("panic", "Panics", nil, func(ctx context.Context, args string) (string, error) {
+		panic("something went wrong")
+	})
+	agent := Agent{
+		Model: &MockToolCallModel{toolCall: tools.ToolCall{ID: "call-1", Name: "panic", Arguments: "{}"}},
+		Tools: []tools.ToolKit{panicTool},
+	}
+	resp, err := agent.Run(context.Background(), "Panic")
+	assert.NoError(t, err, "A panicking tool should not crash the run")
+	assert.Equal(t, "Mock response", resp.Data)
+	assert.Equal(t, "Error: tool panic panicked: something went wrong", agent.Messages[2].Content)
+
+	slowTool := tools.NewTool("slow", "Slow", nil, func(ctx context.Context, args string) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	slowTool.Timeout = time.Minute
+	agent = Agent{
+		Model:        &MockToolCallModel{toolCall: tools.ToolCall{ID: "call-1", Name: "slow", Arguments: "{}"}},
+		Tools:        []tools.ToolKit{slowTool},
+		ToolTimeout:  time.Hour,
+		ToolTimeouts: map[string]time.Duration{"slow": 10 * time.Millisecond},
+	}
+	_, err = agent.Run(context.Background(), "Wait")
+	assert.NoError(t, err)
+	assert.Equal(t, "Error: tool slow timed out after 10ms: context deadline exceeded", agent.Messages[2].Content)
+}
+
+func TestRunWithToolCache(t *testing.T) {
+	calls := 0
+	weatherTool := tools.NewTool("weather", "Gets the weather", nil, func(ctx context.Context, args string) (string, error) {
+		calls++
+		return "Sunny", nil
+	})
+	weatherTool.CacheTTL = time.Minute
+	model := &MockToolCallModel{toolCall: tools.ToolCall{ID: "call-1", Name: "weather", Arguments: `{"city": "Paris", "units": "celsius"}`}}
+	agent := Agent{Model: model, Tools: []tools.ToolKit{weatherTool}, ToolCache: &tools.MemoryCache{}}
+
+	resp, err := agent.Run(context.Background(), "Weather?")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.False(t, resp.ToolCalls[0].Cached)
+	assert.Equal(t, &models.Metrics{ToolCalls: 1}, resp.Metrics)
+
+	// Same arguments in a different order are served from the cache
+	model.called = false
+	model.toolCall.Arguments = `{"units":"celsius","city":"Paris"}`
+	resp, err = agent.Run(context.Background(), "Weather again?")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls, "The tool should not be executed on a cache hit")
+	assert.True(t, resp.ToolCalls[0].Cached)
+	assert.Equal(t, &models.Metrics{ToolCalls: 1, ToolCacheHits: 1}, resp.Metrics)
+	assert.Equal(t, "Sunny", agent.Messages[len(agent.Messages)-2].Content)
+
+	// Caching can be disabled by tool name
+	model.called = false
+	agent.ToolCacheTTLs = map[string]time.Duration{"weather": 0}
+	resp, err = agent.Run(context.Background(), "Weather once more?")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.False(t, resp.ToolCalls[0].Cached)
+}
+
+func TestToolCallMetricsWithUnknownTool(t *testing.T) {
+	toolCall := tools.ToolCall{ID: "call-1", Name: "missing", Arguments: "{}"}
+	agent := Agent{Model: &MockToolCallModel{toolCall: toolCall}, Tools: []tools.ToolKit{&MockToolKit{ToolNames: []string{"tool1"}}}}
+	resp, err := agent.Run(context.Background(), "Call a missing tool")
+	assert.NoError(t, err)
+	assert.Empty(t, resp.ToolCalls)
+	assert.Equal(t, &models.Metrics{ToolCalls: 1}, resp.Metrics)
+	assert.Equal(t, "Error: tool missing not found", agent.Messages[2].Content)
+
+	agent = Agent{Model: &MockToolCallModel{toolCall: toolCall}, Tools: []tools.ToolKit{&MockToolKit{ToolNames: []string{"tool1"}}}}
+	ch, err := agent.RunStream(context.Background(), "Call a missing tool")
+	if !assert.NoError(t, err) {
+		return
+	}
+	var metrics *models.Metrics
+	for resp := range ch {
+		if resp.Event == "end" {
+			metrics = resp.Metrics
+		}
+	}
+	assert.Equal(t, &models.Metrics{ToolCalls: 1}, metrics)
+	assert.Equal(t, "Error: tool missing not found", agent.Messages[2].Content)
+}
+
+// MockDynamicToolKit is a mock DynamicToolKit whose tools can be replaced.
+type MockDynamicToolKit struct {
+	tools   []tools.Tool
+	version uint64
+}
+
+func (tk *MockDynamicToolKit) Tools() []tools.Tool  { return tk.tools }
+func (tk *MockDynamicToolKit) ToolsVersion() uint64 { return tk.version }
+
+func TestRunWithDynamicToolKit(t *testing.T) {
+	toolkit := &MockDynamicToolKit{tools: []tools.Tool{createMockTool("first")}, version: 1}
+	model := &MockModel{}
+	agent := Agent{Model: model, Tools: []tools.ToolKit{toolkit}}
+	_, err := agent.Run(context.Background(), "Hello")
+	assert.NoError(t, err)
+	assert.Len(t, model.tools, 1)
+
+	// New tools are sent to the model once the version changes
+	toolkit.tools = append(toolkit.tools, createMockTool("second"))
+	_, err = agent.Run(context.Background(), "Hello again")
+	assert.NoError(t, err)
+	assert.Len(t, model.tools, 1, "Tools should not be reloaded while the version is unchanged")
+	toolkit.version++
+	_, err = agent.Run(context.Background(), "Hello once more")
+	assert.NoError(t, err)
+	assert.Len(t, model.tools, 2)
+	assert.Len(t, agent.GetAllTools(), 2)
+}
@@ -49,7 +49,11 @@ func (tp *TerminalPrinter) buildContent() string {
 
 	// Tool Calls
 	for _, toolCall := range tp.toolCalls {
-		toolCallStr += fmt.Sprintf("• %s %s\n", toolCall.Name, toolCall.Arguments)
+		toolCallStr += fmt.Sprintf("• %s %s", toolCall.Name, toolCall.Arguments)
+		if toolCall.Cached {
+			toolCallStr += " (cached)"
+		}
+		toolCallStr += "\n"
 	}
 	if toolCallStr != "" {
 		toolCallStr = strings.TrimRight(toolCallStr, "\n")
@@ -0,0 +1,273 @@
+// Command toolgen generates the tool metadata of the toolkits in a package, so that tools.CreateToolFromMethod
+// does not need to parse the package source code at runtime (e.g., in binaries deployed without source).
+//
+// It scans the package for toolkit types, i.e. types with a `Tools() []tools.Tool` method, and registers the
+// doc comments of their exported methods taking a context.Context as first parameter with tools.RegisterToolMetadata.
+//
+// Usage, in a file of the toolkit package:
+//
+//	//go:generate go run github.com/Harsh-2909/hermes-go/cmd/toolgen
+//
+// Flags:
+//
+//	-dir string     Directory of the package (default ".")
+//	-output string  Name of the generated file in the package directory (default "toolmeta_gen.go")
+//	-type string    Comma separated list of types to generate metadata for, instead of all toolkit types
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Harsh-2909/hermes-go/tools"
+)
+
+// toolsPkgPath is the import path of the tools package.
+const toolsPkgPath = "github.com/Harsh-2909/hermes-go/tools"
+
+func main() {
+	dir := flag.String("dir", ".", "directory of the package")
+	output := flag.String("output", "toolmeta_gen.go", "name of the generated file in the package directory")
+	typeNames := flag.String("type", "", "comma separated list of types to generate metadata for, instead of all toolkit types")
+	flag.Parse()
+
+	var typeFilter []string
+	if *typeNames != "" {
+		typeFilter = strings.Split(*typeNames, ",")
+	}
+	code, err := generate(*dir, *output, typeFilter)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "toolgen:", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(filepath.Join(*dir, *output), code, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, "toolgen:", err)
+		os.Exit(1)
+	}
+}
+
+// method is a toolkit method to generate metadata for.
+type method struct {
+	typeName string
+	name     string
+	metadata tools.ToolMetadata
+}
+
+// generate returns the source code registering the metadata of the toolkit methods of the package in dir.
+// The output file is ignored when parsing, so that an outdated version does not affect the result.
+func generate(dir, output string, typeFilter []string) ([]byte, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go") && info.Name() != output
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse package: %v", err)
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected one package in %s, found %d", dir, len(pkgs))
+	}
+	var files []*ast.File
+	var pkgName string
+	for name, pkg := range pkgs {
+		pkgName = name
+		for _, file := range pkg.Files {
+			files = append(files, file)
+		}
+	}
+
+	pkgPath, err := importPath(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Type check the package. Errors are ignored, as only the method signatures are needed
+	info := &types.Info{Defs: make(map[*ast.Ident]types.Object)}
+	config := types.Config{Importer: importer.ForCompiler(fset, "source", nil), Error: func(error) {}}
+	pkg, _ := config.Check(pkgPath, fset, files, info)
+
+	// Map the methods to their declarations to read the doc comments
+	decls := make(map[types.Object]*ast.FuncDecl)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok && fd.Recv != nil {
+				if obj := info.Defs[fd.Name]; obj != nil {
+					decls[obj] = fd
+				}
+			}
+		}
+	}
+
+	var methods []method
+	for _, name := range pkg.Scope().Names() {
+		typeName, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok || typeName.IsAlias() {
+			continue
+		}
+		named, ok := typeName.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+		if typeFilter != nil {
+			if !contains(typeFilter, name) {
+				continue
+			}
+		} else if !isToolKit(named) {
+			continue
+		}
+
+		methodSet := types.NewMethodSet(types.NewPointer(named))
+		for i := 0; i < methodSet.Len(); i++ {
+			fn := methodSet.At(i).Obj().(*types.Func)
+			if !fn.Exported() || fn.Pkg() != pkg {
+				continue
+			}
+			decl := decls[fn]
+			if decl == nil || decl.Doc == nil {
+				continue
+			}
+			paramNames, ok := toolParamNames(fn.Type().(*types.Signature))
+			if !ok {
+				continue
+			}
+			methods = append(methods, method{
+				typeName: name,
+				name:     fn.Name(),
+				metadata: tools.ParseToolDoc(decl.Doc.Text(), paramNames),
+			})
+		}
+	}
+	if typeFilter != nil {
+		for _, name := range typeFilter {
+			if pkg.Scope().Lookup(name) == nil {
+				return nil, fmt.Errorf("type %s not found in package %s", name, pkgPath)
+			}
+		}
+	}
+	sort.Slice(methods, func(i, j int) bool {
+		if methods[i].typeName != methods[j].typeName {
+			return methods[i].typeName < methods[j].typeName
+		}
+		return methods[i].name < methods[j].name
+	})
+
+	return render(pkgName, pkgPath == toolsPkgPath, methods)
+}
+
+// importPath returns the import path of the package in dir.
+func importPath(dir string) (string, error) {
+	cmd := exec.Command("go", "list", "-f", "{{.ImportPath}}", ".")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to find import path of %s: %v", dir, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// isToolKit reports whether the pointer to named has a `Tools() []tools.Tool` method.
+// tools.Tool itself is excluded: it is a toolkit of a single tool, and its methods are not tools.
+func isToolKit(named *types.Named) bool {
+	if isNamed(named, toolsPkgPath, "Tool") {
+		return false
+	}
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(named), false, named.Obj().Pkg(), "Tools")
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+		return false
+	}
+	slice, ok := sig.Results().At(0).Type().(*types.Slice)
+	if !ok {
+		return false
+	}
+	return isNamed(slice.Elem(), toolsPkgPath, "Tool")
+}
+
+// toolParamNames returns the names of the parameters of a tool method after ctx.
+// It returns false if the method does not take a context.Context as first parameter or has unnamed parameters.
+func toolParamNames(sig *types.Signature) ([]string, bool) {
+	params := sig.Params()
+	if params.Len() == 0 || !isNamed(params.At(0).Type(), "context", "Context") {
+		return nil, false
+	}
+	names := make([]string, 0, params.Len()-1)
+	for i := 1; i < params.Len(); i++ {
+		name := params.At(i).Name()
+		if name == "" || name == "_" {
+			return nil, false
+		}
+		names = append(names, name)
+	}
+	return names, true
+}
+
+// isNamed reports whether t is the named type pkgPath.name.
+func isNamed(t types.Type, pkgPath, name string) bool {
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Pkg().Path() == pkgPath && named.Obj().Name() == name
+}
+
+// contains reports whether list contains s.
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if strings.TrimSpace(item) == s {
+			return true
+		}
+	}
+	return false
+}
+
+// render returns the formatted source code of the generated file.
+// inToolsPkg is true when generating for the tools package itself, whose identifiers must not be qualified.
+func render(pkgName string, inToolsPkg bool, methods []method) ([]byte, error) {
+	qualifier := "tools."
+	if inToolsPkg {
+		qualifier = ""
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by toolgen. DO NOT EDIT.\n\npackage %s\n\n", pkgName)
+	if !inToolsPkg && len(methods) > 0 {
+		fmt.Fprintf(&buf, "import %q\n\n", toolsPkgPath)
+	}
+	buf.WriteString("func init() {\n")
+	for _, m := range methods {
+		fmt.Fprintf(&buf, "%sRegisterToolMetadata((*%s)(nil), %q, %sToolMetadata{\n", qualifier, m.typeName, m.name, qualifier)
+		fmt.Fprintf(&buf, "Description: %s,\n", strconv.Quote(m.metadata.Description))
+		fmt.Fprintf(&buf, "Params: []%sParamMetadata{\n", qualifier)
+		for _, p := range m.metadata.Params {
+			fmt.Fprintf(&buf, "{Name: %q, Description: %q, Required: %t", p.Name, p.Description, p.Required)
+			if len(p.Enum) > 0 {
+				fmt.Fprintf(&buf, ", Enum: %#v", p.Enum)
+			}
+			buf.WriteString("},\n")
+		}
+		buf.WriteString("},\n})\n")
+	}
+	buf.WriteString("}\n")
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %v", err)
+	}
+	return code, nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	code, err := generate("testdata/weather", "toolmeta_gen.go", nil)
+	assert.NoError(t, err)
+	expected := `// Code generated by toolgen. DO NOT EDIT.
+
+package weather
+
+import "github.com/Harsh-2909/hermes-go/tools"
+
+func init() {
+	tools.RegisterToolMetadata((*WeatherTools)(nil), "GetWeather", tools.ToolMetadata{
+		Description: "GetWeather returns the weather in a city.",
+		Params: []tools.ParamMetadata{
+			{Name: "city", Description: "Name of the city", Required: true},
+			{Name: "units", Description: "Units of the temperature", Required: false, Enum: []string{"celsius", "fahrenheit"}},
+		},
+	})
+	tools.RegisterToolMetadata((*WeatherTools)(nil), "Undocumented", tools.ToolMetadata{
+		Description: "Undocumented has no @param lines.",
+		Params: []tools.ParamMetadata{
+			{Name: "days", Description: "", Required: false},
+		},
+	})
+}
+`
+	assert.Equal(t, expected, string(code))
+}
+
+func TestGenerateTypeFilter(t *testing.T) {
+	code, err := generate("testdata/weather", "toolmeta_gen.go", []string{"NotAToolKit"})
+	assert.NoError(t, err)
+	assert.Contains(t, string(code), `tools.RegisterToolMetadata((*NotAToolKit)(nil), "Run"`)
+	assert.NotContains(t, string(code), "WeatherTools")
+
+	_, err = generate("testdata/weather", "toolmeta_gen.go", []string{"Missing"})
+	assert.ErrorContains(t, err, "type Missing not found")
+}
+
+func TestGenerateToolsPackage(t *testing.T) {
+	code, err := generate("../../tools", "toolmeta_gen.go", nil)
+	assert.NoError(t, err)
+	assert.Contains(t, string(code), `RegisterToolMetadata((*CalculatorTools)(nil), "Add"`)
+	assert.NotContains(t, string(code), "(*Tool)(nil)", "Tool is not a toolkit of methods")
+}
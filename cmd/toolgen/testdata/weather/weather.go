@@ -0,0 +1,48 @@
+package weather
+
+import (
+	"context"
+
+	"github.com/Harsh-2909/hermes-go/tools"
+)
+
+// WeatherTools is a sample toolkit for testing toolgen.
+type WeatherTools struct{}
+
+// Tools returns the tools of the toolkit.
+func (w *WeatherTools) Tools() []tools.Tool {
+	return nil
+}
+
+// GetWeather returns the weather in a city.
+// @param city: Name of the city
+// @param [optional] units: Units of the temperature
+// @enum units: celsius, fahrenheit
+func (w *WeatherTools) GetWeather(ctx context.Context, city, units string) string {
+	return city
+}
+
+// Undocumented has no @param lines.
+func (w *WeatherTools) Undocumented(ctx context.Context, days int) int {
+	return days
+}
+
+// NoContext is not a tool method.
+func (w *WeatherTools) NoContext(city string) string {
+	return city
+}
+
+func (w *WeatherTools) NoDoc(ctx context.Context) string {
+	return ""
+}
+
+// helper is not exported.
+func (w *WeatherTools) helper(ctx context.Context) {}
+
+// NotAToolKit has no Tools method.
+type NotAToolKit struct{}
+
+// Run is not a tool method as NotAToolKit is not a toolkit.
+func (n *NotAToolKit) Run(ctx context.Context) string {
+	return ""
+}
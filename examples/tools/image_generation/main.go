@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Harsh-2909/hermes-go/agent"
+	models "github.com/Harsh-2909/hermes-go/models/openai"
+	"github.com/Harsh-2909/hermes-go/tools"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	// Load the environment variables
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+	imageTools := &tools.ImageGenerationTools{
+		Generator: &models.OpenAIImageGenerator{
+			ApiKey: os.Getenv("OPENAI_API_KEY"),
+			Id:     "dall-e-3",
+		},
+		OutputDirectory: "./images",
+	}
+	agent := agent.Agent{
+		Model: &models.OpenAIChat{
+			ApiKey: os.Getenv("OPENAI_API_KEY"),
+			Id:     "gpt-4o-mini",
+		},
+		Description: "You are an assistant that can create images.",
+		Tools:       []tools.ToolKit{imageTools},
+	}
+
+	response, err := agent.Run(context.Background(), "Create an image of a lighthouse on a cliff at sunset.")
+	if err != nil {
+		log.Fatalf("Error running agent: %v", err)
+	}
+	fmt.Println("Assistant:", response.Data)
+	for _, image := range response.Images {
+		fmt.Println("Generated image:", image.FilePath)
+	}
+}
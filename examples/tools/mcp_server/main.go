@@ -0,0 +1,54 @@
+// Command mcp_server publishes the calculator and filesystem toolkits to MCP clients (e.g., Claude Desktop or IDEs).
+//
+// Over stdio, configure the client to run the binary:
+//
+//	{"mcpServers": {"hermes": {"command": "/path/to/mcp_server", "args": ["-dir", "/path/to/files"]}}}
+//
+// Over streamable HTTP, start the server and connect the client to http://localhost:8080/mcp:
+//
+//	go run ./examples/tools/mcp_server -http :8080
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+
+	"github.com/Harsh-2909/hermes-go/tools"
+	"github.com/Harsh-2909/hermes-go/tools/mcp"
+	"github.com/Harsh-2909/hermes-go/utils"
+)
+
+func main() {
+	addr := flag.String("http", "", "address to serve HTTP on (e.g., :8080); serves over stdio if empty")
+	dir := flag.String("dir", "./files", "directory of the filesystem tools")
+	flag.Parse()
+
+	// Stdout carries the protocol messages over stdio, so logs are written to stderr
+	utils.DefaultLogger.Writer = os.Stderr
+	log.SetOutput(os.Stderr)
+
+	server := &mcp.Server{
+		ToolKits: []tools.ToolKit{
+			&tools.CalculatorTools{EnableAll: true},
+			&tools.FileSystemTools{EnableAll: true, TargetDirectory: *dir, DefaultExtension: "txt"},
+		},
+		Info:         mcp.Implementation{Name: "hermes-go-example", Version: "0.1.0"},
+		Instructions: "Calculator and file system tools. Files are read from and written to a single directory.",
+	}
+
+	if *addr != "" {
+		http.Handle("/mcp", server)
+		log.Printf("Serving MCP on http://%s/mcp", *addr)
+		log.Fatal(http.ListenAndServe(*addr, nil))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := server.ServeStdio(ctx); err != nil && err != context.Canceled {
+		log.Fatal(err)
+	}
+}
@@ -119,9 +119,10 @@ func formatMessages(messages []models.Message) ([]anthropic.MessageParam, string
 				content = append(content, anthropic.NewImageBlockBase64(mediaType, base64Content))
 			}
 
-			// Audio not supported by Anthropic yet; ignore for now
+			// Audio not supported by Anthropic yet; ignore for now.
+			// Agents can set a Transcriber to send audio to Claude as text.
 			if len(msg.Audios) > 0 {
-				utils.Logger.Warn("Audio inputs are not supported by Anthropic API; ignoring")
+				utils.Logger.Warn("Audio inputs are not supported by Anthropic API; ignoring. Set Agent.Transcriber to transcribe them")
 			}
 			anthropicMessages = append(anthropicMessages, anthropic.MessageParam{
 				Role:    anthropic.MessageParamRoleUser,
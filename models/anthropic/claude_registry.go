@@ -0,0 +1,47 @@
+package models
+
+import (
+	"github.com/Harsh-2909/hermes-go/models"
+	"github.com/Harsh-2909/hermes-go/models/registry"
+)
+
+// claudeCapabilities lists the capabilities of Claude models by model ID prefix.
+// Claude does not accept audio inputs.
+var claudeCapabilities = registry.CapabilityTable{
+	"claude-3":         {Vision: true, Tools: true, Streaming: true, StreamingToolCalls: true, ContextWindow: 200000},
+	"claude-3-5-haiku": {Tools: true, Streaming: true, StreamingToolCalls: true, ContextWindow: 200000},
+	"claude-sonnet-4":  {Vision: true, Tools: true, Streaming: true, StreamingToolCalls: true, ContextWindow: 200000},
+	"claude-opus-4":    {Vision: true, Tools: true, Streaming: true, StreamingToolCalls: true, ContextWindow: 200000},
+}
+
+// defaultClaudeCapabilities are assumed for models missing from claudeCapabilities.
+var defaultClaudeCapabilities = models.Capabilities{Vision: true, Tools: true, Streaming: true, StreamingToolCalls: true, ContextWindow: 200000}
+
+func init() {
+	registry.Register("anthropic", newClaude, claudeModelCapabilities)
+}
+
+// claudeModelCapabilities returns the capabilities of a Claude model ID.
+func claudeModelCapabilities(id string) models.Capabilities {
+	if caps, ok := claudeCapabilities.Find(id); ok {
+		return caps
+	}
+	return defaultClaudeCapabilities
+}
+
+// Capabilities returns the capabilities of the model, implementing models.CapabilityReporter.
+func (model *Claude) Capabilities() models.Capabilities {
+	return claudeModelCapabilities(model.Id)
+}
+
+// newClaude creates a Claude model for the registry.
+// BaseURL is not supported by Claude and is ignored.
+func newClaude(id string, opts registry.Options) (models.Model, error) {
+	return &Claude{
+		ApiKey:      opts.ApiKey,
+		Id:          id,
+		Temperature: opts.Temperature,
+		TopP:        opts.TopP,
+		MaxTokens:   opts.MaxTokens,
+	}, nil
+}
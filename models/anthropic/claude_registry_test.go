@@ -0,0 +1,36 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/Harsh-2909/hermes-go/models"
+	"github.com/Harsh-2909/hermes-go/models/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestClaudeRegistry tests creating Claude models from "anthropic:<model>" strings.
+func TestClaudeRegistry(t *testing.T) {
+	model, err := registry.New("anthropic:claude-3-5-sonnet-latest", registry.Options{ApiKey: "test-key", MaxTokens: 1024})
+	assert.NoError(t, err)
+	claude, ok := model.(*Claude)
+	assert.True(t, ok)
+	assert.Equal(t, "claude-3-5-sonnet-latest", claude.Id)
+	assert.Equal(t, 1024, claude.MaxTokens)
+	assert.True(t, claude.isInit)
+
+	caps, err := registry.Capabilities("anthropic:claude-3-5-haiku-latest")
+	assert.NoError(t, err)
+	assert.False(t, caps.Vision)
+	assert.Equal(t, 200000, caps.ContextWindow)
+}
+
+// TestClaudeCapabilities tests that Claude reports no audio support, so that the Agent can transcribe audio.
+func TestClaudeCapabilities(t *testing.T) {
+	var reporter models.CapabilityReporter = &Claude{Id: "claude-3-5-sonnet-latest"}
+	caps := reporter.Capabilities()
+	assert.True(t, caps.Vision)
+	assert.False(t, caps.Audio)
+
+	caps = (&Claude{Id: "claude-future-model"}).Capabilities()
+	assert.True(t, caps.Tools, "Unknown models should use the default capabilities")
+}
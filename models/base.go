@@ -31,9 +31,27 @@ type ModelResponse struct {
 	Data      string           // Response content or chunk data
 	Usage     *Usage           // Token usage metrics, typically set for "complete" or "end" events; nullable
 	CreatedAt time.Time        // Timestamp when the response was generated
-	Audio     []byte           // Optional audio data, if supported by the model
+	Audio     *AudioResponse   // Optional audio output, if supported by the model; nullable
 	Thinking  string           // Optional intermediate reasoning or thoughts, if provided
 	ToolCalls []tools.ToolCall // Optional tool calls to execute, if provided by the model
+	Images    []*Image         // Optional images generated during an agent run (e.g., by image generation tools)
+	Metrics   *Metrics         // Metrics of the agent run, set by the Agent for "complete" or "end" events; nullable
+}
+
+// Metrics captures statistics of an agent run.
+type Metrics struct {
+	ToolCalls     int // Number of tool calls requested by the model
+	ToolCacheHits int // Number of tool calls answered from the tool cache without executing the tool
+}
+
+// AudioResponse holds audio generated by a model.
+// For streaming, each "audio_chunk" event carries a partial Data and Transcript that must be concatenated by the caller.
+type AudioResponse struct {
+	ID         string    // Provider ID of the audio, used to reference it in follow-up turns
+	Data       []byte    // Raw audio bytes in Format
+	Format     string    // Audio format (e.g., "wav", "mp3", "pcm16")
+	Transcript string    // Text transcript of the audio
+	ExpiresAt  time.Time // Time after which the provider no longer keeps the audio for follow-up turns; zero if unknown
 }
 
 // Media represents a media object (e.g., text, image, audio) that can be processed by AI models.
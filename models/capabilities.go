@@ -0,0 +1,20 @@
+// Package models defines shared types and interfaces for AI model interactions.
+package models
+
+// Capabilities describes the features supported by a model.
+type Capabilities struct {
+	Vision             bool // Accepts image inputs
+	Audio              bool // Accepts audio inputs
+	Tools              bool // Supports tool calling
+	StructuredOutput   bool // Supports responses constrained to a JSON schema
+	Streaming          bool // Supports streaming responses
+	StreamingToolCalls bool // Supports tool calls in streaming responses
+	ContextWindow      int  // Maximum number of tokens in the context window; 0 if unknown
+}
+
+// CapabilityReporter is an optional interface for models which can report their capabilities.
+// The Agent uses it to validate inputs and tools before sending them to the model,
+// instead of the model silently dropping unsupported inputs.
+type CapabilityReporter interface {
+	Capabilities() Capabilities // Capabilities of the configured model
+}
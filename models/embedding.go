@@ -0,0 +1,170 @@
+// Package models defines shared types and interfaces for AI model interactions.
+package models
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Embedder defines the interface for embedding models, which convert texts to vectors.
+// Implementations must support initialization and embedding any number of texts,
+// splitting them into batches of at most MaxBatchSize texts.
+type Embedder interface {
+	Init()                                                                 // Initialize the model with defaults and validate configuration
+	Embed(ctx context.Context, texts []string) ([][]float32, Usage, error) // Embed the texts, returning one vector per text in the same order
+	Dimensions() int                                                       // Number of dimensions of the vectors; 0 if not known yet
+	MaxBatchSize() int                                                     // Maximum number of texts sent in a single request
+}
+
+// EmbedBatchFunc embeds a single batch of texts, returning one vector per text in the same order.
+type EmbedBatchFunc func(ctx context.Context, texts []string) ([][]float32, Usage, error)
+
+// EmbedInBatches splits texts into batches of at most batchSize texts and embeds them with embedBatch,
+// running up to concurrency batches at the same time. The vectors are returned in the order of texts,
+// with the usage summed over all batches. The first error cancels the remaining batches and is returned.
+func EmbedInBatches(ctx context.Context, texts []string, batchSize, concurrency int, embedBatch EmbedBatchFunc) ([][]float32, Usage, error) {
+	if len(texts) == 0 {
+		return [][]float32{}, Usage{}, nil
+	}
+	if batchSize < 1 {
+		batchSize = len(texts)
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	vectors := make([][]float32, len(texts))
+	var usage Usage
+	var firstErr error
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, concurrency)
+
+	for start := 0; start < len(texts); start += batchSize {
+		end := min(start+batchSize, len(texts))
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			batchVectors, batchUsage, err := embedBatch(ctx, texts[start:end])
+			if err == nil && len(batchVectors) != end-start {
+				err = fmt.Errorf("expected %d embeddings, got %d", end-start, len(batchVectors))
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
+			}
+			copy(vectors[start:end], batchVectors)
+			usage.PromptTokens += batchUsage.PromptTokens
+			usage.CompletionTokens += batchUsage.CompletionTokens
+			usage.TotalTokens += batchUsage.TotalTokens
+		}(start, end)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, Usage{}, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, Usage{}, err
+	}
+	return vectors, usage, nil
+}
+
+// HashEmbedder is a deterministic Embedder for tests which requires no API.
+// Each word of a text is hashed into one of Dims dimensions, so texts sharing words have similar vectors.
+// Vectors are L2-normalized; texts without words get a zero vector.
+type HashEmbedder struct {
+	Dims      int // Number of dimensions of the vectors. Defaults to 64
+	BatchSize int // Maximum number of texts per batch. Defaults to 100
+
+	isInit bool // Internal flag to track initialization
+}
+
+// Init initializes the HashEmbedder with defaults.
+func (e *HashEmbedder) Init() {
+	if e.isInit {
+		return
+	}
+	if e.Dims <= 0 {
+		e.Dims = 64
+	}
+	if e.BatchSize <= 0 {
+		e.BatchSize = 100
+	}
+	e.isInit = true
+}
+
+// Dimensions returns the number of dimensions of the vectors.
+func (e *HashEmbedder) Dimensions() int {
+	e.Init()
+	return e.Dims
+}
+
+// MaxBatchSize returns the maximum number of texts per batch.
+func (e *HashEmbedder) MaxBatchSize() int {
+	e.Init()
+	return e.BatchSize
+}
+
+// Embed returns a deterministic vector for each text. Usage counts one prompt token per word.
+func (e *HashEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, Usage, error) {
+	e.Init()
+	return EmbedInBatches(ctx, texts, e.BatchSize, 1, func(ctx context.Context, texts []string) ([][]float32, Usage, error) {
+		var usage Usage
+		vectors := make([][]float32, len(texts))
+		for i, text := range texts {
+			vector := make([]float32, e.Dims)
+			words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+				return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+			})
+			for _, word := range words {
+				h := fnv.New64a()
+				h.Write([]byte(word))
+				sum := h.Sum64()
+				// Use the lowest bit as sign to spread words over both directions
+				if sum&1 == 0 {
+					vector[(sum>>1)%uint64(e.Dims)]++
+				} else {
+					vector[(sum>>1)%uint64(e.Dims)]--
+				}
+			}
+			var norm float64
+			for _, v := range vector {
+				norm += float64(v * v)
+			}
+			if norm > 0 {
+				norm = math.Sqrt(norm)
+				for j := range vector {
+					vector[j] = float32(float64(vector[j]) / norm)
+				}
+			}
+			vectors[i] = vector
+			usage.PromptTokens += len(words)
+			usage.TotalTokens += len(words)
+		}
+		return vectors, usage, nil
+	})
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbedInBatches(t *testing.T) {
+	texts := []string{"a", "b", "c", "d", "e"}
+
+	var calls, running, maxRunning int32
+	embedBatch := func(ctx context.Context, batch []string) ([][]float32, Usage, error) {
+		atomic.AddInt32(&calls, 1)
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			prev := atomic.LoadInt32(&maxRunning)
+			if current <= prev || atomic.CompareAndSwapInt32(&maxRunning, prev, current) {
+				break
+			}
+		}
+		vectors := make([][]float32, len(batch))
+		for i, text := range batch {
+			vectors[i] = []float32{float32(text[0])}
+		}
+		return vectors, Usage{PromptTokens: len(batch), TotalTokens: len(batch)}, nil
+	}
+
+	vectors, usage, err := EmbedInBatches(context.Background(), texts, 2, 2, embedBatch)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{{'a'}, {'b'}, {'c'}, {'d'}, {'e'}}, vectors, "vectors should keep the order of texts")
+	assert.Equal(t, Usage{PromptTokens: 5, TotalTokens: 5}, usage)
+	assert.Equal(t, int32(3), calls, "5 texts in batches of 2 should take 3 calls")
+	assert.LessOrEqual(t, maxRunning, int32(2), "concurrency should be bounded")
+
+	// Empty input
+	vectors, _, err = EmbedInBatches(context.Background(), nil, 2, 2, embedBatch)
+	assert.NoError(t, err)
+	assert.Empty(t, vectors)
+}
+
+func TestEmbedInBatches_Errors(t *testing.T) {
+	texts := []string{"a", "b", "c"}
+
+	// Batch errors are returned
+	_, _, err := EmbedInBatches(context.Background(), texts, 1, 1, func(ctx context.Context, batch []string) ([][]float32, Usage, error) {
+		if batch[0] == "b" {
+			return nil, Usage{}, fmt.Errorf("rate limited")
+		}
+		return [][]float32{{1}}, Usage{}, nil
+	})
+	assert.EqualError(t, err, "rate limited")
+
+	// Mismatched number of vectors
+	_, _, err = EmbedInBatches(context.Background(), texts, 3, 1, func(ctx context.Context, batch []string) ([][]float32, Usage, error) {
+		return [][]float32{{1}}, Usage{}, nil
+	})
+	assert.EqualError(t, err, "expected 3 embeddings, got 1")
+
+	// Cancelled context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = EmbedInBatches(ctx, texts, 1, 1, func(ctx context.Context, batch []string) ([][]float32, Usage, error) {
+		return [][]float32{{1}}, Usage{}, nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// cosine returns the cosine similarity of two L2-normalized vectors.
+func cosine(a, b []float32) float64 {
+	var dot float64
+	for i := range a {
+		dot += float64(a[i] * b[i])
+	}
+	return dot
+}
+
+func TestHashEmbedder(t *testing.T) {
+	embedder := &HashEmbedder{Dims: 32, BatchSize: 2}
+	embedder.Init()
+	assert.Equal(t, 32, embedder.Dimensions())
+	assert.Equal(t, 2, embedder.MaxBatchSize())
+
+	texts := []string{"The cat sat on the mat", "the CAT sat on the mat!", "Stock markets fell sharply", ""}
+	vectors, usage, err := embedder.Embed(context.Background(), texts)
+	assert.NoError(t, err)
+	assert.Len(t, vectors, 4)
+	assert.Equal(t, 16, usage.PromptTokens)
+	for _, vector := range vectors {
+		assert.Len(t, vector, 32)
+	}
+
+	// Deterministic and case/punctuation insensitive
+	again, _, err := embedder.Embed(context.Background(), texts[:1])
+	assert.NoError(t, err)
+	assert.Equal(t, vectors[0], again[0])
+	assert.InDelta(t, 1.0, cosine(vectors[0], vectors[1]), 1e-6)
+
+	// Normalized, with related texts closer than unrelated ones
+	assert.InDelta(t, 1.0, math.Sqrt(cosine(vectors[2], vectors[2])), 1e-6)
+	assert.Greater(t, cosine(vectors[0], vectors[1]), cosine(vectors[0], vectors[2]))
+
+	// Empty text gets a zero vector
+	assert.Equal(t, make([]float32, 32), vectors[3])
+}
@@ -0,0 +1,19 @@
+// Package models defines shared types and interfaces for AI model interactions.
+package models
+
+import (
+	"context"
+
+	"github.com/Harsh-2909/hermes-go/models/media"
+)
+
+// ImageGenerationRequest describes the images to generate from a text prompt.
+// It is an alias of media.ImageGenerationRequest so that it can be used by the tools package without an import cycle.
+type ImageGenerationRequest = media.ImageGenerationRequest
+
+// ImageGenerator defines the interface for text-to-image models.
+// Implementations must support initialization and generating images from a prompt.
+type ImageGenerator interface {
+	Init()                                                                                // Initialize the model with defaults and validate configuration
+	GenerateImages(ctx context.Context, request ImageGenerationRequest) ([]*Image, error) // Generate images from the request prompt
+}
@@ -0,0 +1,12 @@
+// Package models defines shared types and interfaces for AI model interactions.
+package models
+
+import "github.com/Harsh-2909/hermes-go/models/media"
+
+// Image represents an image provided via URL, file path, or base64 content.
+// It is an alias of media.Image so that it can be used by the tools package without an import cycle.
+type Image = media.Image
+
+// Audio represents an audio file provided via URL, file path, or base64 content.
+// It is an alias of media.Audio so that it can be used by the tools package without an import cycle.
+type Audio = media.Audio
@@ -1,5 +1,5 @@
-// models/audio.go
-package models
+// models/media/audio.go
+package media
 
 import (
 	"encoding/base64"
@@ -7,6 +7,8 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path"
+	"strings"
 )
 
 // Audio represents an audio file provided via URL, file path, or base64 content.
@@ -49,3 +51,34 @@ func (a *Audio) Content() (string, error) {
 	}
 	return "", fmt.Errorf("no audio data provided")
 }
+
+// GetFormat returns the audio format (e.g., "wav", "mp3") based on the audio content.
+// If the content does not match a known signature, it falls back to the file extension of FilePath or URL.
+func (a *Audio) GetFormat() (string, error) {
+	base64Content, err := a.Content()
+	if err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(base64Content)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode base64 content: %w", err)
+	}
+	switch {
+	case len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WAVE":
+		return "wav", nil
+	case len(data) >= 3 && string(data[0:3]) == "ID3":
+		return "mp3", nil
+	case len(data) >= 2 && data[0] == 0xFF && data[1]&0xE0 == 0xE0:
+		// MPEG audio frame sync without an ID3 header
+		return "mp3", nil
+	}
+	for _, name := range []string{a.FilePath, a.URL} {
+		switch strings.ToLower(strings.TrimPrefix(path.Ext(name), ".")) {
+		case "wav", "wave":
+			return "wav", nil
+		case "mp3", "mpeg":
+			return "mp3", nil
+		}
+	}
+	return "", fmt.Errorf("unsupported audio format")
+}
@@ -1,5 +1,5 @@
-// models/audio_test.go
-package models
+// models/media/audio_test.go
+package media
 
 import (
 	"encoding/base64"
@@ -132,3 +132,56 @@ func TestAudio_Content_BadResponse(t *testing.T) {
 	_, err := audio.Content()
 	assert.Error(t, err, "Audio.Content() expected error for bad response, got nil")
 }
+
+func TestAudio_GetFormat(t *testing.T) {
+	wavHeader := append([]byte("RIFF\x24\x00\x00\x00WAVE"), []byte("fmt ")...)
+	tests := []struct {
+		name    string
+		audio   *Audio
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "WAV signature",
+			audio: &Audio{Base64: base64.StdEncoding.EncodeToString(wavHeader)},
+			want:  "wav",
+		},
+		{
+			name:  "MP3 with ID3 tag",
+			audio: &Audio{Base64: base64.StdEncoding.EncodeToString([]byte("ID3\x04\x00\x00\x00\x00"))},
+			want:  "mp3",
+		},
+		{
+			name:  "MP3 frame sync",
+			audio: &Audio{Base64: base64.StdEncoding.EncodeToString([]byte{0xFF, 0xFB, 0x90, 0x64})},
+			want:  "mp3",
+		},
+		{
+			name:  "Fallback to URL extension",
+			audio: &Audio{URL: "http://example.com/speech.MP3", Base64: base64.StdEncoding.EncodeToString([]byte("unknown"))},
+			want:  "mp3",
+		},
+		{
+			name:    "Unknown format",
+			audio:   &Audio{Base64: base64.StdEncoding.EncodeToString([]byte("unknown"))},
+			wantErr: true,
+		},
+		{
+			name:    "No data",
+			audio:   &Audio{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.audio.GetFormat()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,4 @@
+// Package media defines media types (e.g., images, audio) exchanged with AI models and tools.
+// It has no dependencies on other hermes-go packages so that both models and tools can use it.
+// The types are re-exported by the models package, e.g. models.Image is media.Image.
+package media
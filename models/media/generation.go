@@ -0,0 +1,9 @@
+package media
+
+// ImageGenerationRequest describes the images to generate from a text prompt.
+type ImageGenerationRequest struct {
+	Prompt  string // Required text description of the desired images
+	Size    string // Size of the images (e.g., "1024x1024"). Uses the model default if empty
+	Count   int    // Number of images to generate. Defaults to 1
+	Quality string // Quality of the images (e.g., "standard", "hd"). Uses the model default if empty
+}
@@ -1,5 +1,5 @@
-// models/image.go
-package models
+// models/media/image.go
+package media
 
 import (
 	"encoding/base64"
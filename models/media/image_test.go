@@ -1,5 +1,5 @@
-// models/image_test.go
-package models
+// models/media/image_test.go
+package media
 
 import (
 	"encoding/base64"
@@ -0,0 +1,147 @@
+// Package models provides implementations of the model interfaces for Ollama.
+package models
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/Harsh-2909/hermes-go/models"
+	"github.com/Harsh-2909/hermes-go/utils"
+)
+
+// OllamaEmbedder implements the Embedder interface for a local or remote Ollama server.
+type OllamaEmbedder struct {
+	Id string // Required model ID (e.g., "nomic-embed-text", "mxbai-embed-large")
+	// Host is the base URL of the Ollama server. If not provided, it will be fetched from the
+	// environment variable `OLLAMA_HOST`, defaulting to "http://localhost:11434".
+	Host string
+	// Dims is the number of dimensions of the model's vectors.
+	// If 0, it is set from the first response as Ollama does not expose it ahead of time.
+	Dims        int
+	BatchSize   int    // Maximum number of texts per request. Defaults to 64
+	Concurrency int    // Maximum number of concurrent requests for inputs larger than BatchSize. Defaults to 2
+	KeepAlive   string // How long the model stays loaded after a request (e.g., "5m"). Uses the server default if empty
+
+	// Internal fields
+
+	httpClient *http.Client // Internal HTTP client
+	mu         sync.Mutex   // Guards Dims when learned from responses
+	isInit     bool         // Internal flag to track initialization
+}
+
+// ollamaEmbedRequest is the request body of Ollama's /api/embed endpoint.
+type ollamaEmbedRequest struct {
+	Model     string   `json:"model"`
+	Input     []string `json:"input"`
+	KeepAlive string   `json:"keep_alive,omitempty"`
+}
+
+// ollamaEmbedResponse is the response body of Ollama's /api/embed endpoint.
+type ollamaEmbedResponse struct {
+	Embeddings      [][]float32 `json:"embeddings"`
+	PromptEvalCount int         `json:"prompt_eval_count"`
+	Error           string      `json:"error"`
+}
+
+// Init initializes the OllamaEmbedder instance with defaults and validates required fields.
+// It panics if Id is missing.
+func (model *OllamaEmbedder) Init() {
+	if model.isInit {
+		return
+	}
+	if model.Id == "" {
+		panic("OllamaEmbedder must have a model ID")
+	}
+	model.Host = utils.FirstNonEmpty(model.Host, os.Getenv("OLLAMA_HOST"), "http://localhost:11434")
+	if !strings.HasPrefix(model.Host, "http://") && !strings.HasPrefix(model.Host, "https://") {
+		model.Host = "http://" + model.Host
+	}
+	model.Host = strings.TrimRight(model.Host, "/")
+	if model.BatchSize <= 0 {
+		model.BatchSize = 64
+	}
+	if model.Concurrency <= 0 {
+		model.Concurrency = 2
+	}
+	if model.httpClient == nil {
+		model.httpClient = http.DefaultClient
+	}
+	model.isInit = true
+}
+
+// Dimensions returns the number of dimensions of the vectors, or 0 if not known yet.
+func (model *OllamaEmbedder) Dimensions() int {
+	model.mu.Lock()
+	defer model.mu.Unlock()
+	return model.Dims
+}
+
+// MaxBatchSize returns the maximum number of texts per request.
+func (model *OllamaEmbedder) MaxBatchSize() int {
+	model.Init()
+	return model.BatchSize
+}
+
+// Embed embeds the texts, splitting them into concurrent requests of at most BatchSize texts.
+// The embedder is initialized if Init was not called.
+func (model *OllamaEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, models.Usage, error) {
+	model.Init()
+	return models.EmbedInBatches(ctx, texts, model.BatchSize, model.Concurrency, model.embedBatch)
+}
+
+// embedBatch embeds a single batch of texts with one request.
+func (model *OllamaEmbedder) embedBatch(ctx context.Context, texts []string) ([][]float32, models.Usage, error) {
+	body, err := json.Marshal(ollamaEmbedRequest{
+		Model:     model.Id,
+		Input:     texts,
+		KeepAlive: model.KeepAlive,
+	})
+	if err != nil {
+		return nil, models.Usage{}, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, model.Host+"/api/embed", bytes.NewReader(body))
+	if err != nil {
+		return nil, models.Usage{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := model.httpClient.Do(req)
+	if err != nil {
+		utils.Logger.Error("Failed to create embeddings", "model", model.Id, "error", err)
+		return nil, models.Usage{}, fmt.Errorf("failed to create embeddings with model %s: %w", model.Id, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, models.Usage{}, fmt.Errorf("failed to read response: %w", err)
+	}
+	var embedResp ollamaEmbedResponse
+	if err := json.Unmarshal(data, &embedResp); err != nil {
+		return nil, models.Usage{}, fmt.Errorf("failed to decode response (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || embedResp.Error != "" {
+		utils.Logger.Error("Failed to create embeddings", "model", model.Id, "status", resp.StatusCode, "error", embedResp.Error)
+		return nil, models.Usage{}, fmt.Errorf("failed to create embeddings with model %s: status %d: %s", model.Id, resp.StatusCode, embedResp.Error)
+	}
+
+	if len(embedResp.Embeddings) > 0 {
+		model.mu.Lock()
+		if model.Dims == 0 {
+			model.Dims = len(embedResp.Embeddings[0])
+		}
+		model.mu.Unlock()
+	}
+	usage := models.Usage{
+		PromptTokens: embedResp.PromptEvalCount,
+		TotalTokens:  embedResp.PromptEvalCount,
+	}
+	return embedResp.Embeddings, usage, nil
+}
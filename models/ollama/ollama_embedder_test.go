@@ -0,0 +1,80 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Harsh-2909/hermes-go/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOllamaEmbedderInit tests the initialization of the OllamaEmbedder struct.
+func TestOllamaEmbedderInit(t *testing.T) {
+	assert.Panics(t, func() {
+		embedder := &OllamaEmbedder{}
+		embedder.Init()
+	}, "should panic when Id is missing")
+
+	t.Setenv("OLLAMA_HOST", "")
+	embedder := &OllamaEmbedder{Id: "nomic-embed-text"}
+	embedder.Init()
+	assert.Equal(t, "http://localhost:11434", embedder.Host)
+	assert.Equal(t, 64, embedder.MaxBatchSize())
+
+	t.Setenv("OLLAMA_HOST", "ollama.internal:11434/")
+	embedder = &OllamaEmbedder{Id: "nomic-embed-text"}
+	embedder.Init()
+	assert.Equal(t, "http://ollama.internal:11434", embedder.Host)
+}
+
+// TestOllamaEmbedderEmbed tests batched embedding requests with a mocked HTTP response.
+func TestOllamaEmbedderEmbed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/embed" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var req ollamaEmbedRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "nomic-embed-text", req.Model)
+		assert.Equal(t, "10m", req.KeepAlive)
+
+		embeddings := make([][]float32, len(req.Input))
+		for i, text := range req.Input {
+			embeddings[i] = []float32{float32(len(text)), 0}
+		}
+		json.NewEncoder(w).Encode(ollamaEmbedResponse{Embeddings: embeddings, PromptEvalCount: 2 * len(req.Input)})
+	}))
+	defer server.Close()
+
+	embedder := &OllamaEmbedder{Id: "nomic-embed-text", Host: server.URL, BatchSize: 2, KeepAlive: "10m"}
+	embedder.Init()
+	assert.Equal(t, 0, embedder.Dimensions(), "dimensions are unknown before the first request")
+
+	vectors, usage, err := embedder.Embed(context.Background(), []string{"a", "bb", "ccc"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 0}, {2, 0}, {3, 0}}, vectors)
+	assert.Equal(t, models.Usage{PromptTokens: 6, TotalTokens: 6}, usage)
+	assert.Equal(t, 2, embedder.Dimensions())
+}
+
+// TestOllamaEmbedderError tests that server errors are returned.
+func TestOllamaEmbedderError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": "model \"missing\" not found, try pulling it first"}`)
+	}))
+	defer server.Close()
+
+	embedder := &OllamaEmbedder{Id: "missing", Host: server.URL}
+	embedder.Init()
+	_, _, err := embedder.Embed(context.Background(), []string{"hello"})
+	assert.ErrorContains(t, err, "not found, try pulling it first")
+
+	// Embed initializes the embedder if Init was not called
+	_, _, err = (&OllamaEmbedder{Id: "missing", Host: server.URL}).Embed(context.Background(), []string{"hello"})
+	assert.ErrorContains(t, err, "not found, try pulling it first")
+}
@@ -0,0 +1,209 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Harsh-2909/hermes-go/models"
+	"github.com/Harsh-2909/hermes-go/utils"
+	"github.com/sashabaranov/go-openai"
+)
+
+// OpenAITranscriber implements the Transcriber interface for OpenAI's speech-to-text API (e.g., Whisper).
+type OpenAITranscriber struct {
+	ApiKey      string  // Required OpenAI API key. If not provided, it will be fetched from the environment variable `OPENAI_API_KEY`.
+	Id          string  // Model ID (e.g., "whisper-1", "gpt-4o-transcribe"). Defaults to "whisper-1"
+	BaseURL     string  // Base URL of the API. Defaults to "https://api.openai.com/v1"
+	Language    string  // Optional ISO-639-1 language of the audio (e.g., "en"). Improves accuracy and latency. Ignored when Translate is set
+	Prompt      string  // Optional text to guide the model's style or continue a previous audio segment
+	Temperature float32 // In [0,1] range. Higher values -> more random.
+	// Translate translates the audio into English instead of transcribing it in its original language.
+	// Only supported by "whisper-1".
+	Translate bool
+
+	// Internal fields
+
+	client *openai.Client // Internal OpenAI API client
+	isInit bool           // Internal flag to track initialization
+}
+
+// Init initializes the OpenAITranscriber instance with defaults and validates required fields.
+// It panics if ApiKey is missing.
+func (model *OpenAITranscriber) Init() {
+	if model.isInit {
+		return
+	}
+	model.ApiKey = utils.FirstNonEmpty(model.ApiKey, os.Getenv("OPENAI_API_KEY"))
+	if model.ApiKey == "" {
+		panic("OpenAITranscriber must have an API key")
+	}
+	if model.Id == "" {
+		model.Id = openai.Whisper1
+	}
+	if model.Temperature < 0 || model.Temperature > 1 {
+		model.Temperature = 0
+	}
+
+	config := openai.DefaultConfig(model.ApiKey)
+	if model.BaseURL != "" {
+		config.BaseURL = model.BaseURL
+	}
+	model.BaseURL = config.BaseURL
+	model.client = openai.NewClientWithConfig(config)
+	model.isInit = true
+}
+
+// audioFilename returns a filename for the audio with an extension matching its format.
+// The OpenAI API uses the extension to determine how to decode the audio.
+func audioFilename(audio *models.Audio) string {
+	if format, err := audio.GetFormat(); err == nil {
+		return "audio." + format
+	}
+	for _, name := range []string{audio.FilePath, audio.URL} {
+		if path.Ext(name) != "" {
+			return path.Base(name)
+		}
+	}
+	return "audio.mp3"
+}
+
+// Transcribe converts the speech in the audio to text.
+// Segments, Language and Duration are only set for models supporting verbose output (e.g., "whisper-1").
+func (model *OpenAITranscriber) Transcribe(ctx context.Context, audio *models.Audio) (models.Transcription, error) {
+	base64Content, err := audio.Content()
+	if err != nil {
+		return models.Transcription{}, fmt.Errorf("failed to get audio content: %w", err)
+	}
+	data, err := base64.StdEncoding.DecodeString(base64Content)
+	if err != nil {
+		return models.Transcription{}, fmt.Errorf("failed to decode base64 content: %w", err)
+	}
+
+	request := openai.AudioRequest{
+		Model:       model.Id,
+		FilePath:    audioFilename(audio),
+		Reader:      bytes.NewReader(data),
+		Prompt:      model.Prompt,
+		Temperature: model.Temperature,
+		Format:      openai.AudioResponseFormatJSON,
+	}
+	// Only Whisper models support the verbose format with segments and language
+	if strings.HasPrefix(model.Id, "whisper") {
+		request.Format = openai.AudioResponseFormatVerboseJSON
+	}
+
+	var resp openai.AudioResponse
+	if model.Translate {
+		resp, err = model.client.CreateTranslation(ctx, request)
+	} else {
+		request.Language = model.Language
+		resp, err = model.client.CreateTranscription(ctx, request)
+	}
+	if err != nil {
+		utils.Logger.Error("Failed to transcribe audio", "model", model.Id, "error", err)
+		return models.Transcription{}, fmt.Errorf("failed to transcribe audio with model %s: %w", model.Id, err)
+	}
+
+	transcription := models.Transcription{
+		Text:     strings.TrimSpace(resp.Text),
+		Language: resp.Language,
+		Duration: secondsToDuration(resp.Duration),
+	}
+	for _, segment := range resp.Segments {
+		transcription.Segments = append(transcription.Segments, models.TranscriptionSegment{
+			Start: secondsToDuration(segment.Start),
+			End:   secondsToDuration(segment.End),
+			Text:  strings.TrimSpace(segment.Text),
+		})
+	}
+	return transcription, nil
+}
+
+// secondsToDuration converts fractional seconds returned by the API to a time.Duration.
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// OpenAISpeechSynthesizer implements the SpeechSynthesizer interface for OpenAI's text-to-speech API.
+type OpenAISpeechSynthesizer struct {
+	ApiKey  string  // Required OpenAI API key. If not provided, it will be fetched from the environment variable `OPENAI_API_KEY`.
+	Id      string  // Model ID (e.g., "tts-1", "tts-1-hd"). Defaults to "tts-1"
+	BaseURL string  // Base URL of the API. Defaults to "https://api.openai.com/v1"
+	Voice   string  // Voice used for the audio (e.g., "alloy", "echo", "nova"). Defaults to "alloy"
+	Format  string  // Audio format: "mp3", "opus", "aac", "flac", "wav" or "pcm". Defaults to "mp3"
+	Speed   float64 // In [0.25,4] range. Defaults to 1.0
+
+	// Internal fields
+
+	client *openai.Client // Internal OpenAI API client
+	isInit bool           // Internal flag to track initialization
+}
+
+// Init initializes the OpenAISpeechSynthesizer instance with defaults and validates required fields.
+// It panics if ApiKey is missing.
+func (model *OpenAISpeechSynthesizer) Init() {
+	if model.isInit {
+		return
+	}
+	model.ApiKey = utils.FirstNonEmpty(model.ApiKey, os.Getenv("OPENAI_API_KEY"))
+	if model.ApiKey == "" {
+		panic("OpenAISpeechSynthesizer must have an API key")
+	}
+	if model.Id == "" {
+		model.Id = string(openai.TTSModel1)
+	}
+	if model.Voice == "" {
+		model.Voice = string(openai.VoiceAlloy)
+	}
+	if model.Format == "" {
+		model.Format = string(openai.SpeechResponseFormatMp3)
+	}
+	if model.Speed < 0.25 || model.Speed > 4 {
+		model.Speed = 1.0
+	}
+
+	config := openai.DefaultConfig(model.ApiKey)
+	if model.BaseURL != "" {
+		config.BaseURL = model.BaseURL
+	}
+	model.BaseURL = config.BaseURL
+	model.client = openai.NewClientWithConfig(config)
+	model.isInit = true
+}
+
+// Synthesize converts the text to speech audio.
+// The returned AudioResponse holds the raw audio bytes in Format and the input text as its Transcript.
+func (model *OpenAISpeechSynthesizer) Synthesize(ctx context.Context, text string) (*models.AudioResponse, error) {
+	if text == "" {
+		return nil, fmt.Errorf("no text provided")
+	}
+	resp, err := model.client.CreateSpeech(ctx, openai.CreateSpeechRequest{
+		Model:          openai.SpeechModel(model.Id),
+		Input:          text,
+		Voice:          openai.SpeechVoice(model.Voice),
+		ResponseFormat: openai.SpeechResponseFormat(model.Format),
+		Speed:          model.Speed,
+	})
+	if err != nil {
+		utils.Logger.Error("Failed to synthesize speech", "model", model.Id, "error", err)
+		return nil, fmt.Errorf("failed to synthesize speech with model %s: %w", model.Id, err)
+	}
+	defer resp.Close()
+
+	data, err := io.ReadAll(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read speech audio: %w", err)
+	}
+	return &models.AudioResponse{
+		Data:       data,
+		Format:     model.Format,
+		Transcript: text,
+	}, nil
+}
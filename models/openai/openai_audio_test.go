@@ -0,0 +1,125 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Harsh-2909/hermes-go/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOpenAITranscriberInit tests the initialization of the OpenAITranscriber struct.
+func TestOpenAITranscriberInit(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "")
+	assert.Panics(t, func() {
+		model := &OpenAITranscriber{}
+		model.Init()
+	}, "should panic when ApiKey is missing")
+
+	model := &OpenAITranscriber{ApiKey: "test-key", Temperature: 2}
+	model.Init()
+	assert.Equal(t, "whisper-1", model.Id)
+	assert.Equal(t, float32(0), model.Temperature)
+	assert.NotNil(t, model.client)
+}
+
+// TestOpenAITranscriberTranscribe tests transcription and translation requests with a mocked HTTP response.
+func TestOpenAITranscriberTranscribe(t *testing.T) {
+	var gotPath string
+	var gotForm map[string][]string
+	var gotFilename string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		assert.NoError(t, r.ParseMultipartForm(1<<20))
+		gotForm = r.MultipartForm.Value
+		gotFilename = r.MultipartForm.File["file"][0].Filename
+		fmt.Fprint(w, `{
+			"task": "transcribe",
+			"language": "english",
+			"duration": 2.5,
+			"text": " Hello world. ",
+			"segments": [
+				{"id": 0, "start": 0.0, "end": 1.2, "text": " Hello"},
+				{"id": 1, "start": 1.2, "end": 2.5, "text": " world."}
+			]
+		}`)
+	}))
+	defer server.Close()
+
+	audio := &models.Audio{Base64: "SUQzBAA="}
+
+	t.Run("Transcription", func(t *testing.T) {
+		model := &OpenAITranscriber{ApiKey: "test-key", BaseURL: server.URL, Language: "en"}
+		model.Init()
+		transcription, err := model.Transcribe(context.Background(), audio)
+		assert.NoError(t, err)
+		assert.Equal(t, "/audio/transcriptions", gotPath)
+		assert.Equal(t, []string{"whisper-1"}, gotForm["model"])
+		assert.Equal(t, []string{"en"}, gotForm["language"])
+		assert.Equal(t, []string{"verbose_json"}, gotForm["response_format"])
+		assert.Equal(t, "audio.mp3", gotFilename)
+
+		assert.Equal(t, "Hello world.", transcription.Text)
+		assert.Equal(t, "english", transcription.Language)
+		assert.Equal(t, 2500*time.Millisecond, transcription.Duration)
+		assert.Equal(t, []models.TranscriptionSegment{
+			{Start: 0, End: 1200 * time.Millisecond, Text: "Hello"},
+			{Start: 1200 * time.Millisecond, End: 2500 * time.Millisecond, Text: "world."},
+		}, transcription.Segments)
+	})
+
+	t.Run("Translation", func(t *testing.T) {
+		model := &OpenAITranscriber{ApiKey: "test-key", BaseURL: server.URL, Language: "fr", Translate: true}
+		model.Init()
+		_, err := model.Transcribe(context.Background(), audio)
+		assert.NoError(t, err)
+		assert.Equal(t, "/audio/translations", gotPath)
+		assert.NotContains(t, gotForm, "language")
+	})
+
+	t.Run("Missing audio", func(t *testing.T) {
+		model := &OpenAITranscriber{ApiKey: "test-key", BaseURL: server.URL}
+		model.Init()
+		_, err := model.Transcribe(context.Background(), &models.Audio{})
+		assert.Error(t, err)
+	})
+}
+
+// TestOpenAISpeechSynthesizerSynthesize tests speech synthesis with a mocked HTTP response.
+func TestOpenAISpeechSynthesizerSynthesize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/audio/speech" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		var req map[string]interface{}
+		assert.NoError(t, json.Unmarshal(body, &req))
+		assert.Equal(t, "tts-1", req["model"])
+		assert.Equal(t, "Hello world", req["input"])
+		assert.Equal(t, "nova", req["voice"])
+		assert.Equal(t, "wav", req["response_format"])
+		assert.Equal(t, 1.0, req["speed"])
+
+		w.Header().Set("Content-Type", "audio/wav")
+		w.Write([]byte("RIFF-audio"))
+	}))
+	defer server.Close()
+
+	model := &OpenAISpeechSynthesizer{ApiKey: "test-key", BaseURL: server.URL, Voice: "nova", Format: "wav"}
+	model.Init()
+
+	audio, err := model.Synthesize(context.Background(), "Hello world")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("RIFF-audio"), audio.Data)
+	assert.Equal(t, "wav", audio.Format)
+	assert.Equal(t, "Hello world", audio.Transcript)
+
+	_, err = model.Synthesize(context.Background(), "")
+	assert.Error(t, err, "should error on empty text")
+}
@@ -5,7 +5,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/Harsh-2909/hermes-go/models"
@@ -18,6 +20,7 @@ import (
 type OpenAIChat struct {
 	ApiKey           string  // Required OpenAI API key. If not provided, it will be fetched from the environment variable `OPENAI_API_KEY`.
 	Id               string  // Required model ID (e.g., "gpt-4o-mini")
+	BaseURL          string  // Base URL of the API. Defaults to "https://api.openai.com/v1"
 	Temperature      float32 // In [0,2] range. Higher values -> more creative.
 	PresencePenalty  float32 // In [-2,2] range.
 	FrequencyPenalty float32 // In [-2,2] range.
@@ -40,11 +43,23 @@ type OpenAIChat struct {
 	// logprobs must be set to true if this parameter is used.
 	TopLogProbs int
 
+	// Audio settings for audio-capable models (e.g., "gpt-4o-audio-preview")
+
+	// Modalities lists the output types the model should generate, e.g. []string{"text", "audio"}.
+	// Audio output is only requested when "audio" is included.
+	Modalities []string
+	// AudioVoice is the voice used for audio output (e.g., "alloy", "echo", "shimmer"). Defaults to "alloy".
+	AudioVoice string
+	// AudioFormat is the format of the audio output: "wav", "mp3", "flac", "opus" or "pcm16".
+	// Defaults to "wav", or "pcm16" when streaming as it is the only format OpenAI supports for streamed audio.
+	AudioFormat string
+
 	// Internal fields
 
-	client *openai.Client // Internal OpenAI API client
-	isInit bool           // Internal flag to track initialization
-	tools  []tools.Tool   // Internal list of tools
+	client     *openai.Client // Internal OpenAI API client
+	httpClient *http.Client   // Internal HTTP client for requests not supported by the OpenAI client (e.g., audio)
+	isInit     bool           // Internal flag to track initialization
+	tools      []tools.Tool   // Internal list of tools
 }
 
 // Init initializes the OpenAIChat instance with defaults and validates required fields.
@@ -81,8 +96,19 @@ func (model *OpenAIChat) Init() {
 	if model.N < 1 {
 		model.N = 1
 	}
+	if model.AudioVoice == "" {
+		model.AudioVoice = "alloy"
+	}
 
-	model.client = openai.NewClient(model.ApiKey)
+	config := openai.DefaultConfig(model.ApiKey)
+	if model.BaseURL != "" {
+		config.BaseURL = model.BaseURL
+	}
+	model.BaseURL = config.BaseURL
+	model.client = openai.NewClientWithConfig(config)
+	if model.httpClient == nil {
+		model.httpClient = http.DefaultClient
+	}
 	model.isInit = true
 }
 
@@ -90,8 +116,37 @@ func (model *OpenAIChat) SetTools(tools []tools.Tool) {
 	model.tools = tools
 }
 
+// usesAudio reports whether a request must go through the audio chat path,
+// i.e. audio output is requested or any message carries audio input.
+func (model *OpenAIChat) usesAudio(messages []models.Message) bool {
+	if slices.Contains(model.Modalities, "audio") {
+		return true
+	}
+	for _, msg := range messages {
+		if len(msg.Audios) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// convertImageToOpenAIFormat converts an Image to an OpenAI image_url content part with a base64-encoded data URL.
+func convertImageToOpenAIFormat(img *models.Image) (openai.ChatMessagePart, error) {
+	base64Content, err := img.Content()
+	if err != nil {
+		return openai.ChatMessagePart{}, fmt.Errorf("failed to get image content: %w", err)
+	}
+	return openai.ChatMessagePart{
+		Type: "image_url",
+		ImageURL: &openai.ChatMessageImageURL{
+			URL: fmt.Sprintf("data:image/jpeg;base64,%s", base64Content),
+		},
+	}, nil
+}
+
 // convertMessageToOpenAIFormat converts a slice of Message instances to OpenAI's ChatCompletionMessage format.
 // It handles text and image content, tool calls, and tool results converting images to base64-encoded URLs.
+// Audio inputs are not supported by the OpenAI client and are skipped; use convertMessageToAudioFormat instead.
 func convertMessageToOpenAIFormat(messages []models.Message) ([]openai.ChatCompletionMessage, error) {
 	var openaiMessages []openai.ChatCompletionMessage
 	var chatMessage openai.ChatCompletionMessage
@@ -118,7 +173,7 @@ func convertMessageToOpenAIFormat(messages []models.Message) ([]openai.ChatCompl
 		}
 
 		// Handle multiple modalities
-		if len(msg.Images) > 0 || len(msg.Audios) > 0 {
+		if len(msg.Images) > 0 {
 			var contentParts []openai.ChatMessagePart
 			if msg.Content != "" {
 				contentParts = append(contentParts, openai.ChatMessagePart{
@@ -127,48 +182,21 @@ func convertMessageToOpenAIFormat(messages []models.Message) ([]openai.ChatCompl
 				})
 			}
 			for _, img := range msg.Images {
-				base64Content, err := img.Content()
 				// TODO: Why return back if only one image fails? Change this part with tests
+				part, err := convertImageToOpenAIFormat(img)
 				if err != nil {
-					return nil, fmt.Errorf("failed to get image content: %w", err)
+					return nil, err
 				}
-				contentParts = append(contentParts, openai.ChatMessagePart{
-					Type: "image_url",
-					ImageURL: &openai.ChatMessageImageURL{
-						URL: fmt.Sprintf("data:image/jpeg;base64,%s", base64Content),
-					},
-				})
-			}
-			for _, audio := range msg.Audios {
-				base64Content, err := audio.Content()
-				// TODO: Why return back if only one audio fails? Change this part with tests
-				if err != nil {
-					return nil, fmt.Errorf("failed to get audio content: %w", err)
-				}
-				contentParts = append(contentParts, openai.ChatMessagePart{
-					Type: "audio_url",
-					// sashabaranov/go-openai does not support audio input in their ChatCompletion API yet.
-					// Substituting audio with image for now.
-					// TODO: Once the support is there, fix this part of the code.
-					ImageURL: &openai.ChatMessageImageURL{
-						URL: fmt.Sprintf("data:audio/mpeg;base64,%s", base64Content),
-					},
-					// AudioURL: &openai.ChatMessageAudioURL{
-					// 	URL: fmt.Sprintf("data:audio/mpeg;base64,%s", base64Content),
-					// },
-				})
+				contentParts = append(contentParts, part)
 			}
 			chatMessage = openai.ChatCompletionMessage{
 				Role:         msg.Role,
 				MultiContent: contentParts,
 			}
 		}
-		// else {
-		// 	chatMessage = openai.ChatCompletionMessage{
-		// 		Role:    msg.Role,
-		// 		Content: msg.Content,
-		// 	}
-		// }
+		if len(msg.Audios) > 0 {
+			utils.Logger.Warn("Audio inputs are not supported by the OpenAI client; ignoring")
+		}
 		openaiMessages = append(openaiMessages, chatMessage)
 	}
 	return openaiMessages, nil
@@ -210,6 +238,9 @@ func (model *OpenAIChat) getChatCompletionRequest(messages []openai.ChatCompleti
 // ChatCompletion sends a synchronous chat request to OpenAI and returns the response.
 // It converts input messages to OpenAI's format, makes the API call, and constructs a ModelResponse with usage data.
 func (model *OpenAIChat) ChatCompletion(ctx context.Context, messages []models.Message) (models.ModelResponse, error) {
+	if model.usesAudio(messages) {
+		return model.audioChatCompletion(ctx, messages)
+	}
 	openaiMessages, err := convertMessageToOpenAIFormat(messages)
 	if err != nil {
 		utils.Logger.Error("Failed to convert messages", "error", err)
@@ -257,6 +288,9 @@ func (model *OpenAIChat) ChatCompletion(ctx context.Context, messages []models.M
 // It emits ModelResponse events ("chunk" for content, "end" for completion, "error" for failures).
 // The caller must consume the channel to process the stream.
 func (model *OpenAIChat) ChatCompletionStream(ctx context.Context, messages []models.Message) (chan models.ModelResponse, error) {
+	if model.usesAudio(messages) {
+		return model.audioChatCompletionStream(ctx, messages)
+	}
 	openaiMessages, err := convertMessageToOpenAIFormat(messages)
 	if err != nil {
 		utils.Logger.Error("Failed to convert messages", "error", err)
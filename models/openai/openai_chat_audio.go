@@ -0,0 +1,429 @@
+package models
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Harsh-2909/hermes-go/models"
+	"github.com/Harsh-2909/hermes-go/tools"
+	"github.com/Harsh-2909/hermes-go/utils"
+	"github.com/sashabaranov/go-openai"
+)
+
+// sashabaranov/go-openai does not support audio input or output in its Chat Completion API.
+// The types below extend the client's request and message types with the missing audio fields,
+// and are sent with a plain HTTP client whenever a request involves audio.
+
+// audioChatMessagePart is a content part which can additionally carry input audio.
+type audioChatMessagePart struct {
+	openai.ChatMessagePart
+	InputAudio *inputAudio `json:"input_audio,omitempty"`
+}
+
+// inputAudio is the payload of an "input_audio" content part.
+type inputAudio struct {
+	Data   string `json:"data"`   // Base64-encoded audio
+	Format string `json:"format"` // "wav" or "mp3"
+}
+
+// audioChatMessage is a chat message with multi-part content including audio.
+type audioChatMessage struct {
+	Role    string                 `json:"role"`
+	Content []audioChatMessagePart `json:"content"`
+}
+
+// audioOutputParams configures the audio generated by the model.
+type audioOutputParams struct {
+	Voice  string `json:"voice"`
+	Format string `json:"format"`
+}
+
+// audioChatCompletionRequest is an OpenAI ChatCompletionRequest with audio support.
+// Messages shadows the embedded field so that audio messages can be mixed with regular ones.
+type audioChatCompletionRequest struct {
+	openai.ChatCompletionRequest
+	Messages   []interface{}      `json:"messages"`
+	Modalities []string           `json:"modalities,omitempty"`
+	Audio      *audioOutputParams `json:"audio,omitempty"`
+}
+
+// audioOutput is the audio returned by the model, either complete or as a streaming delta.
+type audioOutput struct {
+	ID         string `json:"id"`
+	Data       string `json:"data"` // Base64-encoded audio
+	Transcript string `json:"transcript"`
+	ExpiresAt  int64  `json:"expires_at"`
+}
+
+// audioChatCompletionResponse is the subset of a chat completion response used by the audio path.
+type audioChatCompletionResponse struct {
+	Choices []struct {
+		Message struct {
+			Content   string            `json:"content"`
+			ToolCalls []openai.ToolCall `json:"tool_calls"`
+			Audio     *audioOutput      `json:"audio"`
+		} `json:"message"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+	Usage openai.Usage `json:"usage"`
+}
+
+// audioChatCompletionChunk is the subset of a streamed chat completion chunk used by the audio path.
+type audioChatCompletionChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content   string            `json:"content"`
+			ToolCalls []openai.ToolCall `json:"tool_calls"`
+			Audio     *audioOutput      `json:"audio"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Usage *openai.Usage `json:"usage"`
+}
+
+// convertAudioToOpenAIFormat converts an Audio to an OpenAI input_audio content part with its detected format.
+func convertAudioToOpenAIFormat(audio *models.Audio) (audioChatMessagePart, error) {
+	base64Content, err := audio.Content()
+	if err != nil {
+		return audioChatMessagePart{}, fmt.Errorf("failed to get audio content: %w", err)
+	}
+	format, err := audio.GetFormat()
+	if err != nil {
+		return audioChatMessagePart{}, fmt.Errorf("failed to get audio format: %w", err)
+	}
+	return audioChatMessagePart{
+		ChatMessagePart: openai.ChatMessagePart{Type: "input_audio"},
+		InputAudio: &inputAudio{
+			Data:   base64Content,
+			Format: format,
+		},
+	}, nil
+}
+
+// convertMessageToAudioFormat converts a slice of Message instances to OpenAI's message format with audio support.
+// Messages with audio inputs are converted to multi-part messages with input_audio parts,
+// all other messages are converted with convertMessageToOpenAIFormat.
+func convertMessageToAudioFormat(messages []models.Message) ([]interface{}, error) {
+	var openaiMessages []interface{}
+	for _, msg := range messages {
+		if len(msg.Audios) == 0 {
+			converted, err := convertMessageToOpenAIFormat([]models.Message{msg})
+			if err != nil {
+				return nil, err
+			}
+			openaiMessages = append(openaiMessages, converted[0])
+			continue
+		}
+
+		var contentParts []audioChatMessagePart
+		if msg.Content != "" {
+			contentParts = append(contentParts, audioChatMessagePart{
+				ChatMessagePart: openai.ChatMessagePart{Type: "text", Text: msg.Content},
+			})
+		}
+		for _, img := range msg.Images {
+			part, err := convertImageToOpenAIFormat(img)
+			if err != nil {
+				return nil, err
+			}
+			contentParts = append(contentParts, audioChatMessagePart{ChatMessagePart: part})
+		}
+		for _, audio := range msg.Audios {
+			part, err := convertAudioToOpenAIFormat(audio)
+			if err != nil {
+				return nil, err
+			}
+			contentParts = append(contentParts, part)
+		}
+		openaiMessages = append(openaiMessages, audioChatMessage{
+			Role:    msg.Role,
+			Content: contentParts,
+		})
+	}
+	return openaiMessages, nil
+}
+
+// audioFormat returns the audio output format for a request.
+func (model *OpenAIChat) audioFormat(stream bool) string {
+	if stream {
+		// pcm16 is the only format supported for streamed audio output.
+		return "pcm16"
+	}
+	return utils.FirstNonEmpty(model.AudioFormat, "wav")
+}
+
+// getAudioChatCompletionRequest constructs a chat completion request with audio settings from the model's settings and input messages.
+func (model *OpenAIChat) getAudioChatCompletionRequest(messages []interface{}, stream bool) audioChatCompletionRequest {
+	request := audioChatCompletionRequest{
+		ChatCompletionRequest: model.getChatCompletionRequest(nil, stream),
+		Messages:              messages,
+		Modalities:            model.Modalities,
+	}
+	if stream {
+		request.StreamOptions = &openai.StreamOptions{IncludeUsage: true}
+	}
+	if slices.Contains(model.Modalities, "audio") {
+		request.Audio = &audioOutputParams{
+			Voice:  model.AudioVoice,
+			Format: model.audioFormat(stream),
+		}
+	}
+	return request
+}
+
+// sendAudioChatCompletionRequest posts a chat completion request with audio support and returns the HTTP response.
+// The caller must close the response body.
+func (model *OpenAIChat) sendAudioChatCompletionRequest(ctx context.Context, request audioChatCompletionRequest) (*http.Response, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(model.BaseURL, "/")+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+model.ApiKey)
+	if request.Stream {
+		req.Header.Set("Accept", "text/event-stream")
+	}
+
+	httpClient := model.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		var errResp openai.ErrorResponse
+		if err := json.Unmarshal(data, &errResp); err == nil && errResp.Error != nil {
+			errResp.Error.HTTPStatusCode = resp.StatusCode
+			return nil, errResp.Error
+		}
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return resp, nil
+}
+
+// convertAudioOutput converts the audio returned by the model to an AudioResponse, decoding the base64 data.
+func convertAudioOutput(audio *audioOutput, format string) (*models.AudioResponse, error) {
+	data, err := base64.StdEncoding.DecodeString(audio.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode audio data: %w", err)
+	}
+	audioResp := &models.AudioResponse{
+		ID:         audio.ID,
+		Data:       data,
+		Format:     format,
+		Transcript: audio.Transcript,
+	}
+	if audio.ExpiresAt > 0 {
+		audioResp.ExpiresAt = time.Unix(audio.ExpiresAt, 0)
+	}
+	return audioResp, nil
+}
+
+// audioChatCompletion sends a synchronous chat request with audio input and/or output and returns the response.
+// Returned audio is set in ModelResponse.Audio; if the model returned no text, its transcript is used as Data.
+func (model *OpenAIChat) audioChatCompletion(ctx context.Context, messages []models.Message) (models.ModelResponse, error) {
+	openaiMessages, err := convertMessageToAudioFormat(messages)
+	if err != nil {
+		utils.Logger.Error("Failed to convert messages", "error", err)
+		return models.ModelResponse{}, fmt.Errorf("failed to convert messages: %w", err)
+	}
+
+	httpResp, err := model.sendAudioChatCompletionRequest(ctx, model.getAudioChatCompletionRequest(openaiMessages, false))
+	if err != nil {
+		utils.Logger.Error("Failed to get chat completion", "model", model.Id, "error", err)
+		return models.ModelResponse{}, fmt.Errorf("failed to get chat completion for model %s: %w", model.Id, err)
+	}
+	defer httpResp.Body.Close()
+
+	var resp audioChatCompletionResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		utils.Logger.Error("Failed to decode chat completion", "model", model.Id, "error", err)
+		return models.ModelResponse{}, fmt.Errorf("failed to decode chat completion for model %s: %w", model.Id, err)
+	}
+	if len(resp.Choices) == 0 {
+		utils.Logger.Error("No response from model")
+		return models.ModelResponse{}, fmt.Errorf("no response from model")
+	}
+	choice := resp.Choices[0]
+	modelResp := models.ModelResponse{
+		Data:      choice.Message.Content,
+		CreatedAt: time.Now(),
+		Usage: &models.Usage{
+			PromptTokens:     resp.Usage.PromptTokens,
+			CompletionTokens: resp.Usage.CompletionTokens,
+			TotalTokens:      resp.Usage.TotalTokens,
+		},
+	}
+	if choice.Message.Audio != nil {
+		modelResp.Audio, err = convertAudioOutput(choice.Message.Audio, model.audioFormat(false))
+		if err != nil {
+			utils.Logger.Error("Failed to convert audio output", "error", err)
+			return models.ModelResponse{}, err
+		}
+		if modelResp.Data == "" {
+			modelResp.Data = modelResp.Audio.Transcript
+		}
+	}
+	if choice.FinishReason == string(openai.FinishReasonToolCalls) {
+		modelResp.Event = "tool_call"
+		for _, toolCall := range choice.Message.ToolCalls {
+			utils.Logger.Debug("Tool call received", "tool_name", toolCall.Function.Name, "arguments", toolCall.Function.Arguments)
+			modelResp.ToolCalls = append(modelResp.ToolCalls, tools.ToolCall{
+				ID:        toolCall.ID,
+				Name:      toolCall.Function.Name,
+				Arguments: toolCall.Function.Arguments,
+			})
+		}
+	} else {
+		modelResp.Event = "complete"
+	}
+	return modelResp, nil
+}
+
+// audioChatCompletionStream initiates a streaming chat request with audio input and/or output.
+// In addition to the events of ChatCompletionStream, it emits "audio_chunk" events carrying partial audio
+// data and transcript in ModelResponse.Audio.
+func (model *OpenAIChat) audioChatCompletionStream(ctx context.Context, messages []models.Message) (chan models.ModelResponse, error) {
+	openaiMessages, err := convertMessageToAudioFormat(messages)
+	if err != nil {
+		utils.Logger.Error("Failed to convert messages", "error", err)
+		return nil, fmt.Errorf("failed to convert messages: %w", err)
+	}
+
+	httpResp, err := model.sendAudioChatCompletionRequest(ctx, model.getAudioChatCompletionRequest(openaiMessages, true))
+	if err != nil {
+		utils.Logger.Error("Failed to create stream", "error", err)
+		return nil, fmt.Errorf("failed to create stream: %w", err)
+	}
+
+	ch := make(chan models.ModelResponse)
+	go func() {
+		defer close(ch)
+		defer httpResp.Body.Close()
+
+		content := ""
+		toolCalls := make(map[int]*tools.ToolCall)
+		var usage *models.Usage
+		reader := bufio.NewReader(httpResp.Body)
+		for {
+			// Lines are read without a size limit as audio deltas can be large
+			line, err := reader.ReadString('\n')
+			if err != nil && !errors.Is(err, io.EOF) {
+				ch <- models.ModelResponse{
+					Event:     "error",
+					Data:      err.Error(),
+					CreatedAt: time.Now(),
+				}
+				return
+			}
+			data, found := strings.CutPrefix(strings.TrimSpace(line), "data:")
+			data = strings.TrimSpace(data)
+			if found && data == "[DONE]" {
+				break
+			}
+			if found && data != "" {
+				var chunk audioChatCompletionChunk
+				if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+					ch <- models.ModelResponse{
+						Event:     "error",
+						Data:      fmt.Sprintf("failed to decode stream chunk: %v", err),
+						CreatedAt: time.Now(),
+					}
+					return
+				}
+				if chunk.Usage != nil {
+					usage = &models.Usage{
+						PromptTokens:     chunk.Usage.PromptTokens,
+						CompletionTokens: chunk.Usage.CompletionTokens,
+						TotalTokens:      chunk.Usage.TotalTokens,
+					}
+				}
+				if len(chunk.Choices) > 0 {
+					delta := chunk.Choices[0].Delta
+					if delta.Content != "" {
+						content += delta.Content
+						ch <- models.ModelResponse{
+							Event:     "chunk",
+							Data:      delta.Content,
+							CreatedAt: time.Now(),
+						}
+					}
+					if delta.Audio != nil {
+						audio, err := convertAudioOutput(delta.Audio, model.audioFormat(true))
+						if err != nil {
+							ch <- models.ModelResponse{
+								Event:     "error",
+								Data:      err.Error(),
+								CreatedAt: time.Now(),
+							}
+							return
+						}
+						ch <- models.ModelResponse{
+							Event:     "audio_chunk",
+							Audio:     audio,
+							CreatedAt: time.Now(),
+						}
+					}
+
+					// Accumulate tool call deltas
+					for _, tcDelta := range delta.ToolCalls {
+						index := 0
+						if tcDelta.Index != nil {
+							index = *tcDelta.Index
+						}
+						if tc, exists := toolCalls[index]; exists {
+							tc.Arguments += tcDelta.Function.Arguments
+						} else {
+							toolCalls[index] = &tools.ToolCall{
+								ID:        tcDelta.ID,
+								Name:      tcDelta.Function.Name,
+								Arguments: tcDelta.Function.Arguments,
+							}
+						}
+					}
+				}
+			}
+			if errors.Is(err, io.EOF) {
+				break
+			}
+		}
+
+		if len(toolCalls) > 0 {
+			var finalToolCalls []tools.ToolCall
+			for _, index := range slices.Sorted(maps.Keys(toolCalls)) {
+				finalToolCalls = append(finalToolCalls, *toolCalls[index])
+			}
+			ch <- models.ModelResponse{
+				Event:     "tool_call",
+				Data:      content,
+				ToolCalls: finalToolCalls,
+				CreatedAt: time.Now(),
+			}
+		}
+		ch <- models.ModelResponse{
+			Event:     "end",
+			Usage:     usage,
+			CreatedAt: time.Now(),
+		}
+	}()
+
+	return ch, nil
+}
@@ -74,8 +74,8 @@ func TestConvertMessageToOpenAIFormat(t *testing.T) {
 	messages := []models.Message{
 		{Role: "user", Content: "Hello"},
 		{Role: "assistant", Content: "Hi there"},
-		{Role: "user", Content: "Describe this image", Images: []*models.Image{{URL: "http://example.com/image.png"}}},
-		{Role: "user", Content: "Describe this image", Audios: []*models.Audio{{URL: "http://example.com/audio.wav"}}},
+		{Role: "user", Content: "Describe this image", Images: []*models.Image{{Base64: "aW1hZ2U="}}},
+		{Role: "user", Content: "Describe this audio", Audios: []*models.Audio{{Base64: "SUQzBAA="}}},
 	}
 	openaiMessages, err := convertMessageToOpenAIFormat(messages)
 	assert.NoError(t, err)
@@ -90,8 +90,37 @@ func TestConvertMessageToOpenAIFormat(t *testing.T) {
 	assert.Equal(t, "user", openaiMessages[2].Role)
 	assert.Len(t, openaiMessages[2].MultiContent, 2)
 
+	// Audio is not supported by the OpenAI client and is dropped; see convertMessageToAudioFormat
 	assert.Equal(t, "user", openaiMessages[3].Role)
-	assert.Len(t, openaiMessages[3].MultiContent, 2)
+	assert.Equal(t, "Describe this audio", openaiMessages[3].Content)
+	assert.Empty(t, openaiMessages[3].MultiContent)
+}
+
+// TestConvertMessageToAudioFormat tests the conversion of messages with audio to OpenAI's input_audio format.
+func TestConvertMessageToAudioFormat(t *testing.T) {
+	messages := []models.Message{
+		{Role: "system", Content: "Be helpful"},
+		{Role: "user", Content: "Transcribe this", Audios: []*models.Audio{{Base64: "SUQzBAA="}}},
+	}
+	openaiMessages, err := convertMessageToAudioFormat(messages)
+	assert.NoError(t, err)
+	assert.Len(t, openaiMessages, 2)
+
+	data, err := json.Marshal(openaiMessages)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"role": "system", "content": "Be helpful"},
+		{"role": "user", "content": [
+			{"type": "text", "text": "Transcribe this"},
+			{"type": "input_audio", "input_audio": {"data": "SUQzBAA=", "format": "mp3"}}
+		]}
+	]`, string(data))
+
+	// Unknown audio formats are reported as errors
+	_, err = convertMessageToAudioFormat([]models.Message{
+		{Role: "user", Audios: []*models.Audio{{Base64: "dW5rbm93bg=="}}},
+	})
+	assert.Error(t, err)
 }
 
 // TestChatCompletion tests the synchronous ChatCompletion method with a mocked HTTP response.
@@ -482,6 +511,135 @@ func TestChatCompletionStreamWithToolCalls(t *testing.T) {
 	assert.Len(t, expectedEvents, i)
 }
 
+// TestChatCompletionWithAudio tests the synchronous ChatCompletion method with audio input and output.
+func TestChatCompletionWithAudio(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/chat/completions" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+
+		// Verify the audio request options and input audio part
+		var req map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, []interface{}{"text", "audio"}, req["modalities"])
+		assert.Equal(t, map[string]interface{}{"voice": "alloy", "format": "wav"}, req["audio"])
+		parts := req["messages"].([]interface{})[0].(map[string]interface{})["content"].([]interface{})
+		assert.Len(t, parts, 1)
+		assert.Equal(t, "input_audio", parts[0].(map[string]interface{})["type"])
+		assert.Equal(t, "mp3", parts[0].(map[string]interface{})["input_audio"].(map[string]interface{})["format"])
+
+		fmt.Fprint(w, `{
+			"choices": [{
+				"index": 0,
+				"message": {
+					"role": "assistant",
+					"content": null,
+					"audio": {"id": "audio_123", "data": "UklGRg==", "transcript": "Hello there!", "expires_at": 1700000000}
+				},
+				"finish_reason": "stop"
+			}],
+			"usage": {"prompt_tokens": 10, "completion_tokens": 5, "total_tokens": 15}
+		}`)
+	}))
+	defer server.Close()
+
+	model := OpenAIChat{
+		Id:         "gpt-4o-audio-preview",
+		ApiKey:     "test-key",
+		BaseURL:    server.URL,
+		Modalities: []string{"text", "audio"},
+	}
+	model.Init()
+
+	resp, err := model.ChatCompletion(context.Background(), []models.Message{
+		{Role: "user", Audios: []*models.Audio{{Base64: "SUQzBAA="}}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "complete", resp.Event)
+	assert.Equal(t, "Hello there!", resp.Data) // Transcript is used when there is no text content
+	assert.NotNil(t, resp.Audio)
+	assert.Equal(t, "audio_123", resp.Audio.ID)
+	assert.Equal(t, []byte("RIFF"), resp.Audio.Data)
+	assert.Equal(t, "wav", resp.Audio.Format)
+	assert.Equal(t, "Hello there!", resp.Audio.Transcript)
+	assert.Equal(t, int64(1700000000), resp.Audio.ExpiresAt.Unix())
+	assert.Equal(t, 15, resp.Usage.TotalTokens)
+}
+
+// TestChatCompletionWithAudioError tests that API errors on the audio path are returned.
+func TestChatCompletionWithAudioError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error": {"message": "Invalid audio", "type": "invalid_request_error"}}`)
+	}))
+	defer server.Close()
+
+	model := OpenAIChat{Id: "gpt-4o-audio-preview", ApiKey: "test-key", BaseURL: server.URL}
+	model.Init()
+
+	_, err := model.ChatCompletion(context.Background(), []models.Message{
+		{Role: "user", Audios: []*models.Audio{{Base64: "SUQzBAA="}}},
+	})
+	assert.ErrorContains(t, err, "Invalid audio")
+}
+
+// TestChatCompletionStreamWithAudio tests the streaming ChatCompletionStream method with audio output.
+func TestChatCompletionStreamWithAudio(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, true, req["stream"])
+		assert.Equal(t, "pcm16", req["audio"].(map[string]interface{})["format"])
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			t.Fatal("ResponseWriter does not support flushing")
+		}
+		fmt.Fprint(w, `data: {"choices":[{"index":0,"delta":{"audio":{"id":"audio_1","transcript":"Hel"}}}]}`+"\n\n")
+		flusher.Flush()
+		fmt.Fprint(w, `data: {"choices":[{"index":0,"delta":{"audio":{"data":"AAEC","transcript":"lo"}}}]}`+"\n\n")
+		flusher.Flush()
+		fmt.Fprint(w, `data: {"choices":[],"usage":{"prompt_tokens":3,"completion_tokens":4,"total_tokens":7}}`+"\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+		flusher.Flush()
+	}))
+	defer server.Close()
+
+	model := OpenAIChat{
+		Id:         "gpt-4o-audio-preview",
+		ApiKey:     "test-key",
+		BaseURL:    server.URL,
+		Modalities: []string{"text", "audio"},
+	}
+	model.Init()
+
+	ch, err := model.ChatCompletionStream(context.Background(), []models.Message{{Role: "user", Content: "Say hello"}})
+	assert.NoError(t, err)
+
+	var events []string
+	transcript := ""
+	var audio []byte
+	var usage *models.Usage
+	for resp := range ch {
+		events = append(events, resp.Event)
+		if resp.Event == "audio_chunk" {
+			assert.Equal(t, "pcm16", resp.Audio.Format)
+			transcript += resp.Audio.Transcript
+			audio = append(audio, resp.Audio.Data...)
+		}
+		if resp.Event == "end" {
+			usage = resp.Usage
+		}
+	}
+	assert.Equal(t, []string{"audio_chunk", "audio_chunk", "end"}, events)
+	assert.Equal(t, "Hello", transcript)
+	assert.Equal(t, []byte{0, 1, 2}, audio)
+	assert.NotNil(t, usage)
+	assert.Equal(t, 7, usage.TotalTokens)
+}
+
 // Helper function to create a pointer to an int
 func ptr(i int) *int {
 	return &i
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Harsh-2909/hermes-go/models"
+	"github.com/Harsh-2909/hermes-go/utils"
+	"github.com/sashabaranov/go-openai"
+)
+
+// openAIEmbeddingDimensions holds the default number of dimensions of OpenAI embedding models.
+var openAIEmbeddingDimensions = map[string]int{
+	string(openai.SmallEmbedding3): 1536,
+	string(openai.LargeEmbedding3): 3072,
+	string(openai.AdaEmbeddingV2):  1536,
+}
+
+// OpenAIEmbedder implements the Embedder interface for OpenAI's Embeddings API.
+type OpenAIEmbedder struct {
+	ApiKey  string // Required OpenAI API key. If not provided, it will be fetched from the environment variable `OPENAI_API_KEY`.
+	Id      string // Model ID (e.g., "text-embedding-3-small"). Defaults to "text-embedding-3-small"
+	BaseURL string // Base URL of the API. Defaults to "https://api.openai.com/v1"
+	// Dims is the number of dimensions of the vectors. Only supported by "text-embedding-3" and later models.
+	// Uses the model default if 0.
+	Dims        int
+	BatchSize   int // Maximum number of texts per request, up to 2048. Defaults to 2048
+	Concurrency int // Maximum number of concurrent requests for inputs larger than BatchSize. Defaults to 4
+	User        string
+
+	// Internal fields
+
+	client *openai.Client // Internal OpenAI API client
+	isInit bool           // Internal flag to track initialization
+}
+
+// Init initializes the OpenAIEmbedder instance with defaults and validates required fields.
+// It panics if ApiKey is missing.
+func (model *OpenAIEmbedder) Init() {
+	if model.isInit {
+		return
+	}
+	model.ApiKey = utils.FirstNonEmpty(model.ApiKey, os.Getenv("OPENAI_API_KEY"))
+	if model.ApiKey == "" {
+		panic("OpenAIEmbedder must have an API key")
+	}
+	if model.Id == "" {
+		model.Id = string(openai.SmallEmbedding3)
+	}
+	if model.BatchSize <= 0 || model.BatchSize > 2048 {
+		model.BatchSize = 2048
+	}
+	if model.Concurrency <= 0 {
+		model.Concurrency = 4
+	}
+
+	config := openai.DefaultConfig(model.ApiKey)
+	if model.BaseURL != "" {
+		config.BaseURL = model.BaseURL
+	}
+	model.BaseURL = config.BaseURL
+	model.client = openai.NewClientWithConfig(config)
+	model.isInit = true
+}
+
+// Dimensions returns the number of dimensions of the vectors, or 0 if unknown for the model.
+func (model *OpenAIEmbedder) Dimensions() int {
+	model.Init()
+	if model.Dims > 0 {
+		return model.Dims
+	}
+	return openAIEmbeddingDimensions[model.Id]
+}
+
+// MaxBatchSize returns the maximum number of texts per request.
+func (model *OpenAIEmbedder) MaxBatchSize() int {
+	model.Init()
+	return model.BatchSize
+}
+
+// Embed embeds the texts, splitting them into concurrent requests of at most BatchSize texts.
+// The embedder is initialized if Init was not called.
+func (model *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, models.Usage, error) {
+	model.Init()
+	return models.EmbedInBatches(ctx, texts, model.BatchSize, model.Concurrency, model.embedBatch)
+}
+
+// embedBatch embeds a single batch of texts with one request.
+func (model *OpenAIEmbedder) embedBatch(ctx context.Context, texts []string) ([][]float32, models.Usage, error) {
+	resp, err := model.client.CreateEmbeddings(ctx, openai.EmbeddingRequestStrings{
+		Input:      texts,
+		Model:      openai.EmbeddingModel(model.Id),
+		Dimensions: model.Dims,
+		User:       model.User,
+	})
+	if err != nil {
+		utils.Logger.Error("Failed to create embeddings", "model", model.Id, "error", err)
+		return nil, models.Usage{}, fmt.Errorf("failed to create embeddings with model %s: %w", model.Id, err)
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, data := range resp.Data {
+		if data.Index < 0 || data.Index >= len(vectors) {
+			return nil, models.Usage{}, fmt.Errorf("embedding index %d out of range", data.Index)
+		}
+		vectors[data.Index] = data.Embedding
+	}
+	usage := models.Usage{
+		PromptTokens: resp.Usage.PromptTokens,
+		TotalTokens:  resp.Usage.TotalTokens,
+	}
+	return vectors, usage, nil
+}
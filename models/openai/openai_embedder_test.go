@@ -0,0 +1,89 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Harsh-2909/hermes-go/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOpenAIEmbedderEmbed tests batched embedding requests with a mocked HTTP response.
+func TestOpenAIEmbedderEmbed(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/embeddings" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		atomic.AddInt32(&requests, 1)
+		var req struct {
+			Input      []string `json:"input"`
+			Model      string   `json:"model"`
+			Dimensions int      `json:"dimensions"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "text-embedding-3-small", req.Model)
+		assert.Equal(t, 3, req.Dimensions)
+
+		// Return the embeddings in reverse order to check that indexes are respected
+		var data []map[string]interface{}
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			value := float32(len(req.Input[i]))
+			data = append(data, map[string]interface{}{
+				"object":    "embedding",
+				"index":     i,
+				"embedding": []float32{value, value, value},
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"object": "list",
+			"data":   data,
+			"model":  req.Model,
+			"usage":  map[string]int{"prompt_tokens": len(req.Input), "total_tokens": len(req.Input)},
+		})
+	}))
+	defer server.Close()
+
+	embedder := &OpenAIEmbedder{ApiKey: "test-key", BaseURL: server.URL, Dims: 3, BatchSize: 2}
+	embedder.Init()
+	assert.Equal(t, 3, embedder.Dimensions())
+	assert.Equal(t, 2, embedder.MaxBatchSize())
+
+	vectors, usage, err := embedder.Embed(context.Background(), []string{"a", "bb", "ccc"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 1, 1}, {2, 2, 2}, {3, 3, 3}}, vectors)
+	assert.Equal(t, models.Usage{PromptTokens: 3, TotalTokens: 3}, usage)
+	assert.Equal(t, int32(2), requests)
+}
+
+// TestOpenAIEmbedderDefaults tests the defaults of the OpenAIEmbedder.
+func TestOpenAIEmbedderDefaults(t *testing.T) {
+	embedder := &OpenAIEmbedder{ApiKey: "test-key", Id: "text-embedding-3-large", BatchSize: 5000}
+	embedder.Init()
+	assert.Equal(t, 3072, embedder.Dimensions())
+	assert.Equal(t, 2048, embedder.MaxBatchSize())
+	assert.Equal(t, 4, embedder.Concurrency)
+}
+
+// TestOpenAIEmbedderError tests that API errors are returned.
+func TestOpenAIEmbedderError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error": {"message": "Incorrect API key provided", "type": "invalid_request_error"}}`)
+	}))
+	defer server.Close()
+
+	embedder := &OpenAIEmbedder{ApiKey: "test-key", BaseURL: server.URL}
+	embedder.Init()
+	_, _, err := embedder.Embed(context.Background(), []string{"hello"})
+	assert.ErrorContains(t, err, "Incorrect API key provided")
+
+	// Embed initializes the embedder if Init was not called
+	_, _, err = (&OpenAIEmbedder{ApiKey: "test-key", BaseURL: server.URL}).Embed(context.Background(), []string{"hello"})
+	assert.ErrorContains(t, err, "Incorrect API key provided")
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Harsh-2909/hermes-go/models"
+	"github.com/Harsh-2909/hermes-go/utils"
+	"github.com/sashabaranov/go-openai"
+)
+
+// OpenAIImageGenerator implements the ImageGenerator interface for OpenAI's image generation API (e.g., DALL-E).
+type OpenAIImageGenerator struct {
+	ApiKey  string // Required OpenAI API key. If not provided, it will be fetched from the environment variable `OPENAI_API_KEY`.
+	Id      string // Model ID (e.g., "dall-e-3", "dall-e-2", "gpt-image-1"). Defaults to "dall-e-3"
+	BaseURL string // Base URL of the API. Defaults to "https://api.openai.com/v1"
+	Size    string // Default size of the images (e.g., "1024x1024"), used when a request has no size
+	Quality string // Default quality of the images (e.g., "standard", "hd"), used when a request has no quality
+	Style   string // Style of the images: "vivid" or "natural". Only supported by "dall-e-3"
+	User    string
+
+	// Internal fields
+
+	client *openai.Client // Internal OpenAI API client
+	isInit bool           // Internal flag to track initialization
+}
+
+// Init initializes the OpenAIImageGenerator instance with defaults and validates required fields.
+// It panics if ApiKey is missing.
+func (model *OpenAIImageGenerator) Init() {
+	if model.isInit {
+		return
+	}
+	model.ApiKey = utils.FirstNonEmpty(model.ApiKey, os.Getenv("OPENAI_API_KEY"))
+	if model.ApiKey == "" {
+		panic("OpenAIImageGenerator must have an API key")
+	}
+	if model.Id == "" {
+		model.Id = openai.CreateImageModelDallE3
+	}
+
+	config := openai.DefaultConfig(model.ApiKey)
+	if model.BaseURL != "" {
+		config.BaseURL = model.BaseURL
+	}
+	model.BaseURL = config.BaseURL
+	model.client = openai.NewClientWithConfig(config)
+	model.isInit = true
+}
+
+// GenerateImages generates images from the request prompt.
+// The returned images hold base64 content, except when the API only returns a URL.
+func (model *OpenAIImageGenerator) GenerateImages(ctx context.Context, request models.ImageGenerationRequest) ([]*models.Image, error) {
+	if request.Prompt == "" {
+		return nil, fmt.Errorf("no prompt provided")
+	}
+	if request.Count < 1 {
+		request.Count = 1
+	}
+	imageRequest := openai.ImageRequest{
+		Prompt:  request.Prompt,
+		Model:   model.Id,
+		N:       request.Count,
+		Size:    utils.FirstNonEmpty(request.Size, model.Size),
+		Quality: utils.FirstNonEmpty(request.Quality, model.Quality),
+		Style:   model.Style,
+		User:    model.User,
+	}
+	// GPT image models always return base64 content and reject the response_format parameter
+	if strings.HasPrefix(model.Id, "dall-e") {
+		imageRequest.ResponseFormat = openai.CreateImageResponseFormatB64JSON
+	}
+
+	resp, err := model.client.CreateImage(ctx, imageRequest)
+	if err != nil {
+		utils.Logger.Error("Failed to generate images", "model", model.Id, "error", err)
+		return nil, fmt.Errorf("failed to generate images with model %s: %w", model.Id, err)
+	}
+	if len(resp.Data) == 0 {
+		utils.Logger.Error("No images returned from model")
+		return nil, fmt.Errorf("no images returned from model")
+	}
+
+	images := make([]*models.Image, 0, len(resp.Data))
+	for _, data := range resp.Data {
+		if data.RevisedPrompt != "" {
+			utils.Logger.Debug("Image prompt revised by model", "revised_prompt", data.RevisedPrompt)
+		}
+		images = append(images, &models.Image{
+			URL:    data.URL,
+			Base64: data.B64JSON,
+		})
+	}
+	return images, nil
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Harsh-2909/hermes-go/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOpenAIImageGeneratorGenerateImages tests image generation with a mocked HTTP response.
+func TestOpenAIImageGeneratorGenerateImages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/images/generations" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var req map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "dall-e-3", req["model"])
+		assert.Equal(t, "A cat in space", req["prompt"])
+		assert.Equal(t, float64(2), req["n"])
+		assert.Equal(t, "1024x1024", req["size"])
+		assert.Equal(t, "hd", req["quality"])
+		assert.Equal(t, "b64_json", req["response_format"])
+
+		fmt.Fprint(w, `{"created": 1700000000, "data": [
+			{"b64_json": "aW1hZ2Ux", "revised_prompt": "A cat floating in space"},
+			{"b64_json": "aW1hZ2Uy"}
+		]}`)
+	}))
+	defer server.Close()
+
+	model := &OpenAIImageGenerator{ApiKey: "test-key", BaseURL: server.URL, Size: "1024x1024"}
+	model.Init()
+
+	images, err := model.GenerateImages(context.Background(), models.ImageGenerationRequest{
+		Prompt:  "A cat in space",
+		Count:   2,
+		Quality: "hd",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.Image{{Base64: "aW1hZ2Ux"}, {Base64: "aW1hZ2Uy"}}, images)
+
+	_, err = model.GenerateImages(context.Background(), models.ImageGenerationRequest{})
+	assert.Error(t, err, "should error without a prompt")
+}
+
+// TestOpenAIImageGeneratorError tests that API errors are returned.
+func TestOpenAIImageGeneratorError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error": {"message": "Your request was rejected", "type": "invalid_request_error"}}`)
+	}))
+	defer server.Close()
+
+	model := &OpenAIImageGenerator{ApiKey: "test-key", BaseURL: server.URL}
+	model.Init()
+	_, err := model.GenerateImages(context.Background(), models.ImageGenerationRequest{Prompt: "A cat"})
+	assert.ErrorContains(t, err, "Your request was rejected")
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"github.com/Harsh-2909/hermes-go/models"
+	"github.com/Harsh-2909/hermes-go/models/registry"
+)
+
+// openAICapabilities lists the capabilities of OpenAI chat models by model ID prefix.
+var openAICapabilities = registry.CapabilityTable{
+	"gpt-3.5-turbo":        {Tools: true, Streaming: true, StreamingToolCalls: true, ContextWindow: 16385},
+	"gpt-4":                {Tools: true, Streaming: true, StreamingToolCalls: true, ContextWindow: 8192},
+	"gpt-4-turbo":          {Vision: true, Tools: true, Streaming: true, StreamingToolCalls: true, ContextWindow: 128000},
+	"gpt-4o":               {Vision: true, Tools: true, StructuredOutput: true, Streaming: true, StreamingToolCalls: true, ContextWindow: 128000},
+	"gpt-4o-audio-preview": {Audio: true, Tools: true, Streaming: true, StreamingToolCalls: true, ContextWindow: 128000},
+	"gpt-4o-mini-audio":    {Audio: true, Tools: true, Streaming: true, StreamingToolCalls: true, ContextWindow: 128000},
+	"gpt-4.1":              {Vision: true, Tools: true, StructuredOutput: true, Streaming: true, StreamingToolCalls: true, ContextWindow: 1047576},
+	"gpt-5":                {Vision: true, Tools: true, StructuredOutput: true, Streaming: true, StreamingToolCalls: true, ContextWindow: 400000},
+	"o1":                   {Vision: true, Tools: true, StructuredOutput: true, Streaming: true, StreamingToolCalls: true, ContextWindow: 200000},
+	"o1-mini":              {Streaming: true, ContextWindow: 128000},
+	"o3":                   {Vision: true, Tools: true, StructuredOutput: true, Streaming: true, StreamingToolCalls: true, ContextWindow: 200000},
+	"o3-mini":              {Tools: true, StructuredOutput: true, Streaming: true, StreamingToolCalls: true, ContextWindow: 200000},
+	"o4-mini":              {Vision: true, Tools: true, StructuredOutput: true, Streaming: true, StreamingToolCalls: true, ContextWindow: 200000},
+}
+
+// defaultOpenAICapabilities are assumed for models missing from openAICapabilities (e.g., new or fine-tuned models).
+var defaultOpenAICapabilities = models.Capabilities{Vision: true, Tools: true, Streaming: true, StreamingToolCalls: true}
+
+func init() {
+	registry.Register("openai", newOpenAIChat, openAIModelCapabilities)
+}
+
+// openAIModelCapabilities returns the capabilities of an OpenAI chat model ID.
+func openAIModelCapabilities(id string) models.Capabilities {
+	if caps, ok := openAICapabilities.Find(id); ok {
+		return caps
+	}
+	return defaultOpenAICapabilities
+}
+
+// Capabilities returns the capabilities of the model, implementing models.CapabilityReporter.
+func (model *OpenAIChat) Capabilities() models.Capabilities {
+	return openAIModelCapabilities(model.Id)
+}
+
+// newOpenAIChat creates an OpenAIChat for the registry.
+func newOpenAIChat(id string, opts registry.Options) (models.Model, error) {
+	return &OpenAIChat{
+		ApiKey:              opts.ApiKey,
+		Id:                  id,
+		BaseURL:             opts.BaseURL,
+		Temperature:         opts.Temperature,
+		TopP:                opts.TopP,
+		MaxCompletionTokens: opts.MaxTokens,
+	}, nil
+}
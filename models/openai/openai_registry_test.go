@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/Harsh-2909/hermes-go/models"
+	"github.com/Harsh-2909/hermes-go/models/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOpenAIRegistry tests creating OpenAIChat models from "openai:<model>" strings.
+func TestOpenAIRegistry(t *testing.T) {
+	model, err := registry.New("openai:gpt-4o-mini", registry.Options{ApiKey: "test-key", BaseURL: "http://localhost:1234/v1", Temperature: 0.5, MaxTokens: 256})
+	assert.NoError(t, err)
+	chat, ok := model.(*OpenAIChat)
+	assert.True(t, ok)
+	assert.Equal(t, "gpt-4o-mini", chat.Id)
+	assert.Equal(t, "http://localhost:1234/v1", chat.BaseURL)
+	assert.Equal(t, float32(0.5), chat.Temperature)
+	assert.Equal(t, 256, chat.MaxCompletionTokens)
+	assert.True(t, chat.isInit)
+
+	caps, err := registry.Capabilities("openai:gpt-4o-mini")
+	assert.NoError(t, err)
+	assert.True(t, caps.Vision)
+	assert.True(t, caps.Tools)
+	assert.Equal(t, 128000, caps.ContextWindow)
+
+	caps, err = registry.Capabilities("openai:gpt-4o-audio-preview-2024-12-17")
+	assert.NoError(t, err)
+	assert.True(t, caps.Audio)
+	assert.False(t, caps.Vision)
+}
+
+// TestOpenAIChatCapabilities tests the capabilities reported by OpenAIChat.
+func TestOpenAIChatCapabilities(t *testing.T) {
+	var reporter models.CapabilityReporter = &OpenAIChat{Id: "gpt-4o-audio-preview"}
+	caps := reporter.Capabilities()
+	assert.True(t, caps.Audio)
+	assert.False(t, caps.Vision)
+
+	caps = (&OpenAIChat{Id: "ft:my-fine-tuned-model"}).Capabilities()
+	assert.True(t, caps.Tools, "Unknown models should use the default capabilities")
+}
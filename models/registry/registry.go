@@ -0,0 +1,174 @@
+// Package registry creates models from "provider:model" strings, e.g. "openai:gpt-4o-mini".
+//
+// Providers register themselves when their package is imported, similar to database/sql drivers:
+//
+//	import (
+//		"github.com/Harsh-2909/hermes-go/models/registry"
+//		_ "github.com/Harsh-2909/hermes-go/models/anthropic"
+//		_ "github.com/Harsh-2909/hermes-go/models/openai"
+//	)
+//
+//	model, err := registry.New("anthropic:claude-3-5-sonnet-latest", registry.Options{MaxTokens: 1024})
+package registry
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Harsh-2909/hermes-go/models"
+)
+
+// Options holds the provider-independent settings used to configure a model.
+// Zero values leave the model's own defaults in place; settings not supported by a provider are ignored.
+type Options struct {
+	ApiKey      string  // API key. If empty, the provider's environment variable is used (e.g., `OPENAI_API_KEY`)
+	BaseURL     string  // Base URL of the provider API
+	Temperature float32 // Sampling temperature
+	TopP        float32 // Nucleus sampling parameter
+	MaxTokens   int     // Maximum number of tokens to generate
+}
+
+// Factory creates a model with the given model ID and options.
+// The returned model is initialized by New, so factories only need to set its fields.
+type Factory func(id string, opts Options) (models.Model, error)
+
+// CapabilitiesFunc returns the capabilities of a model ID of a provider.
+type CapabilitiesFunc func(id string) models.Capabilities
+
+// provider is a registered model provider.
+type provider struct {
+	factory      Factory
+	capabilities CapabilitiesFunc
+}
+
+var (
+	mu        sync.RWMutex
+	providers = make(map[string]provider)
+)
+
+// Register makes a model provider available under the given name.
+// It panics if the name is empty, already registered or factory is nil.
+// capabilities may be nil if the provider does not know the capabilities of its models.
+func Register(name string, factory Factory, capabilities CapabilitiesFunc) {
+	mu.Lock()
+	defer mu.Unlock()
+	if name == "" || strings.Contains(name, ":") {
+		panic(fmt.Sprintf("registry: invalid provider name %q", name))
+	}
+	if factory == nil {
+		panic("registry: Register factory is nil for provider " + name)
+	}
+	if _, exists := providers[name]; exists {
+		panic("registry: Register called twice for provider " + name)
+	}
+	providers[name] = provider{factory: factory, capabilities: capabilities}
+}
+
+// Providers returns the sorted names of the registered providers.
+func Providers() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Parse splits a "provider:model" string into its provider name and model ID.
+// Only the first colon separates the two, so model IDs may contain colons (e.g., "ollama:llama3:8b").
+func Parse(spec string) (string, string, error) {
+	name, id, found := strings.Cut(strings.TrimSpace(spec), ":")
+	if !found || name == "" || id == "" {
+		return "", "", fmt.Errorf("invalid model %q: expected format \"provider:model\"", spec)
+	}
+	return strings.ToLower(name), id, nil
+}
+
+// lookup returns the registered provider for a "provider:model" string, along with the model ID.
+func lookup(spec string) (provider, string, error) {
+	name, id, err := Parse(spec)
+	if err != nil {
+		return provider{}, "", err
+	}
+	mu.RLock()
+	p, ok := providers[name]
+	mu.RUnlock()
+	if !ok {
+		return provider{}, "", fmt.Errorf("unknown model provider %q (registered: %s); is its package imported?", name, strings.Join(Providers(), ", "))
+	}
+	return p, id, nil
+}
+
+// New creates and initializes the model for a "provider:model" string (e.g., "openai:gpt-4o-mini").
+// It returns an error instead of panicking if the model configuration is invalid, e.g. a missing API key.
+func New(spec string, opts Options) (model models.Model, err error) {
+	p, id, err := lookup(spec)
+	if err != nil {
+		return nil, err
+	}
+	model, err = p.factory(id, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create model %q: %w", spec, err)
+	}
+
+	// Models panic on invalid configuration in Init; surface it as an error here.
+	defer func() {
+		if r := recover(); r != nil {
+			model = nil
+			err = fmt.Errorf("failed to initialize model %q: %v", spec, r)
+		}
+	}()
+	model.Init()
+	return model, nil
+}
+
+// MustNew is like New but panics if the model cannot be created.
+func MustNew(spec string, opts Options) models.Model {
+	model, err := New(spec, opts)
+	if err != nil {
+		panic(err)
+	}
+	return model
+}
+
+// Capabilities returns the capabilities of the model for a "provider:model" string.
+// Unknown models of a registered provider return zero Capabilities.
+func Capabilities(spec string) (models.Capabilities, error) {
+	p, id, err := lookup(spec)
+	if err != nil {
+		return models.Capabilities{}, err
+	}
+	if p.capabilities == nil {
+		return models.Capabilities{}, nil
+	}
+	return p.capabilities(id), nil
+}
+
+// CapabilityTable maps model ID prefixes to capabilities. Providers can use it to implement CapabilitiesFunc.
+type CapabilityTable map[string]models.Capabilities
+
+// Lookup returns the capabilities of the longest prefix of id in the table, or zero Capabilities if none match.
+func (t CapabilityTable) Lookup(id string) models.Capabilities {
+	caps, _ := t.Find(id)
+	return caps
+}
+
+// Find returns the capabilities of the longest prefix of id in the table, and whether any prefix matched.
+func (t CapabilityTable) Find(id string) (models.Capabilities, bool) {
+	prefixes := make([]string, 0, len(t))
+	for prefix := range t {
+		if strings.HasPrefix(id, prefix) {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	if len(prefixes) == 0 {
+		return models.Capabilities{}, false
+	}
+	longest := slices.MaxFunc(prefixes, func(a, b string) int { return len(a) - len(b) })
+	return t[longest], true
+}
@@ -0,0 +1,134 @@
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Harsh-2909/hermes-go/models"
+	"github.com/Harsh-2909/hermes-go/tools"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeModel is a minimal models.Model used to test the registry.
+type fakeModel struct {
+	id       string
+	opts     Options
+	initErr  string
+	initDone bool
+}
+
+func (m *fakeModel) Init() {
+	if m.initErr != "" {
+		panic(m.initErr)
+	}
+	m.initDone = true
+}
+func (m *fakeModel) ChatCompletion(ctx context.Context, messages []models.Message) (models.ModelResponse, error) {
+	return models.ModelResponse{}, nil
+}
+func (m *fakeModel) ChatCompletionStream(ctx context.Context, messages []models.Message) (chan models.ModelResponse, error) {
+	return nil, nil
+}
+func (m *fakeModel) SetTools(tools []tools.Tool) {}
+
+func init() {
+	Register("fake", func(id string, opts Options) (models.Model, error) {
+		m := &fakeModel{id: id, opts: opts}
+		if id == "broken" {
+			m.initErr = "fake model must have an API key"
+		}
+		return m, nil
+	}, CapabilityTable{
+		"fake-chat":        {Tools: true, Streaming: true, ContextWindow: 1000},
+		"fake-chat-vision": {Vision: true, Tools: true, Streaming: true, ContextWindow: 2000},
+	}.Lookup)
+	Register("nocaps", func(id string, opts Options) (models.Model, error) {
+		return &fakeModel{id: id}, nil
+	}, nil)
+}
+
+// TestParse tests splitting "provider:model" strings.
+func TestParse(t *testing.T) {
+	tests := []struct {
+		spec     string
+		provider string
+		id       string
+		wantErr  bool
+	}{
+		{spec: "openai:gpt-4o-mini", provider: "openai", id: "gpt-4o-mini"},
+		{spec: " Anthropic:claude-3-5-sonnet-latest ", provider: "anthropic", id: "claude-3-5-sonnet-latest"},
+		{spec: "ollama:llama3:8b", provider: "ollama", id: "llama3:8b"},
+		{spec: "gpt-4o", wantErr: true},
+		{spec: ":gpt-4o", wantErr: true},
+		{spec: "openai:", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			provider, id, err := Parse(tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.provider, provider)
+			assert.Equal(t, tt.id, id)
+		})
+	}
+}
+
+// TestNew tests creating and initializing models from the registry.
+func TestNew(t *testing.T) {
+	model, err := New("fake:fake-chat", Options{ApiKey: "key", MaxTokens: 100})
+	assert.NoError(t, err)
+	fake, ok := model.(*fakeModel)
+	assert.True(t, ok)
+	assert.Equal(t, "fake-chat", fake.id)
+	assert.Equal(t, "key", fake.opts.ApiKey)
+	assert.Equal(t, 100, fake.opts.MaxTokens)
+	assert.True(t, fake.initDone, "Model should be initialized")
+
+	_, err = New("unknown:model", Options{})
+	assert.ErrorContains(t, err, `unknown model provider "unknown"`)
+
+	_, err = New("fake:broken", Options{})
+	assert.ErrorContains(t, err, "fake model must have an API key")
+
+	_, err = New("fake-chat", Options{})
+	assert.Error(t, err)
+
+	assert.Panics(t, func() { MustNew("fake:broken", Options{}) })
+}
+
+// TestRegister tests that invalid registrations panic.
+func TestRegister(t *testing.T) {
+	factory := func(id string, opts Options) (models.Model, error) { return &fakeModel{}, nil }
+	assert.Panics(t, func() { Register("fake", factory, nil) }, "Duplicate provider should panic")
+	assert.Panics(t, func() { Register("", factory, nil) }, "Empty name should panic")
+	assert.Panics(t, func() { Register("a:b", factory, nil) }, "Name with colon should panic")
+	assert.Panics(t, func() { Register("nilfactory", nil, nil) }, "Nil factory should panic")
+	assert.Contains(t, Providers(), "fake")
+	assert.NotContains(t, Providers(), "nilfactory")
+}
+
+// TestCapabilities tests capability lookup by longest model ID prefix.
+func TestCapabilities(t *testing.T) {
+	caps, err := Capabilities("fake:fake-chat-vision-2024")
+	assert.NoError(t, err)
+	assert.Equal(t, models.Capabilities{Vision: true, Tools: true, Streaming: true, ContextWindow: 2000}, caps)
+
+	caps, err = Capabilities("fake:fake-chat-2024")
+	assert.NoError(t, err)
+	assert.False(t, caps.Vision)
+	assert.Equal(t, 1000, caps.ContextWindow)
+
+	caps, err = Capabilities("fake:other")
+	assert.NoError(t, err)
+	assert.Equal(t, models.Capabilities{}, caps)
+
+	caps, err = Capabilities("nocaps:model")
+	assert.NoError(t, err)
+	assert.Equal(t, models.Capabilities{}, caps)
+
+	_, err = Capabilities("unknown:model")
+	assert.Error(t, err)
+}
@@ -0,0 +1,36 @@
+// Package models defines shared types and interfaces for AI model interactions.
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// Transcriber defines the interface for speech-to-text models.
+// Implementations must support initialization and converting audio to text.
+type Transcriber interface {
+	Init()                                                               // Initialize the model with defaults and validate configuration
+	Transcribe(ctx context.Context, audio *Audio) (Transcription, error) // Convert the speech in the audio to text
+}
+
+// SpeechSynthesizer defines the interface for text-to-speech models.
+// Implementations must support initialization and converting text to audio.
+type SpeechSynthesizer interface {
+	Init()                                                               // Initialize the model with defaults and validate configuration
+	Synthesize(ctx context.Context, text string) (*AudioResponse, error) // Convert the text to speech audio
+}
+
+// Transcription is the text produced by a Transcriber.
+type Transcription struct {
+	Text     string                 // Full transcribed text
+	Language string                 // Detected or requested language of the audio, if provided
+	Duration time.Duration          // Duration of the audio, if provided
+	Segments []TranscriptionSegment // Timestamped segments of the transcription, if provided
+}
+
+// TranscriptionSegment is a timestamped part of a Transcription.
+type TranscriptionSegment struct {
+	Start time.Duration // Offset of the segment start from the beginning of the audio
+	End   time.Duration // Offset of the segment end from the beginning of the audio
+	Text  string        // Transcribed text of the segment
+}
@@ -0,0 +1,46 @@
+package tools
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Harsh-2909/hermes-go/models/media"
+)
+
+// artifactsKey is the context key under which the Artifacts of a run are stored.
+type artifactsKey struct{}
+
+// Artifacts collects media produced by tools during an agent run,
+// so that it can be returned to the caller alongside the model's response.
+// It is safe for concurrent use.
+type Artifacts struct {
+	mu     sync.Mutex
+	images []*media.Image
+}
+
+// WithArtifacts returns a copy of ctx carrying a new Artifacts collector, and the collector itself.
+// Tools executed with the returned context can add media with AddImages.
+func WithArtifacts(ctx context.Context) (context.Context, *Artifacts) {
+	artifacts := &Artifacts{}
+	return context.WithValue(ctx, artifactsKey{}, artifacts), artifacts
+}
+
+// AddImages adds images to the Artifacts collector of ctx.
+// It returns false if ctx carries no collector, in which case the images are dropped.
+func AddImages(ctx context.Context, images ...*media.Image) bool {
+	artifacts, ok := ctx.Value(artifactsKey{}).(*Artifacts)
+	if !ok {
+		return false
+	}
+	artifacts.mu.Lock()
+	defer artifacts.mu.Unlock()
+	artifacts.images = append(artifacts.images, images...)
+	return true
+}
+
+// Images returns the images collected so far.
+func (a *Artifacts) Images() []*media.Image {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]*media.Image(nil), a.images...)
+}
@@ -2,6 +2,7 @@ package tools
 
 import (
 	"context"
+	"time"
 )
 
 // Tool represents a single tool that the agent can call.
@@ -11,6 +12,8 @@ type Tool struct {
 	Description string                                                 // Description for the model to understand the tool's purpose
 	Parameters  map[string]interface{}                                 // JSON Schema for tool parameters
 	Execute     func(ctx context.Context, args string) (string, error) // Function to execute the tool
+	Timeout     time.Duration                                          // Maximum duration of a tool call; 0 uses the agent's default
+	CacheTTL    time.Duration                                          // Duration for which results are cached in the agent's ToolCache; 0 disables caching
 }
 
 // Tools returns a list of tools containing only the tool itself.
@@ -33,9 +36,17 @@ type ToolCall struct {
 	ID        string // Unique ID for the tool call (used in OpenAI's API)
 	Name      string // Name of the tool to call
 	Arguments string // JSON-encoded arguments for the tool
+	Cached    bool   // True if the result was served from the agent's ToolCache instead of executing the tool
 }
 
 // ToolKit is an interface for structs that provide multiple tools.
 type ToolKit interface {
 	Tools() []Tool // Returns a list of tools provided by the struct
 }
+
+// DynamicToolKit is a ToolKit whose tools can change over time (e.g., the tools of a remote MCP server).
+// The Agent reloads the tools of all its toolkits when the version reported by one of them changes.
+type DynamicToolKit interface {
+	ToolKit
+	ToolsVersion() uint64 // Returns a number which increases each time the list of tools changes
+}
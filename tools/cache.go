@@ -0,0 +1,222 @@
+package tools
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache is a backend storing tool results, used by the Agent for tools with a CacheTTL.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) (string, bool)                   // Get returns the cached result for key, if present and not expired
+	Set(key string, value string, ttl time.Duration) // Set stores the result for key for the duration ttl
+}
+
+// CacheKey returns the cache key of a tool call, made of the tool name and its canonicalized JSON arguments,
+// so that arguments differing only in key order or whitespace share the same key.
+func CacheKey(toolName, args string) (string, error) {
+	if args == "" {
+		args = "{}"
+	}
+	// Numbers are kept as written so that large integers do not lose precision
+	decoder := json.NewDecoder(strings.NewReader(args))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return "", fmt.Errorf("failed to unmarshal args: %v", err)
+	}
+	canonical, err := json.Marshal(value) // Maps are encoded with sorted keys
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal args: %v", err)
+	}
+	return toolName + ":" + string(canonical), nil
+}
+
+// defaultCacheMaxEntries is the default maximum number of entries of the cache backends.
+const defaultCacheMaxEntries = 1000
+
+// MemoryCache is an in-memory Cache which evicts the least recently used entries when full.
+type MemoryCache struct {
+	MaxEntries   int // Maximum number of entries. Defaults to 1000
+	MaxValueSize int // Maximum size of a value in bytes; larger values are not cached. 0 means no limit
+
+	// Internal fields
+
+	mu      sync.Mutex
+	entries map[string]*list.Element // Entries by key
+	order   *list.List               // Entries from most to least recently used
+}
+
+// memoryCacheEntry is an entry of a MemoryCache.
+type memoryCacheEntry struct {
+	key       string
+	value     string
+	expiresAt time.Time
+}
+
+// init initializes the internal fields. It must be called with mu held.
+func (c *MemoryCache) init() {
+	if c.entries == nil {
+		c.entries = make(map[string]*list.Element)
+		c.order = list.New()
+	}
+	if c.MaxEntries <= 0 {
+		c.MaxEntries = defaultCacheMaxEntries
+	}
+}
+
+// Get returns the cached value for key, if present and not expired.
+func (c *MemoryCache) Get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.init()
+	element, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	entry := element.Value.(*memoryCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return "", false
+	}
+	c.order.MoveToFront(element)
+	return entry.value, true
+}
+
+// Set stores the value for key for the duration ttl, evicting the least recently used entries if full.
+func (c *MemoryCache) Set(key string, value string, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.init()
+	if ttl <= 0 || (c.MaxValueSize > 0 && len(value) > c.MaxValueSize) {
+		return
+	}
+	entry := &memoryCacheEntry{key: key, value: value, expiresAt: time.Now().Add(ttl)}
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.MaxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryCacheEntry).key)
+	}
+}
+
+// Len returns the number of entries in the cache, including expired entries not yet evicted.
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.init()
+	return c.order.Len()
+}
+
+// FileCache is a Cache storing each entry as a <hash>.hermes-cache.json file in a directory, so that results persist
+// across runs. When full, the least recently written entries are removed.
+type FileCache struct {
+	Directory    string // Required directory of the cache files. It is created if it does not exist
+	MaxEntries   int    // Maximum number of entries. Defaults to 1000
+	MaxValueSize int    // Maximum size of a value in bytes; larger values are not cached. 0 means no limit
+
+	// Internal fields
+
+	mu sync.Mutex
+}
+
+// fileCacheSuffix is the suffix of FileCache files, so that eviction leaves other files in the directory alone.
+const fileCacheSuffix = ".hermes-cache.json"
+
+// fileCacheEntry is the content of a FileCache file.
+type fileCacheEntry struct {
+	Key       string    `json:"key"`
+	Value     string    `json:"value"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// path returns the path of the file of key.
+func (c *FileCache) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Directory, hex.EncodeToString(hash[:])+fileCacheSuffix)
+}
+
+// Get returns the cached value for key, if present and not expired. Expired entries are removed.
+func (c *FileCache) Get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	path := c.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	var entry fileCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key {
+		return "", false
+	}
+	if time.Now().After(entry.ExpiresAt) {
+		os.Remove(path)
+		return "", false
+	}
+	return entry.Value, true
+}
+
+// Set stores the value for key for the duration ttl. Errors writing the cache are ignored, as caching is optional.
+func (c *FileCache) Set(key string, value string, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if ttl <= 0 || (c.MaxValueSize > 0 && len(value) > c.MaxValueSize) {
+		return
+	}
+	if err := os.MkdirAll(c.Directory, 0755); err != nil {
+		return
+	}
+	data, err := json.Marshal(fileCacheEntry{Key: key, Value: value, ExpiresAt: time.Now().Add(ttl)})
+	if err != nil {
+		return
+	}
+	// Write to a temporary file first so that readers never see a partial entry
+	tmp, err := os.CreateTemp(c.Directory, "*.tmp")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil || os.Rename(tmp.Name(), c.path(key)) != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	c.evict()
+}
+
+// evict removes the oldest entries while there are more than MaxEntries. It must be called with mu held.
+func (c *FileCache) evict() {
+	maxEntries := c.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = defaultCacheMaxEntries
+	}
+	files, err := filepath.Glob(filepath.Join(c.Directory, "*"+fileCacheSuffix))
+	if err != nil || len(files) <= maxEntries {
+		return
+	}
+	modTimes := make(map[string]time.Time, len(files))
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			modTimes[file] = info.ModTime()
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return modTimes[files[i]].Before(modTimes[files[j]]) })
+	for _, file := range files[:len(files)-maxEntries] {
+		os.Remove(file)
+	}
+}
@@ -0,0 +1,82 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheKey(t *testing.T) {
+	key, err := CacheKey("weather", `{"units": "celsius", "city": "Paris", "days": 12345678901234567890}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `weather:{"city":"Paris","days":12345678901234567890,"units":"celsius"}`, key)
+
+	key, err = CacheKey("now", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "now:{}", key)
+
+	_, err = CacheKey("weather", "{invalid")
+	assert.Error(t, err)
+}
+
+func TestMemoryCache(t *testing.T) {
+	cache := &MemoryCache{MaxEntries: 2, MaxValueSize: 10}
+	cache.Set("a", "1", time.Minute)
+	cache.Set("b", "2", time.Minute)
+	_, ok := cache.Get("a") // a becomes the most recently used entry
+	assert.True(t, ok)
+	cache.Set("c", "3", time.Minute)
+
+	assert.Equal(t, 2, cache.Len())
+	_, ok = cache.Get("b")
+	assert.False(t, ok, "The least recently used entry should be evicted")
+	value, ok := cache.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, "3", value)
+
+	cache.Set("large", "more than ten bytes", time.Minute)
+	_, ok = cache.Get("large")
+	assert.False(t, ok, "Values larger than MaxValueSize should not be cached")
+
+	cache.Set("expired", "x", time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	_, ok = cache.Get("expired")
+	assert.False(t, ok, "Expired entries should not be returned")
+}
+
+func TestFileCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	cache := &FileCache{Directory: dir, MaxEntries: 2}
+	cache.Set("a", "1", time.Minute)
+	value, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "1", value)
+
+	// Entries persist across instances
+	value, ok = (&FileCache{Directory: dir}).Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "1", value)
+
+	// The oldest entries are removed when full, leaving other files alone
+	old := time.Now().Add(-time.Hour)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "settings.json"), []byte("{}"), 0644))
+	assert.NoError(t, os.Chtimes(filepath.Join(dir, "settings.json"), old, old))
+	assert.NoError(t, os.Chtimes(cache.path("a"), old, old))
+	cache.Set("b", "2", time.Minute)
+	cache.Set("c", "3", time.Minute)
+	_, ok = cache.Get("a")
+	assert.False(t, ok)
+	files, _ := filepath.Glob(filepath.Join(dir, "*.hermes-cache.json"))
+	assert.Len(t, files, 2)
+	assert.FileExists(t, filepath.Join(dir, "settings.json"))
+
+	cache.Set("expired", "x", time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	_, ok = cache.Get("expired")
+	assert.False(t, ok)
+	_, err := os.Stat(cache.path("expired"))
+	assert.True(t, os.IsNotExist(err), "Expired entries should be removed")
+}
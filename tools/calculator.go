@@ -2,12 +2,14 @@ package tools
 
 import (
 	"context"
+	"fmt"
 	"math"
+	"sort"
 
 	"github.com/Harsh-2909/hermes-go/utils"
 )
 
-// CalculatorTools is a toolkit that provides basic arithmetic operations.
+// CalculatorTools is a toolkit that provides arithmetic operations, expression evaluation and statistics.
 type CalculatorTools struct {
 	EnableAdd          bool // EnableAdd enables the Add tool
 	EnableSubtract     bool // EnableSubtract enables the Subtract tool
@@ -18,6 +20,9 @@ type CalculatorTools struct {
 	EnableFactorial    bool // EnableFactorial enables the Factorial tool
 	EnableIsPrime      bool // EnableIsPrime enables the IsPrime tool
 	EnableSquareRoot   bool // EnableSquareRoot enables the SquareRoot tool
+	EnableEvaluate     bool // EnableEvaluate enables the Evaluate tool
+	EnableStatistics   bool // EnableStatistics enables the Statistics tool
+	EnablePercentile   bool // EnablePercentile enables the Percentile tool
 
 	// EnableAll enables all tools in the toolkit.
 	EnableAll bool
@@ -100,6 +105,30 @@ func (c *CalculatorTools) Tools() []Tool {
 			utils.Logger.Error("Failed to create SquareRoot tool", "error", err)
 		}
 	}
+	if c.EnableEvaluate || c.EnableAll {
+		evaluateTool, err := CreateToolFromMethod(c, "Evaluate")
+		if err == nil {
+			tools = append(tools, evaluateTool)
+		} else {
+			utils.Logger.Error("Failed to create Evaluate tool", "error", err)
+		}
+	}
+	if c.EnableStatistics || c.EnableAll {
+		statisticsTool, err := CreateToolFromMethod(c, "Statistics")
+		if err == nil {
+			tools = append(tools, statisticsTool)
+		} else {
+			utils.Logger.Error("Failed to create Statistics tool", "error", err)
+		}
+	}
+	if c.EnablePercentile || c.EnableAll {
+		percentileTool, err := CreateToolFromMethod(c, "Percentile")
+		if err == nil {
+			tools = append(tools, percentileTool)
+		} else {
+			utils.Logger.Error("Failed to create Percentile tool", "error", err)
+		}
+	}
 	return tools
 }
 
@@ -134,49 +163,53 @@ func (c *CalculatorTools) Multiply(ctx context.Context, a, b float64) float64 {
 //
 // @param a: The first number
 // @param b: The second number
-// @return The result of dividing a by b. If b is 0, return 0.
-func (c *CalculatorTools) Divide(ctx context.Context, a, b float64) float64 {
+// @return The result of dividing a by b, or an error if b is 0
+func (c *CalculatorTools) Divide(ctx context.Context, a, b float64) (float64, error) {
 	if b == 0 {
-		utils.Logger.Error("Attempt to divide by zero")
-		return 0
+		return 0, fmt.Errorf("division by zero")
 	}
-	return a / b
+	return a / b, nil
 }
 
 // Modulus two numbers and return the result.
 //
 // @param a: The first number
 // @param b: The second number
-// @return The result of modulus a by b. If b is 0, return 0.
-func (c *CalculatorTools) Modulus(ctx context.Context, a, b int) int {
+// @return The result of modulus a by b, or an error if b is 0
+func (c *CalculatorTools) Modulus(ctx context.Context, a, b int) (int, error) {
 	if b == 0 {
-		utils.Logger.Error("Attempt to modulus by zero")
-		return 0
+		return 0, fmt.Errorf("modulus by zero")
 	}
-	return a % b
+	return a % b, nil
 }
 
 // Exponentiate returns base raised to the power exp.
 // @param base: The base number
 // @param exp: The exponent
-// @return The result of base^exp
-func (c *CalculatorTools) Exponentiate(ctx context.Context, base, exp float64) float64 {
-	return math.Pow(base, exp)
+// @return The result of base^exp, or an error if it is not a finite number
+func (c *CalculatorTools) Exponentiate(ctx context.Context, base, exp float64) (float64, error) {
+	result := math.Pow(base, exp)
+	if math.IsNaN(result) || math.IsInf(result, 0) {
+		return 0, fmt.Errorf("%v^%v is not a finite number", base, exp)
+	}
+	return result, nil
 }
 
 // Factorial returns the factorial of n.
 // @param n: The number to compute factorial for
-// @return The factorial of n. For n < 0, returns 0.
-func (c *CalculatorTools) Factorial(ctx context.Context, n int) int {
+// @return The factorial of n, or an error if n is negative or the result overflows
+func (c *CalculatorTools) Factorial(ctx context.Context, n int) (int, error) {
 	if n < 0 {
-		utils.Logger.Error("Factorial of negative number", "n", n)
-		return 0
+		return 0, fmt.Errorf("factorial of negative number %d", n)
 	}
 	result := 1
 	for i := 2; i <= n; i++ {
+		if result > math.MaxInt/i {
+			return 0, fmt.Errorf("factorial of %d is too large", n)
+		}
 		result *= i
 	}
-	return result
+	return result, nil
 }
 
 // IsPrime determines if n is a prime number.
@@ -196,11 +229,86 @@ func (c *CalculatorTools) IsPrime(ctx context.Context, n int) bool {
 
 // SquareRoot returns the square root of x.
 // @param x: The number to find the square root of
-// @return The square root of x. If x is negative, returns 0.
-func (c *CalculatorTools) SquareRoot(ctx context.Context, x float64) float64 {
+// @return The square root of x, or an error if x is negative
+func (c *CalculatorTools) SquareRoot(ctx context.Context, x float64) (float64, error) {
 	if x < 0 {
-		utils.Logger.Error("Square root of negative number", "x", x)
-		return 0
+		return 0, fmt.Errorf("square root of negative number %v", x)
+	}
+	return math.Sqrt(x), nil
+}
+
+// Evaluate evaluates a mathematical expression and returns the result.
+// @param expression: Expression with +, -, *, /, % (modulo), ^ (power), ! (factorial) and parentheses, e.g. "2 * (3 + sqrt(x)) ^ 2". Functions: sin, cos, tan, asin, acos, atan, atan2, sinh, cosh, tanh, sqrt, cbrt, abs, exp, ln, log (base 10, or log(x, base)), log2, log10, pow, hypot, round (round(x, digits)), floor, ceil, trunc, min, max, sign, factorial, degrees, radians. Constants: pi, e, tau, phi. Angles are in radians
+// @param [optional] variables: Values of the variables used in the expression, e.g. {"x": 4}
+// @return The result of the expression, or an error for invalid expressions and domain errors such as division by zero
+func (c *CalculatorTools) Evaluate(ctx context.Context, expression string, variables map[string]float64) (float64, error) {
+	return evaluateExpression(expression, variables)
+}
+
+// StatisticsResult holds descriptive statistics of numbers.
+type StatisticsResult struct {
+	Count                   int     `json:"count"`
+	Sum                     float64 `json:"sum"`
+	Mean                    float64 `json:"mean"`
+	Median                  float64 `json:"median"`
+	Min                     float64 `json:"min"`
+	Max                     float64 `json:"max"`
+	Variance                float64 `json:"variance"`                  // Population variance
+	StandardDeviation       float64 `json:"standard_deviation"`        // Population standard deviation
+	SampleStandardDeviation float64 `json:"sample_standard_deviation"` // Sample standard deviation, 0 for a single number
+}
+
+// Statistics computes descriptive statistics of numbers.
+// @param numbers: The numbers, e.g. [1, 2, 3.5]
+// @return JSON object with the count, sum, mean, median, min, max, variance and standard deviations of the numbers
+func (c *CalculatorTools) Statistics(ctx context.Context, numbers []float64) (StatisticsResult, error) {
+	if len(numbers) == 0 {
+		return StatisticsResult{}, fmt.Errorf("no numbers provided")
+	}
+	sorted := append([]float64(nil), numbers...)
+	sort.Float64s(sorted)
+
+	result := StatisticsResult{Count: len(sorted), Min: sorted[0], Max: sorted[len(sorted)-1]}
+	for _, number := range sorted {
+		result.Sum += number
+	}
+	result.Mean = result.Sum / float64(len(sorted))
+	result.Median = percentileOf(sorted, 50)
+	squares := 0.0
+	for _, number := range sorted {
+		squares += (number - result.Mean) * (number - result.Mean)
 	}
-	return math.Sqrt(x)
+	result.Variance = squares / float64(len(sorted))
+	result.StandardDeviation = math.Sqrt(result.Variance)
+	if len(sorted) > 1 {
+		result.SampleStandardDeviation = math.Sqrt(squares / float64(len(sorted)-1))
+	}
+	if math.IsNaN(result.Sum) || math.IsInf(result.Sum, 0) || math.IsInf(squares, 0) {
+		return StatisticsResult{}, fmt.Errorf("statistics of the numbers are not finite")
+	}
+	return result, nil
+}
+
+// Percentile computes a percentile of numbers, interpolating linearly between the closest ranks.
+// @param numbers: The numbers, e.g. [1, 2, 3.5]
+// @param percentile: The percentile between 0 and 100, e.g. 90
+// @return The percentile of the numbers
+func (c *CalculatorTools) Percentile(ctx context.Context, numbers []float64, percentile float64) (float64, error) {
+	if len(numbers) == 0 {
+		return 0, fmt.Errorf("no numbers provided")
+	}
+	if percentile < 0 || percentile > 100 {
+		return 0, fmt.Errorf("percentile must be between 0 and 100, got %v", percentile)
+	}
+	sorted := append([]float64(nil), numbers...)
+	sort.Float64s(sorted)
+	return percentileOf(sorted, percentile), nil
+}
+
+// percentileOf returns a percentile of sorted numbers, interpolating linearly between the closest ranks.
+func percentileOf(sorted []float64, percentile float64) float64 {
+	rank := percentile / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
 }
@@ -14,7 +14,7 @@ func TestCalculatorTools_Tools(t *testing.T) {
 		EnableAll: true,
 	}
 	tools := calcTools.Tools()
-	assert.Equal(t, 9, len(tools))
+	assert.Equal(t, 12, len(tools))
 	assert.NotNil(t, tools)
 }
 
@@ -91,7 +91,6 @@ func TestCalculatorTools_Tools_Divide(t *testing.T) {
 		{"Floating point division", 5, 2, 2.50},
 		{"Recurring division", 4, 3, 1.3333333333333333},
 		{"Zero reminder division", 6, 3, 2.00},
-		{"Division by zero", 8, 0, 0.00},
 	} // Test cases
 	for _, test := range tests {
 		t.Run(test.test, func(t *testing.T) {
@@ -99,9 +98,14 @@ func TestCalculatorTools_Tools_Divide(t *testing.T) {
 			assert.Nil(t, err)
 			resultVal, _ := strconv.ParseFloat(val, 64)
 			assert.Equal(t, fmt.Sprintf("%.2f", test.result), fmt.Sprintf("%.2f", resultVal))
-			assert.Equal(t, test.result, calcTools.Divide(ctx, test.a, test.b))
+			result, err := calcTools.Divide(ctx, test.a, test.b)
+			assert.Nil(t, err)
+			assert.Equal(t, test.result, result)
 		})
 	}
+
+	_, err := tool.Execute(ctx, `{"a": 8, "b": 0}`)
+	assert.EqualError(t, err, "division by zero")
 }
 
 func TestCalculatorTools_Tools_Modulus(t *testing.T) {
@@ -122,7 +126,6 @@ func TestCalculatorTools_Tools_Modulus(t *testing.T) {
 	}{
 		{"Modulus of positive numbers", 5, 2, 1},
 		{"Modulus of negative numbers", -5, -2, -1},
-		{"Modulus with zero", 8, 0, 0},
 		{"Modulus of zero", 0, 3, 0},
 	} // Test cases
 	for _, test := range tests {
@@ -131,9 +134,14 @@ func TestCalculatorTools_Tools_Modulus(t *testing.T) {
 			assert.Nil(t, err)
 			resultVal, _ := strconv.Atoi(val)
 			assert.Equal(t, test.result, resultVal)
-			assert.Equal(t, test.result, calcTools.Modulus(ctx, test.a, test.b))
+			result, err := calcTools.Modulus(ctx, test.a, test.b)
+			assert.Nil(t, err)
+			assert.Equal(t, test.result, result)
 		})
 	}
+
+	_, err := tool.Execute(ctx, `{"a": 8, "b": 0}`)
+	assert.EqualError(t, err, "modulus by zero")
 }
 
 func TestCalculatorTools_Tools_Exponentiate(t *testing.T) {
@@ -156,10 +164,15 @@ func TestCalculatorTools_Tools_Exponentiate(t *testing.T) {
 			val, err := tool.Execute(ctx, query)
 			assert.Nil(t, err)
 			res, _ := strconv.ParseFloat(val, 64)
-			assert.Equal(t, tt.expected, calcTools.Exponentiate(ctx, tt.base, tt.exp))
+			result, err := calcTools.Exponentiate(ctx, tt.base, tt.exp)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, result)
 			assert.Equal(t, fmt.Sprintf("%.2f", tt.expected), fmt.Sprintf("%.2f", res))
 		})
 	}
+
+	_, err := tool.Execute(ctx, `{"base": -8, "exp": 0.5}`)
+	assert.EqualError(t, err, "-8^0.5 is not a finite number")
 }
 
 func TestCalculatorTools_Tools_Factorial(t *testing.T) {
@@ -175,18 +188,25 @@ func TestCalculatorTools_Tools_Factorial(t *testing.T) {
 	}{
 		{"Factorial of 5", 5, 120},
 		{"Factorial of 0", 0, 1},
-		{"Factorial of negative", -1, 0},
+		{"Factorial of 20", 20, 2432902008176640000},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			query := fmt.Sprintf(`{"n": %d}`, tt.n)
 			val, err := tool.Execute(ctx, query)
 			assert.Nil(t, err)
-			assert.Equal(t, tt.expected, calcTools.Factorial(ctx, tt.n))
+			result, err := calcTools.Factorial(ctx, tt.n)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, result)
 			res, _ := strconv.Atoi(val)
 			assert.Equal(t, tt.expected, res)
 		})
 	}
+
+	_, err := tool.Execute(ctx, `{"n": -1}`)
+	assert.EqualError(t, err, "factorial of negative number -1")
+	_, err = tool.Execute(ctx, `{"n": 21}`)
+	assert.EqualError(t, err, "factorial of 21 is too large")
 }
 
 func TestCalculatorTools_Tools_IsPrime(t *testing.T) {
@@ -230,7 +250,6 @@ func TestCalculatorTools_Tools_SquareRoot(t *testing.T) {
 	}{
 		{"Square root of positive", 9, 3.0},
 		{"Square root of zero", 0, 0.0},
-		{"Square root of negative", -4, 0.0},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -238,8 +257,110 @@ func TestCalculatorTools_Tools_SquareRoot(t *testing.T) {
 			val, err := tool.Execute(ctx, query)
 			assert.Nil(t, err)
 			res, _ := strconv.ParseFloat(val, 64)
-			assert.Equal(t, tt.expected, calcTools.SquareRoot(ctx, tt.x))
+			result, err := calcTools.SquareRoot(ctx, tt.x)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, result)
 			assert.Equal(t, fmt.Sprintf("%.2f", tt.expected), fmt.Sprintf("%.2f", res))
 		})
 	}
+
+	_, err := tool.Execute(ctx, `{"x": -4}`)
+	assert.EqualError(t, err, "square root of negative number -4")
+}
+
+func TestCalculatorTools_Evaluate(t *testing.T) {
+	calcTools := &CalculatorTools{}
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		expression string
+		variables  map[string]float64
+		expected   float64
+	}{
+		{"Precedence", "2 + 3 * 4 - 6 / 2", nil, 11},
+		{"Parentheses", "(2 + 3) * (4 - 6) / 2", nil, -5},
+		{"Power is right associative", "2 ^ 3 ^ 2", nil, 512},
+		{"Power binds tighter than unary minus", "-2^2 + 2**-1", nil, -3.5},
+		{"Modulo", "10 % 4 + -7 % 3", nil, 1},
+		{"Factorial", "3! + 2!!", nil, 8},
+		{"Scientific notation", "1.5e3 + 2E-1 + .5", nil, 1500.7},
+		{"Functions", "sqrt(16) + abs(-2) + log(1000) + ln(e) + log(8, 2) + round(2.567, 2)", nil, 15.57},
+		{"Variadic functions", "max(1, 5, 3) - min(4, 2)", nil, 3},
+		{"Trigonometry", "round(sin(pi / 2) + cos(0) + degrees(atan2(1, 1)), 6)", nil, 47},
+		{"Variables", "price * (1 + rate) ^ years", map[string]float64{"price": 100, "rate": 0.1, "years": 2}, 121},
+		{"Variables shadow constants", "e * 2", map[string]float64{"e": 3}, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calcTools.Evaluate(ctx, tt.expression, tt.variables)
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.expected, result, 1e-9)
+		})
+	}
+
+	errorTests := []struct {
+		expression string
+		err        string
+	}{
+		{"1 / (2 - 2)", "division by zero"},
+		{"5 % 0", "modulo by zero"},
+		{"sqrt(-1)", "square root of negative number -1"},
+		{"log(0)", "logarithm of non-positive number 0"},
+		{"asin(2)", "asin is only defined between -1 and 1, got 2"},
+		{"2.5!", "factorial is only defined for non-negative integers, got 2.5"},
+		{"200!", "factorial of 200 is too large"},
+		{"10 ^ 400", "result is not a finite number"},
+		{"2 +", "invalid expression: unexpected end of expression at position 4"},
+		{"(1 + 2", "invalid expression: missing closing parenthesis at position 7"},
+		{"1 + 2)", "invalid expression: unexpected ')' at position 6"},
+		{"2 $ 3", "invalid expression: unexpected '$' at position 3"},
+		{"1.2.3", "invalid expression: invalid number \"1.2.3\" at position 1"},
+		{"x + 1", "unknown variable x; constants: e, phi, pi, tau"},
+		{"sqrt", "invalid expression: function sqrt must be called with parentheses at position 5"},
+		{"atan2(1)", "atan2 expects 2 arguments, got 1"},
+		{"max()", "max expects at least 1 arguments, got 0"},
+		{"foo(1)", "unknown function foo; functions: "},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := calcTools.Evaluate(ctx, tt.expression, nil)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestCalculatorTools_Statistics(t *testing.T) {
+	calcTools := &CalculatorTools{EnableStatistics: true, EnablePercentile: true}
+	tools := calcTools.Tools()
+	assert.Equal(t, 2, len(tools))
+	ctx := context.Background()
+
+	val, err := tools[0].Execute(ctx, `{"numbers": [2, 4, 4, 4, 5, 5, 7, 9]}`)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"count": 8, "sum": 40, "mean": 5, "median": 4.5, "min": 2, "max": 9,
+		"variance": 4, "standard_deviation": 2, "sample_standard_deviation": 2.138089935299395}`, val)
+
+	result, err := calcTools.Statistics(ctx, []float64{3})
+	assert.NoError(t, err)
+	assert.Equal(t, StatisticsResult{Count: 1, Sum: 3, Mean: 3, Median: 3, Min: 3, Max: 3}, result)
+	_, err = calcTools.Statistics(ctx, nil)
+	assert.EqualError(t, err, "no numbers provided")
+
+	tests := []struct {
+		percentile float64
+		expected   float64
+	}{
+		{0, 1},
+		{50, 3},
+		{90, 4.6},
+		{100, 5},
+	}
+	for _, tt := range tests {
+		result, err := calcTools.Percentile(ctx, []float64{5, 1, 4, 2, 3}, tt.percentile)
+		assert.NoError(t, err)
+		assert.InDelta(t, tt.expected, result, 1e-9)
+	}
+	_, err = tools[1].Execute(ctx, `{"numbers": [1, 2], "percentile": 101}`)
+	assert.EqualError(t, err, "percentile must be between 0 and 100, got 101")
 }
@@ -0,0 +1,1046 @@
+package tools
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/Harsh-2909/hermes-go/utils"
+)
+
+// DataTools provides tools for analysing CSV and JSON files as in-memory tables.
+// Files are read from TargetDirectory with the path confinement of FileSystemTools.
+type DataTools struct {
+	EnableLoadData     bool   // Enable the LoadData tool
+	EnableDescribeData bool   // Enable the DescribeData tool
+	EnableQueryData    bool   // Enable the QueryData tool
+	EnableAll          bool   // Enable all tools if true
+	TargetDirectory    string // Root directory of the data files. Defaults to the current directory
+	MaxRows            int    // Maximum number of rows returned by QueryData. Defaults to 50
+	MaxFileSize        int    // Maximum size in bytes of a loaded file. Defaults to 50000000
+
+	// Internal fields
+
+	mu     sync.Mutex
+	tables map[string]*dataTable
+}
+
+// dataTable is an in-memory table. Values are float64, bool, string or nil.
+type dataTable struct {
+	columns []string
+	types   []string // Inferred type of each column: "integer", "number", "boolean", "string" or "empty"
+	rows    [][]interface{}
+}
+
+// ColumnDescription describes a column of a data table.
+type ColumnDescription struct {
+	Name     string      `json:"name"`
+	Type     string      `json:"type"`
+	Count    int         `json:"count"` // Number of non-null values
+	Nulls    int         `json:"nulls"`
+	Distinct int         `json:"distinct"`
+	Min      interface{} `json:"min,omitempty"`
+	Max      interface{} `json:"max,omitempty"`
+	Mean     *float64    `json:"mean,omitempty"` // Mean of numeric columns
+	Examples []string    `json:"examples,omitempty"`
+}
+
+// DataDescription is the result of the DescribeData tool.
+type DataDescription struct {
+	Table   string              `json:"table"`
+	Rows    int                 `json:"rows"`
+	Columns []ColumnDescription `json:"columns"`
+}
+
+// Tools returns a list of available tools based on enable flags.
+func (d *DataTools) Tools() []Tool {
+	var tools []Tool
+
+	if d.EnableLoadData || d.EnableAll {
+		if loadTool, err := CreateToolFromMethod(d, "LoadData"); err == nil {
+			tools = append(tools, loadTool)
+		} else {
+			utils.Logger.Error("Failed to create tool", "tool", "LoadData", "error", err)
+		}
+	}
+
+	if d.EnableDescribeData || d.EnableAll {
+		if describeTool, err := CreateToolFromMethod(d, "DescribeData"); err == nil {
+			tools = append(tools, describeTool)
+		} else {
+			utils.Logger.Error("Failed to create tool", "tool", "DescribeData", "error", err)
+		}
+	}
+
+	if d.EnableQueryData || d.EnableAll {
+		if queryTool, err := CreateToolFromMethod(d, "QueryData"); err == nil {
+			tools = append(tools, queryTool)
+		} else {
+			utils.Logger.Error("Failed to create tool", "tool", "QueryData", "error", err)
+		}
+	}
+
+	return tools
+}
+
+// LoadData loads a CSV, TSV, JSON or JSON Lines file into an in-memory table.
+// @param path: Path of the file, relative to the target directory. JSON files must hold an array of objects
+// @param [optional] table: Name of the table. Defaults to the file name without extension
+// @return Summary of the loaded table with its columns and their types
+func (d *DataTools) LoadData(ctx context.Context, path, table string) (string, error) {
+	filePath, err := (&FileSystemTools{TargetDirectory: d.TargetDirectory}).resolve(path)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return "", fmt.Errorf("file not found: %s", path)
+	}
+	maxFileSize := d.MaxFileSize
+	if maxFileSize <= 0 {
+		maxFileSize = 50000000
+	}
+	if info.Size() > int64(maxFileSize) {
+		return "", fmt.Errorf("%s is larger than %d bytes", path, maxFileSize)
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %v", err)
+	}
+	defer file.Close()
+
+	var loaded *dataTable
+	switch extension := strings.ToLower(filepath.Ext(filePath)); extension {
+	case ".csv":
+		loaded, err = loadCSV(file, ',')
+	case ".tsv":
+		loaded, err = loadCSV(file, '\t')
+	case ".json":
+		loaded, err = loadJSON(file, false)
+	case ".jsonl", ".ndjson":
+		loaded, err = loadJSON(file, true)
+	default:
+		return "", fmt.Errorf("unsupported file type %q: expected .csv, .tsv, .json, .jsonl or .ndjson", extension)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to load %s: %v", path, err)
+	}
+
+	if table == "" {
+		table = strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	}
+	d.mu.Lock()
+	if d.tables == nil {
+		d.tables = make(map[string]*dataTable)
+	}
+	d.tables[table] = loaded
+	d.mu.Unlock()
+
+	columns := make([]string, len(loaded.columns))
+	for i, column := range loaded.columns {
+		columns[i] = fmt.Sprintf("%s (%s)", column, loaded.types[i])
+	}
+	return fmt.Sprintf("Loaded %d rows into table %s with columns: %s", len(loaded.rows), table, strings.Join(columns, ", ")), nil
+}
+
+// DescribeData describes the columns of a loaded table.
+// @param table: Name of the table
+// @return JSON object with the number of rows and, for each column, its type, counts of values, nulls and distinct values, min, max, mean and examples
+func (d *DataTools) DescribeData(ctx context.Context, table string) (DataDescription, error) {
+	t, err := d.table(table)
+	if err != nil {
+		return DataDescription{}, err
+	}
+	description := DataDescription{Table: table, Rows: len(t.rows), Columns: make([]ColumnDescription, len(t.columns))}
+	for i, name := range t.columns {
+		column := ColumnDescription{Name: name, Type: t.types[i]}
+		distinct := make(map[string]bool)
+		sum := 0.0
+		for _, row := range t.rows {
+			value := row[i]
+			if value == nil {
+				column.Nulls++
+				continue
+			}
+			column.Count++
+			text := formatDataValue(value)
+			if !distinct[text] && len(column.Examples) < 5 {
+				column.Examples = append(column.Examples, text)
+			}
+			distinct[text] = true
+			if number, ok := value.(float64); ok {
+				sum += number
+			}
+			if column.Min == nil || compareDataValues(value, column.Min) < 0 {
+				column.Min = value
+			}
+			if column.Max == nil || compareDataValues(value, column.Max) > 0 {
+				column.Max = value
+			}
+		}
+		column.Distinct = len(distinct)
+		if (column.Type == "integer" || column.Type == "number") && column.Count > 0 {
+			mean := sum / float64(column.Count)
+			column.Mean = &mean
+		}
+		description.Columns[i] = column
+	}
+	return description, nil
+}
+
+// QueryData filters, groups, aggregates and sorts the rows of a loaded table.
+// @param table: Name of the table
+// @param [optional] filter: Condition on the rows, e.g. "price > 10 and (category = 'books' or category = 'music')". Operators: =, !=, <, <=, >, >=, contains, in ('a', 'b'), is null, is not null, and, or, not. Quote column names with spaces with backticks
+// @param [optional] columns: Columns to return when not aggregating. Defaults to all columns
+// @param [optional] group_by: Columns to group the rows by
+// @param [optional] aggregates: Aggregates to compute, over the groups if group_by is provided, e.g. ["count", "sum(price)", "avg(price)"]. Functions: count, count(column), count_distinct, sum, avg, min, max, median. Defaults to ["count"] when grouping
+// @param [optional] sort_by: Comma separated columns or aggregates to sort by, with "desc" for descending order, e.g. "sum(price) desc, category"
+// @param [optional] limit: Maximum number of rows to return
+// @return Results as a Markdown table
+func (d *DataTools) QueryData(ctx context.Context, table, filter string, columns, group_by, aggregates []string, sort_by string, limit int) (string, error) {
+	t, err := d.table(table)
+	if err != nil {
+		return "", err
+	}
+
+	rows := t.rows
+	if strings.TrimSpace(filter) != "" {
+		condition, err := parseDataFilter(filter, t.columns)
+		if err != nil {
+			return "", err
+		}
+		rows = nil
+		for _, row := range t.rows {
+			if condition(row) {
+				rows = append(rows, row)
+			}
+		}
+	}
+
+	// Aggregates are sorted after grouping, and rows before selecting columns to sort by any column
+	var result *dataTable
+	if len(group_by) > 0 || len(aggregates) > 0 {
+		if len(aggregates) == 0 {
+			aggregates = []string{"count"}
+		}
+		if result, err = aggregateData(t, rows, group_by, aggregates); err != nil {
+			return "", err
+		}
+		if sort_by != "" {
+			if err := sortData(result, sort_by); err != nil {
+				return "", err
+			}
+		}
+	} else {
+		sorted := &dataTable{columns: t.columns, rows: append([][]interface{}(nil), rows...)}
+		if sort_by != "" {
+			if err := sortData(sorted, sort_by); err != nil {
+				return "", err
+			}
+		}
+		if result, err = selectData(sorted, sorted.rows, columns); err != nil {
+			return "", err
+		}
+	}
+
+	maxRows := d.MaxRows
+	if maxRows <= 0 {
+		maxRows = 50
+	}
+	if limit > 0 && limit < maxRows {
+		maxRows = limit
+	}
+	output := QueryResult{Columns: result.columns}
+	for i, row := range result.rows {
+		if i == maxRows {
+			break
+		}
+		formatted := make([]interface{}, len(row))
+		for j, value := range row {
+			if value != nil {
+				formatted[j] = formatDataValue(value)
+			}
+		}
+		output.Rows = append(output.Rows, formatted)
+	}
+	markdown := formatMarkdownTable(output, maxRows)
+	if len(result.rows) > maxRows {
+		markdown += fmt.Sprintf("\n[Showing the first %d of %d rows]", maxRows, len(result.rows))
+	}
+	return markdown, nil
+}
+
+// table returns a loaded table.
+func (d *DataTools) table(name string) (*dataTable, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if t, ok := d.tables[name]; ok {
+		return t, nil
+	}
+	names := make([]string, 0, len(d.tables))
+	for name := range d.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return nil, fmt.Errorf("table %s not found; load a file with LoadData first", name)
+	}
+	return nil, fmt.Errorf("table %s not found; loaded tables: %s", name, strings.Join(names, ", "))
+}
+
+// loadCSV loads a CSV file with a header row, inferring the types of the columns.
+func loadCSV(r io.Reader, separator rune) (*dataTable, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = separator
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no header row")
+	}
+	header := records[0]
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff") // Byte order mark
+	}
+	t := &dataTable{columns: uniqueColumnNames(header)}
+	for _, record := range records[1:] {
+		row := make([]interface{}, len(t.columns))
+		for i := range row {
+			if i < len(record) && strings.TrimSpace(record[i]) != "" {
+				row[i] = record[i]
+			}
+		}
+		t.rows = append(t.rows, row)
+	}
+
+	// Convert the columns whose values are all numbers or booleans
+	t.types = make([]string, len(t.columns))
+	for i := range t.columns {
+		t.types[i] = inferColumnType(t.rows, i)
+		for _, row := range t.rows {
+			if row[i] == nil {
+				continue
+			}
+			text := strings.TrimSpace(row[i].(string))
+			switch t.types[i] {
+			case "integer", "number":
+				row[i], _ = strconv.ParseFloat(text, 64)
+			case "boolean":
+				row[i], _ = strconv.ParseBool(text)
+			}
+		}
+	}
+	return t, nil
+}
+
+// inferColumnType returns the type of the string values of a column.
+func inferColumnType(rows [][]interface{}, i int) string {
+	columnType := "empty"
+	for _, row := range rows {
+		if row[i] == nil {
+			continue
+		}
+		text := strings.TrimSpace(row[i].(string))
+		valueType := "string"
+		if _, err := strconv.ParseInt(text, 10, 64); err == nil {
+			valueType = "integer"
+		} else if number, err := strconv.ParseFloat(text, 64); err == nil && !math.IsInf(number, 0) && !math.IsNaN(number) {
+			valueType = "number"
+		} else if _, err := strconv.ParseBool(text); err == nil && !strings.ContainsAny(text, "01") {
+			valueType = "boolean"
+		}
+		switch {
+		case columnType == "empty" || columnType == valueType:
+			columnType = valueType
+		case columnType == "integer" && valueType == "number" || columnType == "number" && valueType == "integer":
+			columnType = "number"
+		default:
+			return "string"
+		}
+	}
+	return columnType
+}
+
+// loadJSON loads a JSON array of objects, or JSON Lines with an object per line.
+// Columns are ordered by first appearance, and nested values are kept as JSON.
+func loadJSON(r io.Reader, lines bool) (*dataTable, error) {
+	var objects []map[string]interface{}
+	var keys [][]string // Keys of each object in document order
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	if !lines {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		if delim, ok := token.(json.Delim); !ok || delim != '[' {
+			return nil, fmt.Errorf("expected an array of objects")
+		}
+	}
+	for decoder.More() || lines {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err == io.EOF && lines {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		object, objectKeys, err := decodeJSONObject(raw)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, object)
+		keys = append(keys, objectKeys)
+	}
+
+	t := &dataTable{}
+	index := make(map[string]int)
+	for _, objectKeys := range keys {
+		for _, key := range objectKeys {
+			if _, ok := index[key]; !ok {
+				index[key] = len(t.columns)
+				t.columns = append(t.columns, key)
+			}
+		}
+	}
+	for _, object := range objects {
+		row := make([]interface{}, len(t.columns))
+		for key, value := range object {
+			switch v := value.(type) {
+			case json.Number:
+				row[index[key]], _ = v.Float64()
+			case map[string]interface{}, []interface{}:
+				encoded, _ := json.Marshal(v)
+				row[index[key]] = string(encoded)
+			default:
+				row[index[key]] = v
+			}
+		}
+		t.rows = append(t.rows, row)
+	}
+
+	t.types = make([]string, len(t.columns))
+	for i := range t.columns {
+		t.types[i] = "empty"
+		for _, row := range t.rows {
+			valueType := ""
+			switch v := row[i].(type) {
+			case nil:
+				continue
+			case float64:
+				valueType = "number"
+				if v == math.Trunc(v) {
+					valueType = "integer"
+				}
+			case bool:
+				valueType = "boolean"
+			default:
+				valueType = "string"
+			}
+			switch current := t.types[i]; {
+			case current == "empty" || current == valueType:
+				t.types[i] = valueType
+			case (current == "integer" || current == "number") && (valueType == "integer" || valueType == "number"):
+				t.types[i] = "number"
+			default:
+				t.types[i] = "mixed"
+			}
+		}
+	}
+	return t, nil
+}
+
+// decodeJSONObject decodes a JSON object and returns its keys in document order.
+func decodeJSONObject(raw json.RawMessage) (map[string]interface{}, []string, error) {
+	decoder := json.NewDecoder(strings.NewReader(string(raw)))
+	decoder.UseNumber()
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, nil, fmt.Errorf("expected an object, got %s", strings.TrimSpace(string(raw[:min(len(raw), 50)])))
+	}
+	object := make(map[string]interface{})
+	var keys []string
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key := token.(string)
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		if _, seen := object[key]; !seen {
+			keys = append(keys, key)
+		}
+		object[key] = value
+	}
+	return object, keys, nil
+}
+
+// uniqueColumnNames names empty columns and renames duplicated columns, e.g. "name", "name_2".
+func uniqueColumnNames(header []string) []string {
+	columns := make([]string, len(header))
+	seen := make(map[string]int)
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			name = fmt.Sprintf("column_%d", i+1)
+		}
+		seen[name]++
+		if seen[name] > 1 {
+			name = fmt.Sprintf("%s_%d", name, seen[name])
+		}
+		columns[i] = name
+	}
+	return columns
+}
+
+// formatDataValue formats a value for display, without exponents for numbers.
+func formatDataValue(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		return "NULL"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// compareDataValues orders values: nulls first, then booleans, numbers and strings.
+func compareDataValues(a, b interface{}) int {
+	rank := func(value interface{}) int {
+		switch value.(type) {
+		case nil:
+			return 0
+		case bool:
+			return 1
+		case float64:
+			return 2
+		default:
+			return 3
+		}
+	}
+	if rankA, rankB := rank(a), rank(b); rankA != rankB {
+		return rankA - rankB
+	}
+	switch v := a.(type) {
+	case bool:
+		if v == b.(bool) {
+			return 0
+		} else if !v {
+			return -1
+		}
+		return 1
+	case float64:
+		switch w := b.(float64); {
+		case v < w:
+			return -1
+		case v > w:
+			return 1
+		}
+		return 0
+	case string:
+		return strings.Compare(v, b.(string))
+	}
+	return 0
+}
+
+// columnIndex returns the index of a column, with an error listing the columns if it does not exist.
+func columnIndex(columns []string, name string) (int, error) {
+	name = strings.Trim(strings.TrimSpace(name), "`")
+	for i, column := range columns {
+		if column == name {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("unknown column %s; columns: %s", name, strings.Join(columns, ", "))
+}
+
+// selectData returns the given columns of rows.
+func selectData(t *dataTable, rows [][]interface{}, columns []string) (*dataTable, error) {
+	if len(columns) == 0 {
+		return &dataTable{columns: t.columns, rows: rows}, nil
+	}
+	indexes := make([]int, len(columns))
+	for i, column := range columns {
+		index, err := columnIndex(t.columns, column)
+		if err != nil {
+			return nil, err
+		}
+		indexes[i] = index
+	}
+	result := &dataTable{columns: make([]string, len(columns))}
+	for i, index := range indexes {
+		result.columns[i] = t.columns[index]
+	}
+	for _, row := range rows {
+		selected := make([]interface{}, len(indexes))
+		for i, index := range indexes {
+			selected[i] = row[index]
+		}
+		result.rows = append(result.rows, selected)
+	}
+	return result, nil
+}
+
+// dataAggregate is a parsed aggregate such as "sum(price)".
+type dataAggregate struct {
+	name     string // Column name of the result, e.g. "sum(price)"
+	function string
+	column   int // Index of the aggregated column, or -1 for count
+}
+
+// aggregateData groups rows by columns and computes aggregates for each group, in order of first appearance.
+func aggregateData(t *dataTable, rows [][]interface{}, groupBy, aggregates []string) (*dataTable, error) {
+	groupIndexes := make([]int, len(groupBy))
+	result := &dataTable{}
+	for i, column := range groupBy {
+		index, err := columnIndex(t.columns, column)
+		if err != nil {
+			return nil, err
+		}
+		groupIndexes[i] = index
+		result.columns = append(result.columns, t.columns[index])
+	}
+	parsed := make([]dataAggregate, len(aggregates))
+	for i, aggregate := range aggregates {
+		function, argument, hasArgument := strings.Cut(strings.TrimSpace(aggregate), "(")
+		function = strings.ToLower(strings.TrimSpace(function))
+		parsed[i] = dataAggregate{name: function, function: function, column: -1}
+		if hasArgument {
+			argument = strings.TrimSpace(strings.TrimSuffix(argument, ")"))
+			if argument != "*" {
+				index, err := columnIndex(t.columns, argument)
+				if err != nil {
+					return nil, err
+				}
+				parsed[i].column = index
+				parsed[i].name = function + "(" + t.columns[index] + ")"
+			}
+		}
+		switch function {
+		case "count":
+		case "count_distinct", "sum", "avg", "mean", "min", "max", "median":
+			if parsed[i].column < 0 {
+				return nil, fmt.Errorf("aggregate %s requires a column, e.g. %s(price)", function, function)
+			}
+		default:
+			return nil, fmt.Errorf("unknown aggregate %s; functions: count, count_distinct, sum, avg, min, max, median", function)
+		}
+		result.columns = append(result.columns, parsed[i].name)
+	}
+
+	// Group the rows by the formatted values of the grouping columns
+	var keys []string
+	groups := make(map[string][][]interface{})
+	for _, row := range rows {
+		var key strings.Builder
+		for _, index := range groupIndexes {
+			key.WriteString(fmt.Sprintf("%T:%v\x00", row[index], row[index]))
+		}
+		if _, ok := groups[key.String()]; !ok {
+			keys = append(keys, key.String())
+		}
+		groups[key.String()] = append(groups[key.String()], row)
+	}
+	if len(groupIndexes) == 0 && len(keys) == 0 {
+		keys = []string{""} // Aggregates of no rows
+	}
+
+	for _, key := range keys {
+		group := groups[key]
+		row := make([]interface{}, 0, len(result.columns))
+		for _, index := range groupIndexes {
+			row = append(row, group[0][index])
+		}
+		for _, aggregate := range parsed {
+			row = append(row, computeAggregate(aggregate, group))
+		}
+		result.rows = append(result.rows, row)
+	}
+	return result, nil
+}
+
+// computeAggregate computes an aggregate over the rows of a group. Numeric aggregates ignore non-numeric values.
+func computeAggregate(aggregate dataAggregate, rows [][]interface{}) interface{} {
+	if aggregate.column < 0 {
+		return float64(len(rows))
+	}
+	var values []interface{}
+	var numbers []float64
+	for _, row := range rows {
+		if value := row[aggregate.column]; value != nil {
+			values = append(values, value)
+			if number, ok := value.(float64); ok {
+				numbers = append(numbers, number)
+			}
+		}
+	}
+	switch aggregate.function {
+	case "count":
+		return float64(len(values))
+	case "count_distinct":
+		distinct := make(map[interface{}]bool)
+		for _, value := range values {
+			distinct[value] = true
+		}
+		return float64(len(distinct))
+	case "min", "max":
+		if len(values) == 0 {
+			return nil
+		}
+		result := values[0]
+		for _, value := range values[1:] {
+			if c := compareDataValues(value, result); (aggregate.function == "min" && c < 0) || (aggregate.function == "max" && c > 0) {
+				result = value
+			}
+		}
+		return result
+	}
+	if len(numbers) == 0 {
+		return nil
+	}
+	sum := 0.0
+	for _, number := range numbers {
+		sum += number
+	}
+	switch aggregate.function {
+	case "sum":
+		return sum
+	case "median":
+		sort.Float64s(numbers)
+		return percentileOf(numbers, 50)
+	default: // avg and mean
+		return sum / float64(len(numbers))
+	}
+}
+
+// sortData sorts the rows of a table by comma separated columns, each optionally followed by "asc" or "desc".
+func sortData(t *dataTable, sortBy string) error {
+	type sortKey struct {
+		index      int
+		descending bool
+	}
+	var keys []sortKey
+	for _, part := range strings.Split(sortBy, ",") {
+		part = strings.TrimSpace(part)
+		descending := false
+		lower := strings.ToLower(part)
+		if strings.HasSuffix(lower, " desc") {
+			descending = true
+			part = strings.TrimSpace(part[:len(part)-5])
+		} else if strings.HasSuffix(lower, " asc") {
+			part = strings.TrimSpace(part[:len(part)-4])
+		}
+		index, err := columnIndex(t.columns, part)
+		if err != nil {
+			// Aggregates are matched case-insensitively, e.g. "SUM(price)"
+			for i, column := range t.columns {
+				if strings.EqualFold(column, part) {
+					index, err = i, nil
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+		keys = append(keys, sortKey{index, descending})
+	}
+	sort.SliceStable(t.rows, func(i, j int) bool {
+		for _, key := range keys {
+			c := compareDataValues(t.rows[i][key.index], t.rows[j][key.index])
+			if c == 0 {
+				continue
+			}
+			if key.descending {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+	return nil
+}
+
+// dataFilterParser parses filter conditions into functions on rows.
+type dataFilterParser struct {
+	tokens  []string
+	pos     int
+	columns []string
+}
+
+// parseDataFilter parses a filter condition on the columns of a table.
+func parseDataFilter(filter string, columns []string) (func([]interface{}) bool, error) {
+	tokens, err := tokenizeDataFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	p := &dataFilterParser{tokens: tokens, columns: columns}
+	condition, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("invalid filter: unexpected %s", p.tokens[p.pos])
+	}
+	return condition, nil
+}
+
+// tokenizeDataFilter splits a filter into identifiers, numbers, quoted strings ('...' or "..."),
+// quoted columns (`...`), operators and parentheses.
+func tokenizeDataFilter(filter string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(filter); {
+		c := filter[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case c == '\'' || c == '"' || c == '`':
+			end := strings.IndexByte(filter[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("invalid filter: unterminated quote")
+			}
+			tokens = append(tokens, filter[i:i+end+2])
+			i += end + 2
+		case strings.ContainsRune("(),", rune(c)):
+			tokens = append(tokens, string(c))
+			i++
+		case strings.ContainsRune("=!<>", rune(c)):
+			j := i + 1
+			if j < len(filter) && filter[j] == '=' {
+				j++
+			}
+			tokens = append(tokens, filter[i:j])
+			i = j
+		default:
+			j := i
+			for j < len(filter) && !unicode.IsSpace(rune(filter[j])) && !strings.ContainsRune("(),=!<>'\"`", rune(filter[j])) {
+				j++
+			}
+			tokens = append(tokens, filter[i:j])
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+func (p *dataFilterParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+// keyword consumes the next token if it is the given keyword, case-insensitively.
+func (p *dataFilterParser) keyword(word string) bool {
+	if strings.EqualFold(p.peek(), word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *dataFilterParser) parseOr() (func([]interface{}) bool, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		previous := left
+		left = func(row []interface{}) bool { return previous(row) || right(row) }
+	}
+	return left, nil
+}
+
+func (p *dataFilterParser) parseAnd() (func([]interface{}) bool, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		previous := left
+		left = func(row []interface{}) bool { return previous(row) && right(row) }
+	}
+	return left, nil
+}
+
+func (p *dataFilterParser) parseNot() (func([]interface{}) bool, error) {
+	if p.keyword("not") {
+		condition, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return func(row []interface{}) bool { return !condition(row) }, nil
+	}
+	if p.peek() == "(" {
+		p.pos++
+		condition, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("invalid filter: missing closing parenthesis")
+		}
+		p.pos++
+		return condition, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *dataFilterParser) parseComparison() (func([]interface{}) bool, error) {
+	name := p.peek()
+	if name == "" {
+		return nil, fmt.Errorf("invalid filter: unexpected end of filter")
+	}
+	p.pos++
+	index, err := columnIndex(p.columns, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.keyword("is") {
+		negate := p.keyword("not")
+		if !p.keyword("null") {
+			return nil, fmt.Errorf("invalid filter: expected null after is")
+		}
+		return func(row []interface{}) bool { return (row[index] == nil) != negate }, nil
+	}
+
+	operator := strings.ToLower(p.peek())
+	p.pos++
+	switch operator {
+	case "in":
+		if p.peek() != "(" {
+			return nil, fmt.Errorf("invalid filter: expected ( after in")
+		}
+		p.pos++
+		var values []interface{}
+		for {
+			value, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+			if p.peek() == ")" {
+				p.pos++
+				break
+			}
+			if p.peek() != "," {
+				return nil, fmt.Errorf("invalid filter: expected , or ) in the values of in")
+			}
+			p.pos++
+		}
+		return func(row []interface{}) bool {
+			for _, value := range values {
+				if equalDataValues(row[index], value) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case "contains":
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		needle := strings.ToLower(formatDataValue(value))
+		return func(row []interface{}) bool {
+			return row[index] != nil && strings.Contains(strings.ToLower(formatDataValue(row[index])), needle)
+		}, nil
+	case "=", "==", "!=", "<>", "<", "<=", ">", ">=":
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return func(row []interface{}) bool {
+			switch operator {
+			case "=", "==":
+				return equalDataValues(row[index], value)
+			case "!=", "<>":
+				return !equalDataValues(row[index], value)
+			}
+			left := row[index]
+			if left == nil || value == nil {
+				return false
+			}
+			// Values of different types are compared as text
+			if fmt.Sprintf("%T", left) != fmt.Sprintf("%T", value) {
+				left, value := formatDataValue(left), formatDataValue(value)
+				return compareWith(strings.Compare(left, value), operator)
+			}
+			return compareWith(compareDataValues(left, value), operator)
+		}, nil
+	case "":
+		return nil, fmt.Errorf("invalid filter: expected an operator after %s", name)
+	}
+	return nil, fmt.Errorf("invalid filter: unknown operator %s", operator)
+}
+
+// parseValue parses a literal: a quoted string, a number, true, false or null.
+func (p *dataFilterParser) parseValue() (interface{}, error) {
+	token := p.peek()
+	if token == "" {
+		return nil, fmt.Errorf("invalid filter: expected a value")
+	}
+	p.pos++
+	if token[0] == '\'' || token[0] == '"' {
+		return token[1 : len(token)-1], nil
+	}
+	switch strings.ToLower(token) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if number, err := strconv.ParseFloat(token, 64); err == nil {
+		return number, nil
+	}
+	return nil, fmt.Errorf("invalid filter: invalid value %s; quote text values, e.g. '%s'", token, token)
+}
+
+// equalDataValues reports whether a value equals a literal, comparing as text if their types differ.
+func equalDataValues(value, literal interface{}) bool {
+	if value == nil || literal == nil {
+		return value == nil && literal == nil
+	}
+	if fmt.Sprintf("%T", value) != fmt.Sprintf("%T", literal) {
+		return formatDataValue(value) == formatDataValue(literal)
+	}
+	return compareDataValues(value, literal) == 0
+}
+
+// compareWith applies a comparison operator to the result of a comparison.
+func compareWith(c int, operator string) bool {
+	switch operator {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	default: // ">="
+		return c >= 0
+	}
+}
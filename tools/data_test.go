@@ -0,0 +1,146 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDataTools returns DataTools with a sales table loaded from a CSV file.
+func newDataTools(t *testing.T) *DataTools {
+	dir := t.TempDir()
+	csv := "region,product,units,price,shipped\n" +
+		"north,book,3,12.5,true\n" +
+		"south,book,1,12.5,false\n" +
+		"north,pen,10,1.25,true\n" +
+		"east,\"desk, oak\",1,250,\n" +
+		"south,pen,4,1.25,true\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sales.csv"), []byte(csv), 0644))
+	dataTools := &DataTools{TargetDirectory: dir}
+	msg, err := dataTools.LoadData(context.Background(), "sales.csv", "")
+	require.NoError(t, err)
+	assert.Equal(t, "Loaded 5 rows into table sales with columns: region (string), product (string), units (integer), price (number), shipped (boolean)", msg)
+	return dataTools
+}
+
+func TestDataTools_LoadData(t *testing.T) {
+	ctx := context.Background()
+	dataTools := newDataTools(t)
+	dir := dataTools.TargetDirectory
+
+	json := `[{"name": "Ada", "age": 36, "tags": ["math"]}, {"name": "Alan", "age": null, "city": "London"}]`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "people.json"), []byte(json), 0644))
+	msg, err := dataTools.LoadData(ctx, "people.json", "people")
+	assert.NoError(t, err)
+	assert.Equal(t, "Loaded 2 rows into table people with columns: name (string), age (integer), tags (string), city (string)", msg)
+
+	jsonLines := "{\"id\": 1, \"score\": 0.5}\n{\"id\": 2, \"score\": 2}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "scores.jsonl"), []byte(jsonLines), 0644))
+	msg, err = dataTools.LoadData(ctx, "scores.jsonl", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "Loaded 2 rows into table scores with columns: id (integer), score (number)", msg)
+
+	_, err = dataTools.LoadData(ctx, "../outside.csv", "")
+	assert.ErrorContains(t, err, "outside")
+	_, err = dataTools.LoadData(ctx, "missing.csv", "")
+	assert.EqualError(t, err, "file not found: missing.csv")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("notes"), 0644))
+	_, err = dataTools.LoadData(ctx, "notes.txt", "")
+	assert.EqualError(t, err, `unsupported file type ".txt": expected .csv, .tsv, .json, .jsonl or .ndjson`)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "object.json"), []byte(`{"a": 1}`), 0644))
+	_, err = dataTools.LoadData(ctx, "object.json", "")
+	assert.EqualError(t, err, "failed to load object.json: expected an array of objects")
+
+	dataTools.MaxFileSize = 10
+	_, err = dataTools.LoadData(ctx, "sales.csv", "")
+	assert.EqualError(t, err, "sales.csv is larger than 10 bytes")
+}
+
+func TestDataTools_DescribeData(t *testing.T) {
+	dataTools := newDataTools(t)
+
+	description, err := dataTools.DescribeData(context.Background(), "sales")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, description.Rows)
+	units := description.Columns[2]
+	assert.Equal(t, "integer", units.Type)
+	assert.Equal(t, 4, units.Distinct)
+	assert.Equal(t, 1.0, units.Min)
+	assert.Equal(t, 10.0, units.Max)
+	assert.InDelta(t, 3.8, *units.Mean, 1e-9)
+	shipped := description.Columns[4]
+	assert.Equal(t, 4, shipped.Count)
+	assert.Equal(t, 1, shipped.Nulls)
+	assert.Equal(t, []string{"true", "false"}, shipped.Examples)
+	assert.Nil(t, description.Columns[0].Mean)
+
+	_, err = dataTools.DescribeData(context.Background(), "missing")
+	assert.EqualError(t, err, "table missing not found; loaded tables: sales")
+	_, err = (&DataTools{}).DescribeData(context.Background(), "sales")
+	assert.EqualError(t, err, "table sales not found; load a file with LoadData first")
+}
+
+func TestDataTools_QueryData(t *testing.T) {
+	ctx := context.Background()
+	dataTools := newDataTools(t)
+
+	result, err := dataTools.QueryData(ctx, "sales", "units > 1 and (product = 'pen' or region != \"north\")", []string{"region", "units"}, nil, nil, "units desc", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "| region | units |\n| --- | --- |\n| north | 10 |\n| south | 4 |", result)
+
+	result, err = dataTools.QueryData(ctx, "sales", "", nil, []string{"product"}, []string{"count", "SUM(units)", "avg(price)"}, "sum(units) desc", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "| product | count | sum(units) | avg(price) |\n| --- | --- | --- | --- |\n| pen | 2 | 14 | 1.25 |\n| book | 2 | 4 | 12.5 |\n| desk, oak | 1 | 1 | 250 |", result)
+
+	result, err = dataTools.QueryData(ctx, "sales", "shipped is null or product contains 'OAK'", nil, nil, []string{"max(price)", "count_distinct(region)"}, "", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "| max(price) | count_distinct(region) |\n| --- | --- |\n| 250 | 1 |", result)
+
+	result, err = dataTools.QueryData(ctx, "sales", "region in ('east', 'south') and not shipped = false", []string{"product"}, nil, nil, "", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "| product |\n| --- |\n| desk, oak |\n| pen |", result)
+
+	result, err = dataTools.QueryData(ctx, "sales", "", []string{"region"}, nil, nil, "region, units", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "| region |\n| --- |\n| east |\n| north |\n[Showing the first 2 of 5 rows]", result)
+
+	result, err = dataTools.QueryData(ctx, "sales", "units > 100", nil, nil, nil, "", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "No rows", result)
+}
+
+func TestDataTools_QueryDataErrors(t *testing.T) {
+	ctx := context.Background()
+	dataTools := newDataTools(t)
+
+	tests := []struct {
+		filter     string
+		aggregates []string
+		sortBy     string
+		err        string
+	}{
+		{filter: "color = 'red'", err: "unknown column color; columns: region, product, units, price, shipped"},
+		{filter: "region = north", err: "invalid filter: invalid value north; quote text values, e.g. 'north'"},
+		{filter: "(units > 1", err: "invalid filter: missing closing parenthesis"},
+		{filter: "units ~ 1", err: "invalid filter: unknown operator ~"},
+		{filter: "region = 'north", err: "invalid filter: unterminated quote"},
+		{aggregates: []string{"sum"}, err: "aggregate sum requires a column, e.g. sum(price)"},
+		{aggregates: []string{"stddev(units)"}, err: "unknown aggregate stddev; functions: count, count_distinct, sum, avg, min, max, median"},
+		{sortBy: "weight desc", err: "unknown column weight; columns: region, product, units, price, shipped"},
+	}
+	for _, test := range tests {
+		_, err := dataTools.QueryData(ctx, "sales", test.filter, nil, nil, test.aggregates, test.sortBy, 0)
+		assert.EqualError(t, err, test.err, test.filter)
+	}
+}
+
+func TestDataTools_Tools(t *testing.T) {
+	assert.Len(t, (&DataTools{EnableAll: true}).Tools(), 3)
+	tools := (&DataTools{EnableQueryData: true}).Tools()
+	assert.Len(t, tools, 1)
+	assert.Equal(t, "QueryData", tools[0].Name)
+}
@@ -0,0 +1,733 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Harsh-2909/hermes-go/utils"
+)
+
+// DateTimeTools provides tools for current times, timezone conversions, date arithmetic,
+// natural language dates and business days.
+type DateTimeTools struct {
+	EnableNow               bool // Enable the Now tool
+	EnableConvertTimezone   bool // Enable the ConvertTimezone tool
+	EnableAddDuration       bool // Enable the AddDuration tool
+	EnableDiffDates         bool // Enable the DiffDates tool
+	EnableParseNaturalDate  bool // Enable the ParseNaturalDate tool
+	EnableAddBusinessDays   bool // Enable the AddBusinessDays tool
+	EnableCountBusinessDays bool // Enable the CountBusinessDays tool
+	EnableAll               bool // Enable all tools if true
+
+	DefaultTimezone string   // IANA name of the timezone used when none is provided, e.g. "Europe/Paris". Defaults to the local timezone
+	Holidays        []string // Dates in the format 2006-01-02 that are not business days, in addition to weekends
+
+	// Clock returns the current time. Defaults to time.Now; tests can set it to a fixed time.
+	Clock func() time.Time
+}
+
+// DateTimeInfo describes a point in time.
+type DateTimeInfo struct {
+	DateTime string `json:"datetime"` // RFC 3339, e.g. 2024-03-08T15:00:00+01:00
+	Timezone string `json:"timezone"`
+	Weekday  string `json:"weekday"`
+	Unix     int64  `json:"unix"`
+}
+
+// DateDifference is the difference between two dates, in calendar units and in totals.
+// All values are negative if the end is before the start.
+type DateDifference struct {
+	Years        int     `json:"years"`
+	Months       int     `json:"months"`
+	Days         int     `json:"days"`
+	Hours        int     `json:"hours"`
+	Minutes      int     `json:"minutes"`
+	Seconds      int     `json:"seconds"`
+	TotalDays    float64 `json:"total_days"`
+	TotalHours   float64 `json:"total_hours"`
+	TotalSeconds float64 `json:"total_seconds"`
+}
+
+// Tools returns a list of available tools based on enable flags.
+func (d *DateTimeTools) Tools() []Tool {
+	var tools []Tool
+
+	methods := []struct {
+		enabled bool
+		name    string
+	}{
+		{d.EnableNow || d.EnableAll, "Now"},
+		{d.EnableConvertTimezone || d.EnableAll, "ConvertTimezone"},
+		{d.EnableAddDuration || d.EnableAll, "AddDuration"},
+		{d.EnableDiffDates || d.EnableAll, "DiffDates"},
+		{d.EnableParseNaturalDate || d.EnableAll, "ParseNaturalDate"},
+		{d.EnableAddBusinessDays || d.EnableAll, "AddBusinessDays"},
+		{d.EnableCountBusinessDays || d.EnableAll, "CountBusinessDays"},
+	}
+	for _, method := range methods {
+		if !method.enabled {
+			continue
+		}
+		if tool, err := CreateToolFromMethod(d, method.name); err == nil {
+			tools = append(tools, tool)
+		} else {
+			utils.Logger.Error("Failed to create tool", "tool", method.name, "error", err)
+		}
+	}
+
+	return tools
+}
+
+// Now returns the current date and time.
+// @param [optional] timezone: IANA timezone, e.g. "America/New_York", or UTC offset, e.g. "+05:30". Defaults to the configured timezone
+// @return JSON object with the date and time in RFC 3339 format, the timezone, the weekday and the Unix timestamp
+func (d *DateTimeTools) Now(ctx context.Context, timezone string) (DateTimeInfo, error) {
+	loc, err := d.location(timezone)
+	if err != nil {
+		return DateTimeInfo{}, err
+	}
+	return newDateTimeInfo(d.now().In(loc)), nil
+}
+
+// ConvertTimezone converts a date and time to another timezone.
+// @param datetime: Date and time, e.g. "2024-03-08T15:00:00", "2024-03-08 15:00" or "tomorrow 9am"
+// @param [optional] from_timezone: Timezone of the date and time if it has no UTC offset. Defaults to the configured timezone
+// @param to_timezone: Timezone to convert to, e.g. "Asia/Tokyo"
+// @return JSON object with the converted date and time, the timezone, the weekday and the Unix timestamp
+func (d *DateTimeTools) ConvertTimezone(ctx context.Context, datetime, from_timezone, to_timezone string) (DateTimeInfo, error) {
+	from, err := d.location(from_timezone)
+	if err != nil {
+		return DateTimeInfo{}, err
+	}
+	if to_timezone == "" {
+		return DateTimeInfo{}, fmt.Errorf("no target timezone provided")
+	}
+	to, err := d.location(to_timezone)
+	if err != nil {
+		return DateTimeInfo{}, err
+	}
+	t, err := d.parseDateTime(datetime, from)
+	if err != nil {
+		return DateTimeInfo{}, err
+	}
+	return newDateTimeInfo(t.In(to)), nil
+}
+
+// AddDuration adds a duration to a date and time.
+// @param [optional] datetime: Date and time, e.g. "2024-03-08T15:00:00". Defaults to now
+// @param duration: Duration to add, e.g. "90 minutes", "2h30m", "1 year 2 months" or "3 weeks". Prefix it with "-" to subtract it
+// @param [optional] timezone: Timezone of the date and time if it has no UTC offset. Defaults to the configured timezone
+// @return JSON object with the resulting date and time, the timezone, the weekday and the Unix timestamp
+func (d *DateTimeTools) AddDuration(ctx context.Context, datetime, duration, timezone string) (DateTimeInfo, error) {
+	loc, err := d.location(timezone)
+	if err != nil {
+		return DateTimeInfo{}, err
+	}
+	t, err := d.parseDateTime(datetime, loc)
+	if err != nil {
+		return DateTimeInfo{}, err
+	}
+	if t, err = addDuration(t, duration); err != nil {
+		return DateTimeInfo{}, err
+	}
+	return newDateTimeInfo(t), nil
+}
+
+// DiffDates computes the difference between two dates and times.
+// @param start: Start date and time, e.g. "2024-01-31" or "2024-01-31T08:00:00"
+// @param [optional] end: End date and time. Defaults to now
+// @param [optional] timezone: Timezone of the dates if they have no UTC offset. Defaults to the configured timezone
+// @return JSON object with the difference in years, months, days, hours, minutes and seconds, and in total days, hours and seconds
+func (d *DateTimeTools) DiffDates(ctx context.Context, start, end, timezone string) (DateDifference, error) {
+	loc, err := d.location(timezone)
+	if err != nil {
+		return DateDifference{}, err
+	}
+	if start == "" {
+		return DateDifference{}, fmt.Errorf("no start date provided")
+	}
+	startTime, err := d.parseDateTime(start, loc)
+	if err != nil {
+		return DateDifference{}, err
+	}
+	endTime, err := d.parseDateTime(end, loc)
+	if err != nil {
+		return DateDifference{}, err
+	}
+
+	sign := 1
+	if endTime.Before(startTime) {
+		sign = -1
+		startTime, endTime = endTime, startTime
+	}
+	endTime = endTime.In(startTime.Location())
+	months := (endTime.Year()-startTime.Year())*12 + int(endTime.Month()-startTime.Month())
+	// Months are added as in AddDuration, so that e.g. from January 31 to February 29 is one month
+	if addMonths(startTime, months).After(endTime) {
+		months--
+	}
+	rest := endTime.Sub(addMonths(startTime, months))
+	days := int(rest / (24 * time.Hour))
+	rest -= time.Duration(days) * 24 * time.Hour
+	total := endTime.Sub(startTime)
+	return DateDifference{
+		Years:        sign * (months / 12),
+		Months:       sign * (months % 12),
+		Days:         sign * days,
+		Hours:        sign * int(rest/time.Hour),
+		Minutes:      sign * int(rest%time.Hour/time.Minute),
+		Seconds:      sign * int(rest%time.Minute/time.Second),
+		TotalDays:    float64(sign) * total.Hours() / 24,
+		TotalHours:   float64(sign) * total.Hours(),
+		TotalSeconds: float64(sign) * total.Seconds(),
+	}, nil
+}
+
+// ParseNaturalDate converts a natural language expression to a date and time.
+// Dates without a time are at midnight, and "next friday" is the first Friday after today.
+// @param expression: Expression such as "next friday 3pm", "tomorrow at 9:30am", "in 2 hours", "3 days ago", "last monday", "march 5 2025" or "2025-03-05 noon"
+// @param [optional] timezone: Timezone of the expression. Defaults to the configured timezone
+// @return JSON object with the date and time, the timezone, the weekday and the Unix timestamp
+func (d *DateTimeTools) ParseNaturalDate(ctx context.Context, expression, timezone string) (DateTimeInfo, error) {
+	loc, err := d.location(timezone)
+	if err != nil {
+		return DateTimeInfo{}, err
+	}
+	t, err := parseNaturalDate(expression, d.now().In(loc))
+	if err != nil {
+		return DateTimeInfo{}, err
+	}
+	return newDateTimeInfo(t), nil
+}
+
+// AddBusinessDays adds business days to a date, skipping weekends and holidays.
+// @param [optional] date: Start date, e.g. "2024-03-08". Defaults to today
+// @param days: Number of business days to add. Negative numbers subtract business days
+// @param [optional] timezone: Timezone of the date. Defaults to the configured timezone
+// @return JSON object with the resulting date and time, the timezone, the weekday and the Unix timestamp
+func (d *DateTimeTools) AddBusinessDays(ctx context.Context, date string, days int, timezone string) (DateTimeInfo, error) {
+	loc, err := d.location(timezone)
+	if err != nil {
+		return DateTimeInfo{}, err
+	}
+	t, err := d.parseDateTime(date, loc)
+	if err != nil {
+		return DateTimeInfo{}, err
+	}
+	if days > maxBusinessDays || days < -maxBusinessDays {
+		return DateTimeInfo{}, fmt.Errorf("days must be between %d and %d", -maxBusinessDays, maxBusinessDays)
+	}
+	step := 1
+	if days < 0 {
+		step, days = -1, -days
+	}
+	// Any 7 consecutive days have 5 weekdays, so whole weeks are skipped and the holidays on their weekdays added back.
+	// At least one business day is left to the loop, so that the result is a business day
+	weeks := max(days-1, 0) / 5
+	skipped := t.AddDate(0, 0, step*7*weeks)
+	if step > 0 {
+		days += d.weekdayHolidays(t.AddDate(0, 0, 1), skipped.AddDate(0, 0, 1))
+	} else {
+		days += d.weekdayHolidays(skipped, t)
+	}
+	t, days = skipped, days-5*weeks
+	for days > 0 {
+		if err := ctx.Err(); err != nil {
+			return DateTimeInfo{}, err
+		}
+		t = t.AddDate(0, 0, step)
+		if d.isBusinessDay(t) {
+			days--
+		}
+	}
+	return newDateTimeInfo(t), nil
+}
+
+// CountBusinessDays counts the business days between two dates, excluding weekends and holidays.
+// @param start: Start date, included, e.g. "2024-03-01"
+// @param end: End date, excluded, e.g. "2024-04-01"
+// @param [optional] timezone: Timezone of the dates. Defaults to the configured timezone
+// @return Number of business days, negative if the end is before the start
+func (d *DateTimeTools) CountBusinessDays(ctx context.Context, start, end, timezone string) (int, error) {
+	loc, err := d.location(timezone)
+	if err != nil {
+		return 0, err
+	}
+	if start == "" || end == "" {
+		return 0, fmt.Errorf("start and end dates are required")
+	}
+	startTime, err := d.parseDateTime(start, loc)
+	if err != nil {
+		return 0, err
+	}
+	endTime, err := d.parseDateTime(end, loc)
+	if err != nil {
+		return 0, err
+	}
+	sign := 1
+	if endTime.Before(startTime) {
+		sign = -1
+		startTime, endTime = endTime, startTime
+	}
+	endTime = endTime.In(startTime.Location())
+	// Dates are counted in UTC, where all days have 24 hours
+	startDate := time.Date(startTime.Year(), startTime.Month(), startTime.Day(), 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(endTime.Year(), endTime.Month(), endTime.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(endDate.Sub(startDate).Hours() / 24)
+	count := days / 7 * 5
+	for day := startDate.AddDate(0, 0, days/7*7); day.Before(endDate); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			count++
+		}
+	}
+	count -= d.weekdayHolidays(startDate, endDate)
+	return sign * count, nil
+}
+
+// maxBusinessDays is the maximum number of business days added by AddBusinessDays, about 380 years.
+const maxBusinessDays = 100000
+
+// weekdayHolidays counts the holidays from start, included, to end, excluded, which are not on weekends.
+func (d *DateTimeTools) weekdayHolidays(start, end time.Time) int {
+	from, to := start.Format(time.DateOnly), end.Format(time.DateOnly)
+	seen := make(map[string]bool)
+	count := 0
+	for _, holiday := range d.Holidays {
+		date, err := time.Parse(time.DateOnly, holiday)
+		if err != nil || seen[holiday] || holiday < from || holiday >= to {
+			continue
+		}
+		seen[holiday] = true
+		if date.Weekday() != time.Saturday && date.Weekday() != time.Sunday {
+			count++
+		}
+	}
+	return count
+}
+
+// now returns the current time of the clock.
+func (d *DateTimeTools) now() time.Time {
+	if d.Clock != nil {
+		return d.Clock()
+	}
+	return time.Now()
+}
+
+// isBusinessDay reports whether a date is neither a weekend day nor a holiday.
+func (d *DateTimeTools) isBusinessDay(t time.Time) bool {
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+	date := t.Format(time.DateOnly)
+	for _, holiday := range d.Holidays {
+		if holiday == date {
+			return false
+		}
+	}
+	return true
+}
+
+// utcOffsetRegexp matches UTC offsets such as "+05:30", "-0800", "UTC+5" and "GMT-03:00".
+var utcOffsetRegexp = regexp.MustCompile(`^(?:UTC|GMT)?([+-])(\d{1,2})(?::?(\d{2}))?$`)
+
+// location returns the timezone with the given name, or the default timezone if the name is empty.
+func (d *DateTimeTools) location(name string) (*time.Location, error) {
+	name = strings.TrimSpace(utils.FirstNonEmpty(name, d.DefaultTimezone))
+	switch strings.ToUpper(name) {
+	case "", "LOCAL":
+		return time.Local, nil
+	case "UTC", "GMT", "Z":
+		return time.UTC, nil
+	}
+	if match := utcOffsetRegexp.FindStringSubmatch(strings.ToUpper(name)); match != nil {
+		hours, _ := strconv.Atoi(match[2])
+		minutes, _ := strconv.Atoi(match[3] + strings.Repeat("0", 2-len(match[3])))
+		if hours > 14 || minutes > 59 {
+			return nil, fmt.Errorf("invalid UTC offset %q", name)
+		}
+		offset := hours*3600 + minutes*60
+		if match[1] == "-" {
+			offset = -offset
+		}
+		return time.FixedZone(name, offset), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q: use an IANA name such as Europe/London or a UTC offset such as +05:30", name)
+	}
+	return loc, nil
+}
+
+// dateTimeLayouts are the layouts accepted for dates and times, besides natural language expressions.
+var dateTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	time.DateOnly,
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+// parseDateTime parses a date and time in one of dateTimeLayouts or in natural language.
+// Values without a UTC offset are in loc, and an empty value is the current time.
+func (d *DateTimeTools) parseDateTime(value string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return d.now().In(loc), nil
+	}
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	t, err := parseNaturalDate(value, d.now().In(loc))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: use a date such as 2006-01-02, a date and time such as 2006-01-02T15:04:05 or an expression such as \"next friday 3pm\"", value)
+	}
+	return t, nil
+}
+
+// newDateTimeInfo describes a time in its location.
+func newDateTimeInfo(t time.Time) DateTimeInfo {
+	timezone := t.Location().String()
+	if timezone == "" {
+		timezone = t.Format("-07:00")
+	}
+	return DateTimeInfo{
+		DateTime: t.Format(time.RFC3339),
+		Timezone: timezone,
+		Weekday:  t.Weekday().String(),
+		Unix:     t.Unix(),
+	}
+}
+
+// durationUnit returns the unit of a word such as "hrs" or "weeks", or an empty string if it is not a unit.
+func durationUnit(word string) string {
+	switch word {
+	case "y", "yr", "yrs", "year", "years":
+		return "year"
+	case "mo", "mos", "month", "months":
+		return "month"
+	case "w", "wk", "wks", "week", "weeks":
+		return "week"
+	case "d", "day", "days":
+		return "day"
+	case "h", "hr", "hrs", "hour", "hours":
+		return "hour"
+	case "m", "min", "mins", "minute", "minutes":
+		return "minute"
+	case "s", "sec", "secs", "second", "seconds":
+		return "second"
+	}
+	return ""
+}
+
+// addUnits adds an amount of a unit to a time. Years, months, weeks and days are calendar units, and
+// adding months keeps the day of the month within the target month, e.g. January 31 plus 1 month is February 29 in 2024.
+func addUnits(t time.Time, unit string, amount float64) (time.Time, error) {
+	var result time.Time
+	switch unit {
+	case "year", "month":
+		if amount != math.Trunc(amount) {
+			return time.Time{}, fmt.Errorf("fractional %ss are not supported", unit)
+		}
+		months := amount
+		if unit == "year" {
+			months *= 12
+		}
+		if math.Abs(months) > 12*10000 {
+			return time.Time{}, fmt.Errorf("duration of %v %ss is too large", amount, unit)
+		}
+		result = addMonths(t, int(months))
+	case "week", "day":
+		days := amount
+		if unit == "week" {
+			days *= 7
+		}
+		if math.Abs(days) > 366*10000 {
+			return time.Time{}, fmt.Errorf("duration of %v %ss is too large", amount, unit)
+		}
+		if days == math.Trunc(days) {
+			result = t.AddDate(0, 0, int(days))
+		} else {
+			result = t.Add(time.Duration(days * float64(24*time.Hour)))
+		}
+	default:
+		unitDuration := map[string]time.Duration{"hour": time.Hour, "minute": time.Minute, "second": time.Second}[unit]
+		nanoseconds := amount * float64(unitDuration)
+		if math.Abs(nanoseconds) >= math.MaxInt64 {
+			return time.Time{}, fmt.Errorf("duration of %v %ss is too large", amount, unit)
+		}
+		result = t.Add(time.Duration(nanoseconds))
+	}
+	if result.Year() < 1 || result.Year() > 9999 {
+		return time.Time{}, fmt.Errorf("resulting date is out of range")
+	}
+	return result, nil
+}
+
+// addMonths adds months to a time, moving the day to the last day of the target month if it is shorter.
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), lastDay)-1)
+}
+
+// durationPartRegexp matches a part of a duration such as "3 days" or "30m".
+var durationPartRegexp = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([a-z]+)`)
+
+// addDuration adds a duration such as "1 year 2 months", "2h30m" or "-3 weeks" to a time.
+func addDuration(t time.Time, duration string) (time.Time, error) {
+	rest := strings.ToLower(strings.TrimSpace(duration))
+	sign := 1.0
+	if strings.HasPrefix(rest, "-") {
+		sign = -1
+	}
+	rest = strings.TrimSpace(strings.TrimLeft(rest, "+-"))
+	if rest == "" {
+		return time.Time{}, fmt.Errorf("no duration provided")
+	}
+	for rest != "" {
+		match := durationPartRegexp.FindStringSubmatch(rest)
+		if match == nil {
+			return time.Time{}, fmt.Errorf("invalid duration %q: use durations such as \"90 minutes\", \"2h30m\" or \"1 year 2 months\"", duration)
+		}
+		unit := durationUnit(match[2])
+		if unit == "" {
+			return time.Time{}, fmt.Errorf("invalid duration %q: unknown unit %q", duration, match[2])
+		}
+		amount, _ := strconv.ParseFloat(match[1], 64)
+		var err error
+		if t, err = addUnits(t, unit, sign*amount); err != nil {
+			return time.Time{}, err
+		}
+		rest = strings.TrimLeft(rest[len(match[0]):], " ,")
+		rest = strings.TrimPrefix(rest, "and ")
+	}
+	return t, nil
+}
+
+var (
+	weekdayNames = map[string]time.Weekday{
+		"sunday": time.Sunday, "sun": time.Sunday,
+		"monday": time.Monday, "mon": time.Monday,
+		"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+		"wednesday": time.Wednesday, "wed": time.Wednesday,
+		"thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday,
+		"friday": time.Friday, "fri": time.Friday,
+		"saturday": time.Saturday, "sat": time.Saturday,
+	}
+	monthNames = map[string]time.Month{
+		"january": time.January, "jan": time.January,
+		"february": time.February, "feb": time.February,
+		"march": time.March, "mar": time.March,
+		"april": time.April, "apr": time.April,
+		"may":  time.May,
+		"june": time.June, "jun": time.June,
+		"july": time.July, "jul": time.July,
+		"august": time.August, "aug": time.August,
+		"september": time.September, "sep": time.September, "sept": time.September,
+		"october": time.October, "oct": time.October,
+		"november": time.November, "nov": time.November,
+		"december": time.December, "dec": time.December,
+	}
+	numberWords = map[string]float64{
+		"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+		"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+	}
+
+	// clockTimeRegexp matches times such as "3pm", "9:30am" and "15:45:10".
+	clockTimeRegexp = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(?::(\d{2}))?(am|pm)?$`)
+	// dayOfMonthRegexp matches days of the month such as "5" and "21st".
+	dayOfMonthRegexp = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?$`)
+)
+
+// parseNaturalDate parses an expression such as "next friday 3pm" or "3 days ago" relative to now.
+func parseNaturalDate(expression string, now time.Time) (time.Time, error) {
+	words := strings.Fields(strings.NewReplacer(",", " ", ".", " ").Replace(strings.ToLower(expression)))
+	if len(words) == 0 {
+		return time.Time{}, fmt.Errorf("no expression provided")
+	}
+	unknown := func(word string) error {
+		return fmt.Errorf("could not parse %q: unexpected %q", expression, word)
+	}
+
+	t := now
+	dateSet, timeSet := false, false
+	hour, minute, second := 0, 0, 0
+	setDate := func(year int, month time.Month, day int) error {
+		date := time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+		if date.Day() != day {
+			return fmt.Errorf("could not parse %q: invalid date", expression)
+		}
+		t, dateSet = date, true
+		return nil
+	}
+	number := func(word string) (float64, bool) {
+		if n, ok := numberWords[word]; ok {
+			return n, true
+		}
+		n, err := strconv.ParseFloat(word, 64)
+		return n, err == nil && n >= 0
+	}
+	next := func(i int) string {
+		if i+1 < len(words) {
+			return words[i+1]
+		}
+		return ""
+	}
+
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if weekday, ok := weekdayNames[word]; ok {
+			days := (int(weekday) - int(t.Weekday()) + 7) % 7
+			t, dateSet = t.AddDate(0, 0, days), true
+			continue
+		}
+		if month, ok := monthNames[word]; ok {
+			// Month followed by a day and an optional year, e.g. "march 5th 2025"
+			match := dayOfMonthRegexp.FindStringSubmatch(next(i))
+			if match == nil {
+				return time.Time{}, unknown(word)
+			}
+			i++
+			day, _ := strconv.Atoi(match[1])
+			year := t.Year()
+			if y, err := strconv.Atoi(next(i)); err == nil && len(next(i)) == 4 {
+				year = y
+				i++
+			}
+			if err := setDate(year, month, day); err != nil {
+				return time.Time{}, err
+			}
+			continue
+		}
+		if date, err := time.ParseInLocation(time.DateOnly, word, t.Location()); err == nil {
+			if err := setDate(date.Year(), date.Month(), date.Day()); err != nil {
+				return time.Time{}, err
+			}
+			continue
+		}
+
+		switch word {
+		case "now", "at", "on", "the", "of", "and", "in", "from", "later":
+			// Filler words; durations are added unless followed by "ago"
+		case "today":
+			dateSet = true
+		case "tomorrow":
+			t, dateSet = t.AddDate(0, 0, 1), true
+		case "yesterday":
+			t, dateSet = t.AddDate(0, 0, -1), true
+		case "noon", "midday":
+			hour, minute, second, timeSet = 12, 0, 0, true
+		case "midnight":
+			hour, minute, second, timeSet = 0, 0, 0, true
+		case "next", "last", "this":
+			following := next(i)
+			i++
+			if weekday, ok := weekdayNames[following]; ok {
+				days := (int(weekday) - int(t.Weekday()) + 7) % 7
+				switch {
+				case word == "next" && days == 0:
+					days = 7
+				case word == "last":
+					days = -((int(t.Weekday()) - int(weekday) + 7) % 7)
+					if days == 0 {
+						days = -7
+					}
+				}
+				t, dateSet = t.AddDate(0, 0, days), true
+			} else if unit := durationUnit(following); unit != "" {
+				amount := map[string]float64{"next": 1, "last": -1, "this": 0}[word]
+				t, _ = addUnits(t, unit, amount)
+			} else {
+				return time.Time{}, unknown(word + " " + following)
+			}
+		default:
+			if match := dayOfMonthRegexp.FindStringSubmatch(word); match != nil && monthNames[next(i)] != 0 {
+				// Day followed by a month and an optional year, e.g. "5 march 2025"
+				day, _ := strconv.Atoi(match[1])
+				month := monthNames[next(i)]
+				i++
+				year := t.Year()
+				if y, err := strconv.Atoi(next(i)); err == nil && len(next(i)) == 4 {
+					year = y
+					i++
+				}
+				if err := setDate(year, month, day); err != nil {
+					return time.Time{}, err
+				}
+				continue
+			}
+			if amount, ok := number(word); ok && durationUnit(next(i)) != "" {
+				// Durations, e.g. "2 hours and 30 minutes ago"
+				var amounts []float64
+				var units []string
+				for ; i < len(words); i++ {
+					if words[i] == "and" {
+						continue
+					}
+					if amount, ok = number(words[i]); !ok || durationUnit(next(i)) == "" {
+						break
+					}
+					amounts = append(amounts, amount)
+					units = append(units, durationUnit(next(i)))
+					i++
+				}
+				sign := 1.0
+				if i < len(words) && words[i] == "ago" {
+					sign = -1
+				} else {
+					i--
+				}
+				for j, unit := range units {
+					var err error
+					if t, err = addUnits(t, unit, sign*amounts[j]); err != nil {
+						return time.Time{}, err
+					}
+				}
+				continue
+			}
+			clock := word
+			if next(i) == "am" || next(i) == "pm" {
+				clock += next(i)
+				i++
+			}
+			match := clockTimeRegexp.FindStringSubmatch(clock)
+			if match == nil || (match[2] == "" && match[4] == "") {
+				return time.Time{}, unknown(word)
+			}
+			hour, _ = strconv.Atoi(match[1])
+			minute, _ = strconv.Atoi(match[2] + strings.Repeat("0", 2-len(match[2])))
+			second, _ = strconv.Atoi(match[3] + strings.Repeat("0", 2-len(match[3])))
+			if match[4] != "" {
+				if hour < 1 || hour > 12 {
+					return time.Time{}, fmt.Errorf("could not parse %q: invalid time %q", expression, clock)
+				}
+				hour %= 12
+				if match[4] == "pm" {
+					hour += 12
+				}
+			}
+			if hour > 23 || minute > 59 || second > 59 {
+				return time.Time{}, fmt.Errorf("could not parse %q: invalid time %q", expression, clock)
+			}
+			timeSet = true
+		}
+	}
+
+	switch {
+	case timeSet:
+		return time.Date(t.Year(), t.Month(), t.Day(), hour, minute, second, 0, t.Location()), nil
+	case dateSet:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()), nil
+	}
+	return t, nil
+}
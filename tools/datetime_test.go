@@ -0,0 +1,247 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newDateTimeTools returns DateTimeTools whose clock is fixed on Wednesday 2024-03-06 10:30 UTC.
+func newDateTimeTools() *DateTimeTools {
+	return &DateTimeTools{
+		DefaultTimezone: "UTC",
+		Holidays:        []string{"2024-03-29"},
+		Clock:           func() time.Time { return time.Date(2024, 3, 6, 10, 30, 0, 0, time.UTC) },
+	}
+}
+
+func TestDateTimeTools_NowAndConvertTimezone(t *testing.T) {
+	ctx := context.Background()
+	dateTimeTools := newDateTimeTools()
+
+	now, err := dateTimeTools.Now(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, DateTimeInfo{DateTime: "2024-03-06T10:30:00Z", Timezone: "UTC", Weekday: "Wednesday", Unix: 1709721000}, now)
+	now, err = dateTimeTools.Now(ctx, "Asia/Kolkata")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-03-06T16:00:00+05:30", now.DateTime)
+	assert.Equal(t, "Asia/Kolkata", now.Timezone)
+	now, err = dateTimeTools.Now(ctx, "UTC-3")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-03-06T07:30:00-03:00", now.DateTime)
+	_, err = dateTimeTools.Now(ctx, "Mars/Olympus")
+	assert.EqualError(t, err, `unknown timezone "Mars/Olympus": use an IANA name such as Europe/London or a UTC offset such as +05:30`)
+
+	converted, err := dateTimeTools.ConvertTimezone(ctx, "2024-07-01 09:00", "America/New_York", "Europe/Paris")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-07-01T15:00:00+02:00", converted.DateTime)
+	converted, err = dateTimeTools.ConvertTimezone(ctx, "2024-01-01T23:00:00+01:00", "Asia/Tokyo", "UTC")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-01-01T22:00:00Z", converted.DateTime)
+	converted, err = dateTimeTools.ConvertTimezone(ctx, "tomorrow 9am", "", "Asia/Tokyo")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-03-07T18:00:00+09:00", converted.DateTime)
+	_, err = dateTimeTools.ConvertTimezone(ctx, "soon", "", "UTC")
+	assert.ErrorContains(t, err, `invalid date "soon"`)
+	_, err = dateTimeTools.ConvertTimezone(ctx, "2024-01-01", "", "")
+	assert.EqualError(t, err, "no target timezone provided")
+}
+
+func TestDateTimeTools_AddDurationAndDiffDates(t *testing.T) {
+	ctx := context.Background()
+	dateTimeTools := newDateTimeTools()
+
+	tests := []struct {
+		datetime, duration, expected string
+	}{
+		{"2024-01-31", "1 month", "2024-02-29T00:00:00Z"},
+		{"2024-03-31", "-1 month", "2024-02-29T00:00:00Z"},
+		{"2024-02-29", "1 year", "2025-02-28T00:00:00Z"},
+		{"2024-03-06T10:00:00", "2h30m", "2024-03-06T12:30:00Z"},
+		{"2024-03-06T10:00:00", "1 year, 2 weeks and 90 minutes", "2025-03-20T11:30:00Z"},
+		{"2024-03-06T10:00:00", "-1.5 days", "2024-03-04T22:00:00Z"},
+		{"", "3 days", "2024-03-09T10:30:00Z"},
+	}
+	for _, test := range tests {
+		result, err := dateTimeTools.AddDuration(ctx, test.datetime, test.duration, "")
+		assert.NoError(t, err, test.duration)
+		assert.Equal(t, test.expected, result.DateTime, test.duration)
+	}
+	_, err := dateTimeTools.AddDuration(ctx, "", "3 fortnights", "")
+	assert.EqualError(t, err, `invalid duration "3 fortnights": unknown unit "fortnights"`)
+	_, err = dateTimeTools.AddDuration(ctx, "", "0.5 months", "")
+	assert.EqualError(t, err, "fractional months are not supported")
+	_, err = dateTimeTools.AddDuration(ctx, "", "", "")
+	assert.EqualError(t, err, "no duration provided")
+	_, err = dateTimeTools.AddDuration(ctx, "", "99999999999 hours", "")
+	assert.EqualError(t, err, "duration of 9.9999999999e+10 hours is too large")
+	_, err = dateTimeTools.AddDuration(ctx, "", "9000 years", "")
+	assert.EqualError(t, err, "resulting date is out of range")
+
+	// DST starts on 2024-03-31 in Paris, so the day has 23 hours
+	result, err := dateTimeTools.AddDuration(ctx, "2024-03-30T12:00:00", "1 day", "Europe/Paris")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-03-31T12:00:00+02:00", result.DateTime)
+
+	difference, err := dateTimeTools.DiffDates(ctx, "2023-01-15T08:00:00", "2024-03-06T10:30:05", "")
+	assert.NoError(t, err)
+	assert.Equal(t, DateDifference{Years: 1, Months: 1, Days: 20, Hours: 2, Minutes: 30, Seconds: 5, TotalDays: 416.10422453703706, TotalHours: 9986.501388888889, TotalSeconds: 35951405}, difference)
+	difference, err = dateTimeTools.DiffDates(ctx, "2024-12-25", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, -9, difference.Months)
+	assert.Equal(t, -18, difference.Days)
+	assert.Equal(t, -13, difference.Hours)
+	assert.Equal(t, -30, difference.Minutes)
+
+	// Adding the difference to the start gives the end, including at the end of months
+	added, err := dateTimeTools.AddDuration(ctx, "2024-01-31", "1 month", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-02-29T00:00:00Z", added.DateTime)
+	difference, err = dateTimeTools.DiffDates(ctx, "2024-01-31", "2024-02-29", "")
+	assert.NoError(t, err)
+	assert.Equal(t, DateDifference{Months: 1, TotalDays: 29, TotalHours: 696, TotalSeconds: 2505600}, difference)
+	difference, err = dateTimeTools.DiffDates(ctx, "2024-03-31", "2024-01-30", "")
+	assert.NoError(t, err)
+	assert.Equal(t, -2, difference.Months)
+	assert.Equal(t, -1, difference.Days)
+	_, err = dateTimeTools.DiffDates(ctx, "", "", "")
+	assert.EqualError(t, err, "no start date provided")
+}
+
+func TestDateTimeTools_ParseNaturalDate(t *testing.T) {
+	ctx := context.Background()
+	dateTimeTools := newDateTimeTools()
+
+	tests := []struct {
+		expression, expected string
+	}{
+		{"now", "2024-03-06T10:30:00Z"},
+		{"today", "2024-03-06T00:00:00Z"},
+		{"tomorrow at 9:30am", "2024-03-07T09:30:00Z"},
+		{"yesterday noon", "2024-03-05T12:00:00Z"},
+		{"next friday 3pm", "2024-03-08T15:00:00Z"},
+		{"next wednesday", "2024-03-13T00:00:00Z"},
+		{"wednesday 18:00", "2024-03-06T18:00:00Z"},
+		{"last monday", "2024-03-04T00:00:00Z"},
+		{"last wed", "2024-02-28T00:00:00Z"},
+		{"in 2 hours and 15 minutes", "2024-03-06T12:45:00Z"},
+		{"3 days ago", "2024-03-03T10:30:00Z"},
+		{"a week ago", "2024-02-28T10:30:00Z"},
+		{"next month", "2024-04-06T10:30:00Z"},
+		{"March 5th, 2025 at 12 am", "2025-03-05T00:00:00Z"},
+		{"21 june 5:45 pm", "2024-06-21T17:45:00Z"},
+		{"2024-12-24 midnight", "2024-12-24T00:00:00Z"},
+	}
+	for _, test := range tests {
+		result, err := dateTimeTools.ParseNaturalDate(ctx, test.expression, "")
+		assert.NoError(t, err, test.expression)
+		assert.Equal(t, test.expected, result.DateTime, test.expression)
+	}
+
+	// Months keep the day within the target month
+	endOfMonth := &DateTimeTools{DefaultTimezone: "UTC", Clock: func() time.Time { return time.Date(2024, 3, 31, 8, 0, 0, 0, time.UTC) }}
+	result, err := endOfMonth.ParseNaturalDate(ctx, "last month", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-02-29T08:00:00Z", result.DateTime)
+	result, err = endOfMonth.ParseNaturalDate(ctx, "next month", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-04-30T08:00:00Z", result.DateTime)
+
+	result, err = dateTimeTools.ParseNaturalDate(ctx, "tomorrow 9am", "America/Los_Angeles")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-03-07T09:00:00-08:00", result.DateTime)
+
+	_, err = dateTimeTools.ParseNaturalDate(ctx, "next blue moon", "")
+	assert.EqualError(t, err, `could not parse "next blue moon": unexpected "next blue"`)
+	_, err = dateTimeTools.ParseNaturalDate(ctx, "february 30", "")
+	assert.EqualError(t, err, `could not parse "february 30": invalid date`)
+	_, err = dateTimeTools.ParseNaturalDate(ctx, "13pm", "")
+	assert.EqualError(t, err, `could not parse "13pm": invalid time "13pm"`)
+	_, err = dateTimeTools.ParseNaturalDate(ctx, "2025", "")
+	assert.EqualError(t, err, `could not parse "2025": unexpected "2025"`)
+	_, err = dateTimeTools.ParseNaturalDate(ctx, " ", "")
+	assert.EqualError(t, err, "no expression provided")
+}
+
+func TestDateTimeTools_BusinessDays(t *testing.T) {
+	ctx := context.Background()
+	dateTimeTools := newDateTimeTools()
+
+	result, err := dateTimeTools.AddBusinessDays(ctx, "2024-03-08", 1, "")
+	assert.NoError(t, err)
+	assert.Equal(t, DateTimeInfo{DateTime: "2024-03-11T00:00:00Z", Timezone: "UTC", Weekday: "Monday", Unix: 1710115200}, result)
+	result, err = dateTimeTools.AddBusinessDays(ctx, "2024-03-26", 5, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-04-03T00:00:00Z", result.DateTime)
+	result, err = dateTimeTools.AddBusinessDays(ctx, "2024-03-11", -2, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-03-07T00:00:00Z", result.DateTime)
+
+	count, err := dateTimeTools.CountBusinessDays(ctx, "2024-03-01", "2024-04-01", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 20, count)
+	count, err = dateTimeTools.CountBusinessDays(ctx, "2024-03-11", "2024-03-04", "")
+	assert.NoError(t, err)
+	assert.Equal(t, -5, count)
+	_, err = dateTimeTools.CountBusinessDays(ctx, "2024-03-01", "", "")
+	assert.EqualError(t, err, "start and end dates are required")
+
+	// Large values are computed without iterating over every day
+	_, err = dateTimeTools.AddBusinessDays(ctx, "2024-03-08", 1<<31, "")
+	assert.EqualError(t, err, "days must be between -100000 and 100000")
+	result, err = dateTimeTools.AddBusinessDays(ctx, "2024-03-08", 100000, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "2407-07-02T00:00:00Z", result.DateTime)
+	count, err = dateTimeTools.CountBusinessDays(ctx, "0001-01-01", "9999-12-31", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 2608613, count)
+}
+
+func TestDateTimeTools_BusinessDaysMatchDayByDay(t *testing.T) {
+	ctx := context.Background()
+	dateTimeTools := newDateTimeTools()
+	dateTimeTools.Holidays = []string{"2024-03-29", "2024-04-01", "2024-04-06", "2024-05-01", "2024-05-01"}
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	for offset := 0; offset < 14; offset++ {
+		from := start.AddDate(0, 0, offset)
+		for days := -30; days <= 30; days++ {
+			// Step day by day to the expected date
+			expected, remaining := from, days
+			for remaining != 0 {
+				step := 1
+				if remaining < 0 {
+					step = -1
+				}
+				expected = expected.AddDate(0, 0, step)
+				if dateTimeTools.isBusinessDay(expected) {
+					remaining -= step
+				}
+			}
+			result, err := dateTimeTools.AddBusinessDays(ctx, from.Format(time.DateOnly), days, "")
+			assert.NoError(t, err)
+			assert.Equal(t, expected.Format(time.RFC3339), result.DateTime, "%s + %d", from.Format(time.DateOnly), days)
+
+			count := 0
+			for day := from; day.Before(expected); day = day.AddDate(0, 0, 1) {
+				if dateTimeTools.isBusinessDay(day) {
+					count++
+				}
+			}
+			counted, err := dateTimeTools.CountBusinessDays(ctx, from.Format(time.DateOnly), expected.Format(time.DateOnly), "")
+			assert.NoError(t, err)
+			if days >= 0 {
+				assert.Equal(t, count, counted, "%s to %s", from.Format(time.DateOnly), expected.Format(time.DateOnly))
+			}
+		}
+	}
+}
+
+func TestDateTimeTools_Tools(t *testing.T) {
+	assert.Len(t, (&DateTimeTools{EnableAll: true}).Tools(), 7)
+	tools := (&DateTimeTools{EnableParseNaturalDate: true}).Tools()
+	assert.Len(t, tools, 1)
+	assert.Equal(t, "ParseNaturalDate", tools[0].Name)
+}
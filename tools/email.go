@@ -0,0 +1,715 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/smtp"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Harsh-2909/hermes-go/utils"
+	"github.com/emersion/go-imap"
+	"github.com/emersion/go-imap/client"
+	"github.com/emersion/go-message"
+	_ "github.com/emersion/go-message/charset" // Decodes emails in charsets other than UTF-8
+	"github.com/emersion/go-message/mail"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"golang.org/x/net/html"
+)
+
+// EmailTools provides tools for sending emails over SMTP and reading them over IMAP.
+// The ListInbox and ReadEmail tools are only available if IMAPHost is set.
+type EmailTools struct {
+	EnableSendEmail bool // Enable the SendEmail tool
+	EnableListInbox bool // Enable the ListInbox tool
+	EnableReadEmail bool // Enable the ReadEmail tool
+	EnableAll       bool // Enable all tools if true
+
+	From         string // Required address of the sender, e.g. "Agent <agent@example.com>"
+	SMTPHost     string // Host of the SMTP server
+	SMTPPort     int    // Port of the SMTP server. Defaults to 587, or 465 if SMTPSecurity is "tls"
+	SMTPUsername string // Username of the SMTP server. Authentication is skipped if empty
+	SMTPPassword string // Password of the SMTP server
+	SMTPSecurity string // "starttls" (default), "tls" for implicit TLS, or "none"
+
+	IMAPHost     string // Host of the IMAP server
+	IMAPPort     int    // Port of the IMAP server. Defaults to 993, or 143 if IMAPSecurity is "starttls" or "none"
+	IMAPUsername string // Username of the IMAP server. Defaults to SMTPUsername
+	IMAPPassword string // Password of the IMAP server. Defaults to SMTPPassword
+	IMAPSecurity string // "tls" (default), "starttls" or "none"
+
+	AllowedRecipients   []string      // Allowed addresses, e.g. "bob@example.com", or domains, e.g. "@example.com". All recipients are allowed if empty
+	AttachmentDirectory string        // Directory attachments are read from, with the path confinement of FileSystemTools. Attachments are disabled if empty
+	MaxAttachmentSize   int           // Maximum total size in bytes of the attachments of an email. Defaults to 10000000
+	MaxBodySize         int           // Maximum size in bytes of the body returned by ReadEmail. Defaults to 20000
+	DryRun              bool          // If true, SendEmail builds the email and returns it without sending it
+	TLSConfig           *tls.Config   // TLS configuration of the connections, e.g. for custom certificate authorities
+	Timeout             time.Duration // Maximum duration of a connection to a server. Defaults to 30 seconds
+}
+
+// EmailSummary is an email listed by the ListInbox tool.
+type EmailSummary struct {
+	UID     uint32 `json:"uid"`
+	From    string `json:"from"`
+	Subject string `json:"subject"`
+	Date    string `json:"date"`
+	Seen    bool   `json:"seen"`
+}
+
+// Email is an email read by the ReadEmail tool.
+type Email struct {
+	UID         uint32   `json:"uid"`
+	From        string   `json:"from"`
+	To          []string `json:"to"`
+	Cc          []string `json:"cc,omitempty"`
+	Subject     string   `json:"subject"`
+	Date        string   `json:"date"`
+	Body        string   `json:"body"`
+	Attachments []string `json:"attachments,omitempty"` // File names of the attachments
+	Truncated   bool     `json:"truncated,omitempty"`
+}
+
+// emailAttachment is a file attached to an email.
+type emailAttachment struct {
+	name string
+	data []byte
+}
+
+// Tools returns a list of available tools based on enable flags.
+func (e *EmailTools) Tools() []Tool {
+	var tools []Tool
+
+	methods := []struct {
+		enabled bool
+		name    string
+	}{
+		{e.EnableSendEmail || e.EnableAll, "SendEmail"},
+		{(e.EnableListInbox || e.EnableAll) && e.IMAPHost != "", "ListInbox"},
+		{(e.EnableReadEmail || e.EnableAll) && e.IMAPHost != "", "ReadEmail"},
+	}
+	for _, method := range methods {
+		if !method.enabled {
+			continue
+		}
+		if tool, err := CreateToolFromMethod(e, method.name); err == nil {
+			tools = append(tools, tool)
+		} else {
+			utils.Logger.Error("Failed to create tool", "tool", method.name, "error", err)
+		}
+	}
+
+	return tools
+}
+
+// SendEmail sends an email.
+// @param to: Addresses of the recipients, e.g. ["bob@example.com", "Alice <alice@example.com>"]
+// @param [optional] cc: Addresses of the recipients in copy
+// @param subject: Subject of the email
+// @param body: Body of the email
+// @param [optional] markdown: If true, the body is Markdown and the email also has an HTML version of it
+// @param [optional] attachments: Paths of the files to attach, relative to the attachment directory
+// @return Confirmation message, or the email that would be sent in dry-run mode
+func (e *EmailTools) SendEmail(ctx context.Context, to, cc []string, subject, body string, markdown bool, attachments []string) (string, error) {
+	if e.From == "" {
+		return "", fmt.Errorf("no sender address configured")
+	}
+	from, err := mail.ParseAddress(e.From)
+	if err != nil {
+		return "", fmt.Errorf("invalid sender address %q: %v", e.From, err)
+	}
+	if len(to) == 0 {
+		return "", fmt.Errorf("no recipients provided")
+	}
+	toAddresses, err := e.parseRecipients(to)
+	if err != nil {
+		return "", err
+	}
+	ccAddresses, err := e.parseRecipients(cc)
+	if err != nil {
+		return "", err
+	}
+	if strings.ContainsAny(subject, "\r\n") {
+		return "", fmt.Errorf("subject must be a single line")
+	}
+	files, err := e.readAttachments(attachments)
+	if err != nil {
+		return "", err
+	}
+	htmlBody := ""
+	if markdown {
+		var buf bytes.Buffer
+		if err := goldmark.New(goldmark.WithExtensions(extension.GFM)).Convert([]byte(body), &buf); err != nil {
+			return "", fmt.Errorf("failed to convert Markdown: %v", err)
+		}
+		htmlBody = buf.String()
+	}
+	message, err := buildEmail(from, toAddresses, ccAddresses, subject, body, htmlBody, files)
+	if err != nil {
+		return "", err
+	}
+
+	var recipients []string
+	for _, address := range append(toAddresses, ccAddresses...) {
+		recipients = append(recipients, address.Address)
+	}
+	if e.DryRun {
+		var sb strings.Builder
+		sb.WriteString("Dry run, the email was not sent.\n")
+		sb.WriteString("From: " + from.String() + "\n")
+		sb.WriteString("To: " + formatAddresses(toAddresses) + "\n")
+		if len(ccAddresses) > 0 {
+			sb.WriteString("Cc: " + formatAddresses(ccAddresses) + "\n")
+		}
+		sb.WriteString("Subject: " + subject + "\n")
+		if len(files) > 0 {
+			names := make([]string, len(files))
+			for i, file := range files {
+				names[i] = fmt.Sprintf("%s (%d bytes)", file.name, len(file.data))
+			}
+			sb.WriteString("Attachments: " + strings.Join(names, ", ") + "\n")
+		}
+		sb.WriteString("\n" + body)
+		return sb.String(), nil
+	}
+
+	if err := e.sendSMTP(ctx, from.Address, recipients, message); err != nil {
+		return "", err
+	}
+	utils.Logger.Debug("Sent email", "to", recipients, "subject", subject)
+	return fmt.Sprintf("Sent email %q to %s", subject, strings.Join(recipients, ", ")), nil
+}
+
+// ListInbox lists the latest emails of a mailbox, newest first.
+// @param [optional] mailbox: Name of the mailbox. Defaults to INBOX
+// @param [optional] limit: Maximum number of emails. Defaults to 20
+// @param [optional] unread_only: If true, only lists the unread emails
+// @return JSON array of emails with their UID, sender, subject, date and whether they were read
+func (e *EmailTools) ListInbox(ctx context.Context, mailbox string, limit int, unread_only bool) ([]EmailSummary, error) {
+	if mailbox == "" {
+		mailbox = "INBOX"
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	c, closeClient, err := e.imapClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer closeClient()
+
+	status, err := c.Select(mailbox, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mailbox %s: %v", mailbox, err)
+	}
+	summaries := []EmailSummary{}
+	seqSet := new(imap.SeqSet)
+	if unread_only {
+		criteria := imap.NewSearchCriteria()
+		criteria.WithoutFlags = []string{imap.SeenFlag}
+		seqNums, err := c.Search(criteria)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search mailbox %s: %v", mailbox, err)
+		}
+		sort.Slice(seqNums, func(i, j int) bool { return seqNums[i] < seqNums[j] })
+		if len(seqNums) > limit {
+			seqNums = seqNums[len(seqNums)-limit:]
+		}
+		seqSet.AddNum(seqNums...)
+	} else if status.Messages > 0 {
+		first := uint32(1)
+		if status.Messages > uint32(limit) {
+			first = status.Messages - uint32(limit) + 1
+		}
+		seqSet.AddRange(first, status.Messages)
+	}
+	if seqSet.Empty() {
+		return summaries, nil
+	}
+
+	messages := make(chan *imap.Message, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Fetch(seqSet, []imap.FetchItem{imap.FetchUid, imap.FetchEnvelope, imap.FetchFlags}, messages)
+	}()
+	var fetched []*imap.Message
+	for msg := range messages {
+		fetched = append(fetched, msg)
+	}
+	if err := <-done; err != nil {
+		return nil, fmt.Errorf("failed to fetch emails: %v", err)
+	}
+	sort.Slice(fetched, func(i, j int) bool { return fetched[i].SeqNum > fetched[j].SeqNum })
+	for _, msg := range fetched {
+		summary := EmailSummary{UID: msg.Uid}
+		if msg.Envelope != nil {
+			summary.Subject = msg.Envelope.Subject
+			if len(msg.Envelope.From) > 0 {
+				summary.From = formatIMAPAddress(msg.Envelope.From[0])
+			}
+			if !msg.Envelope.Date.IsZero() {
+				summary.Date = msg.Envelope.Date.Format(time.RFC3339)
+			}
+		}
+		for _, flag := range msg.Flags {
+			if flag == imap.SeenFlag {
+				summary.Seen = true
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+// ReadEmail reads an email without marking it as read.
+// @param uid: UID of the email, as returned by ListInbox
+// @param [optional] mailbox: Name of the mailbox. Defaults to INBOX
+// @return JSON object with the sender, recipients, subject, date, text body and attachment names of the email
+func (e *EmailTools) ReadEmail(ctx context.Context, uid int, mailbox string) (Email, error) {
+	if mailbox == "" {
+		mailbox = "INBOX"
+	}
+	if uid <= 0 {
+		return Email{}, fmt.Errorf("invalid UID %d", uid)
+	}
+	c, closeClient, err := e.imapClient(ctx)
+	if err != nil {
+		return Email{}, err
+	}
+	defer closeClient()
+
+	if _, err := c.Select(mailbox, true); err != nil {
+		return Email{}, fmt.Errorf("failed to open mailbox %s: %v", mailbox, err)
+	}
+	seqSet := new(imap.SeqSet)
+	seqSet.AddNum(uint32(uid))
+	section := &imap.BodySectionName{Peek: true}
+	messages := make(chan *imap.Message, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- c.UidFetch(seqSet, []imap.FetchItem{imap.FetchUid, section.FetchItem()}, messages)
+	}()
+	var fetched *imap.Message
+	for msg := range messages {
+		fetched = msg
+	}
+	if err := <-done; err != nil {
+		return Email{}, fmt.Errorf("failed to fetch email: %v", err)
+	}
+	if fetched == nil || fetched.GetBody(section) == nil {
+		return Email{}, fmt.Errorf("email %d not found in %s", uid, mailbox)
+	}
+
+	email, err := parseEmail(fetched.GetBody(section))
+	if err != nil {
+		return Email{}, fmt.Errorf("failed to parse email: %v", err)
+	}
+	email.UID = fetched.Uid
+	maxBodySize := e.MaxBodySize
+	if maxBodySize <= 0 {
+		maxBodySize = 20000
+	}
+	if len(email.Body) > maxBodySize {
+		email.Body = strings.ToValidUTF8(email.Body[:maxBodySize], "")
+		email.Truncated = true
+	}
+	return email, nil
+}
+
+// parseRecipients parses addresses and checks that they are allowed.
+func (e *EmailTools) parseRecipients(recipients []string) ([]*mail.Address, error) {
+	var addresses []*mail.Address
+	for _, recipient := range recipients {
+		address, err := mail.ParseAddress(recipient)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q: %v", recipient, err)
+		}
+		if !e.recipientAllowed(address.Address) {
+			return nil, fmt.Errorf("recipient %s is not allowed", address.Address)
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses, nil
+}
+
+// recipientAllowed reports whether an address matches AllowedRecipients, case-insensitively.
+func (e *EmailTools) recipientAllowed(address string) bool {
+	if len(e.AllowedRecipients) == 0 {
+		return true
+	}
+	address = strings.ToLower(address)
+	domain := address[strings.LastIndex(address, "@")+1:]
+	for _, allowed := range e.AllowedRecipients {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if allowed == address || strings.TrimPrefix(allowed, "@") == domain && !strings.Contains(strings.TrimPrefix(allowed, "@"), "@") {
+			return true
+		}
+	}
+	return false
+}
+
+// readAttachments reads the attached files from AttachmentDirectory.
+func (e *EmailTools) readAttachments(paths []string) ([]emailAttachment, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	if e.AttachmentDirectory == "" {
+		return nil, fmt.Errorf("attachments are disabled: no attachment directory configured")
+	}
+	maxSize := e.MaxAttachmentSize
+	if maxSize <= 0 {
+		maxSize = 10000000
+	}
+	sandbox := &FileSystemTools{TargetDirectory: e.AttachmentDirectory}
+	var attachments []emailAttachment
+	total := 0
+	for _, path := range paths {
+		filePath, err := sandbox.resolve(path)
+		if err != nil {
+			return nil, err
+		}
+		info, err := os.Stat(filePath)
+		if err != nil || info.IsDir() {
+			return nil, fmt.Errorf("attachment not found: %s", path)
+		}
+		total += int(info.Size())
+		if total > maxSize {
+			return nil, fmt.Errorf("attachments are larger than %d bytes", maxSize)
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read attachment %s: %v", path, err)
+		}
+		attachments = append(attachments, emailAttachment{name: filepath.Base(filePath), data: data})
+	}
+	return attachments, nil
+}
+
+// buildEmail builds a MIME email with a text body, an optional HTML alternative and attachments.
+func buildEmail(from *mail.Address, to, cc []*mail.Address, subject, text, htmlBody string, attachments []emailAttachment) ([]byte, error) {
+	var header mail.Header
+	header.SetDate(time.Now())
+	header.SetAddressList("From", []*mail.Address{from})
+	header.SetAddressList("To", to)
+	if len(cc) > 0 {
+		header.SetAddressList("Cc", cc)
+	}
+	header.SetSubject(subject)
+	if err := header.GenerateMessageIDWithHostname(from.Address[strings.LastIndex(from.Address, "@")+1:]); err != nil {
+		return nil, fmt.Errorf("failed to generate message ID: %v", err)
+	}
+
+	var buf bytes.Buffer
+	writeBody := func(create func(mail.InlineHeader) (io.WriteCloser, error), contentType, content string) error {
+		var partHeader mail.InlineHeader
+		partHeader.SetContentType(contentType, map[string]string{"charset": "utf-8"})
+		w, err := create(partHeader)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, content); err != nil {
+			return err
+		}
+		return w.Close()
+	}
+	writeInline := func(w *mail.InlineWriter) error {
+		if err := writeBody(w.CreatePart, "text/plain", text); err != nil {
+			return err
+		}
+		if err := writeBody(w.CreatePart, "text/html", htmlBody); err != nil {
+			return err
+		}
+		return w.Close()
+	}
+
+	var err error
+	switch {
+	case len(attachments) == 0 && htmlBody == "":
+		header.SetContentType("text/plain", map[string]string{"charset": "utf-8"})
+		var w io.WriteCloser
+		if w, err = mail.CreateSingleInlineWriter(&buf, header); err == nil {
+			if _, err = io.WriteString(w, text); err == nil {
+				err = w.Close()
+			}
+		}
+	case len(attachments) == 0:
+		var w *mail.InlineWriter
+		if w, err = mail.CreateInlineWriter(&buf, header); err == nil {
+			err = writeInline(w)
+		}
+	default:
+		err = writeMixedEmail(&buf, header, attachments, func(w *mail.Writer) error {
+			if htmlBody == "" {
+				return writeBody(w.CreateSingleInline, "text/plain", text)
+			}
+			inline, err := w.CreateInline()
+			if err != nil {
+				return err
+			}
+			return writeInline(inline)
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to build email: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeMixedEmail writes an email with a body written by writeBody followed by attachments.
+func writeMixedEmail(buf *bytes.Buffer, header mail.Header, attachments []emailAttachment, writeBody func(*mail.Writer) error) error {
+	w, err := mail.CreateWriter(buf, header)
+	if err != nil {
+		return err
+	}
+	if err := writeBody(w); err != nil {
+		return err
+	}
+	for _, attachment := range attachments {
+		var attachmentHeader mail.AttachmentHeader
+		contentType := mime.TypeByExtension(filepath.Ext(attachment.name))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		attachmentHeader.Set("Content-Type", contentType)
+		attachmentHeader.SetFilename(attachment.name)
+		aw, err := w.CreateAttachment(attachmentHeader)
+		if err != nil {
+			return err
+		}
+		if _, err := aw.Write(attachment.data); err != nil {
+			return err
+		}
+		if err := aw.Close(); err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
+
+// parseEmail parses a MIME email, preferring its text body to its HTML body converted to Markdown.
+func parseEmail(r io.Reader) (Email, error) {
+	reader, err := mail.CreateReader(r)
+	if err != nil && !message.IsUnknownCharset(err) {
+		return Email{}, err
+	}
+	var email Email
+	if from, err := reader.Header.AddressList("From"); err == nil && len(from) > 0 {
+		email.From = from[0].String()
+	}
+	for key, addresses := range map[string]*[]string{"To": &email.To, "Cc": &email.Cc} {
+		if list, err := reader.Header.AddressList(key); err == nil {
+			for _, address := range list {
+				*addresses = append(*addresses, address.String())
+			}
+		}
+	}
+	email.Subject, _ = reader.Header.Subject()
+	if date, err := reader.Header.Date(); err == nil && !date.IsZero() {
+		email.Date = date.Format(time.RFC3339)
+	}
+
+	var text, htmlBody string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		} else if err != nil && !message.IsUnknownCharset(err) {
+			return Email{}, err
+		}
+		switch header := part.Header.(type) {
+		case *mail.InlineHeader:
+			contentType, _, _ := header.ContentType()
+			content, err := io.ReadAll(part.Body)
+			if err != nil {
+				return Email{}, err
+			}
+			if !utf8.Valid(content) {
+				content = []byte(strings.ToValidUTF8(string(content), "�"))
+			}
+			if contentType == "text/plain" && text == "" {
+				text = string(content)
+			} else if contentType == "text/html" && htmlBody == "" {
+				htmlBody = string(content)
+			}
+		case *mail.AttachmentHeader:
+			name, _ := header.Filename()
+			email.Attachments = append(email.Attachments, name)
+		}
+	}
+	email.Body = strings.TrimSpace(text)
+	if email.Body == "" && htmlBody != "" {
+		if doc, err := html.Parse(strings.NewReader(htmlBody)); err == nil {
+			_, email.Body, _ = htmlToMarkdown(doc, &url.URL{})
+		}
+	}
+	return email, nil
+}
+
+// formatAddresses formats addresses for a header.
+func formatAddresses(addresses []*mail.Address) string {
+	formatted := make([]string, len(addresses))
+	for i, address := range addresses {
+		formatted[i] = address.String()
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// formatIMAPAddress formats an address of an IMAP envelope, e.g. "Bob <bob@example.com>".
+func formatIMAPAddress(address *imap.Address) string {
+	email := address.Address()
+	if address.PersonalName == "" {
+		return email
+	}
+	return fmt.Sprintf("%s <%s>", address.PersonalName, email)
+}
+
+// timeout returns the maximum duration of a connection to a server.
+func (e *EmailTools) timeout() time.Duration {
+	if e.Timeout > 0 {
+		return e.Timeout
+	}
+	return 30 * time.Second
+}
+
+// tlsConfig returns the TLS configuration of a connection to host.
+func (e *EmailTools) tlsConfig(host string) *tls.Config {
+	config := &tls.Config{}
+	if e.TLSConfig != nil {
+		config = e.TLSConfig.Clone()
+	}
+	if config.ServerName == "" {
+		config.ServerName = host
+	}
+	return config
+}
+
+// sendSMTP sends an email to recipients through the SMTP server.
+func (e *EmailTools) sendSMTP(ctx context.Context, from string, recipients []string, message []byte) error {
+	if e.SMTPHost == "" {
+		return fmt.Errorf("no SMTP host configured")
+	}
+	security := strings.ToLower(utils.FirstNonEmpty(e.SMTPSecurity, "starttls"))
+	port := e.SMTPPort
+	switch {
+	case security != "starttls" && security != "tls" && security != "none":
+		return fmt.Errorf("invalid SMTP security %q: expected starttls, tls or none", e.SMTPSecurity)
+	case port == 0 && security == "tls":
+		port = 465
+	case port == 0:
+		port = 587
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, e.timeout())
+	defer cancel()
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", net.JoinHostPort(e.SMTPHost, strconv.Itoa(port)))
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %v", err)
+	}
+	defer conn.Close()
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+	if security == "tls" {
+		conn = tls.Client(conn, e.tlsConfig(e.SMTPHost))
+	}
+
+	c, err := smtp.NewClient(conn, e.SMTPHost)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %v", err)
+	}
+	defer c.Close()
+	if security == "starttls" {
+		// Credentials and emails are never sent in clear text unless the security is "none"
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("SMTP server does not support STARTTLS")
+		}
+		if err := c.StartTLS(e.tlsConfig(e.SMTPHost)); err != nil {
+			return fmt.Errorf("failed to start TLS: %v", err)
+		}
+	}
+	if e.SMTPUsername != "" {
+		if err := c.Auth(smtp.PlainAuth("", e.SMTPUsername, e.SMTPPassword, e.SMTPHost)); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %v", err)
+		}
+	}
+	if err := c.Mail(from); err != nil {
+		return fmt.Errorf("SMTP server rejected sender %s: %v", from, err)
+	}
+	for _, recipient := range recipients {
+		if err := c.Rcpt(recipient); err != nil {
+			return fmt.Errorf("SMTP server rejected recipient %s: %v", recipient, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("failed to send email: %v", err)
+	}
+	if _, err := w.Write(message); err != nil {
+		return fmt.Errorf("failed to send email: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send email: %v", err)
+	}
+	return c.Quit()
+}
+
+// imapClient connects and logs in to the IMAP server. The returned function logs out.
+func (e *EmailTools) imapClient(ctx context.Context) (*client.Client, func(), error) {
+	if e.IMAPHost == "" {
+		return nil, nil, fmt.Errorf("no IMAP host configured")
+	}
+	security := strings.ToLower(utils.FirstNonEmpty(e.IMAPSecurity, "tls"))
+	port := e.IMAPPort
+	switch {
+	case security != "starttls" && security != "tls" && security != "none":
+		return nil, nil, fmt.Errorf("invalid IMAP security %q: expected tls, starttls or none", e.IMAPSecurity)
+	case port == 0 && security == "tls":
+		port = 993
+	case port == 0:
+		port = 143
+	}
+
+	address := net.JoinHostPort(e.IMAPHost, strconv.Itoa(port))
+	dialer := &net.Dialer{Timeout: e.timeout()}
+	var c *client.Client
+	var err error
+	if security == "tls" {
+		c, err = client.DialWithDialerTLS(dialer, address, e.tlsConfig(e.IMAPHost))
+	} else {
+		c, err = client.DialWithDialer(dialer, address)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to IMAP server: %v", err)
+	}
+	c.Timeout = e.timeout()
+	// The client does not take a context, so the connection is closed if the context is canceled
+	stop := context.AfterFunc(ctx, func() { c.Terminate() })
+	closeClient := func() {
+		stop()
+		c.Logout()
+	}
+
+	if security == "starttls" {
+		if err := c.StartTLS(e.tlsConfig(e.IMAPHost)); err != nil {
+			closeClient()
+			return nil, nil, fmt.Errorf("failed to start TLS: %v", err)
+		}
+	}
+	username := utils.FirstNonEmpty(e.IMAPUsername, e.SMTPUsername)
+	password := utils.FirstNonEmpty(e.IMAPPassword, e.SMTPPassword)
+	if err := c.Login(username, password); err != nil {
+		closeClient()
+		return nil, nil, fmt.Errorf("IMAP authentication failed: %v", err)
+	}
+	return c, closeClient, nil
+}
@@ -0,0 +1,303 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/emersion/go-imap"
+	"github.com/emersion/go-imap/backend/memory"
+	"github.com/emersion/go-imap/server"
+	"github.com/emersion/go-message/mail"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer is an in-process SMTP server recording the emails it receives.
+type fakeSMTPServer struct {
+	host      string
+	port      int
+	tlsConfig *tls.Config // STARTTLS is supported if set
+
+	mu         sync.Mutex
+	auth       string
+	usedTLS    bool
+	from       string
+	recipients []string
+	data       string
+}
+
+func newFakeSMTPServer(t *testing.T, tlsConfig *tls.Config) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	s := &fakeSMTPServer{host: "127.0.0.1", port: listener.Addr().(*net.TCPAddr).Port, tlsConfig: tlsConfig}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer func() { conn.Close() }()
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 localhost ESMTP")
+	secure := false
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		switch strings.ToUpper(strings.Fields(line + " ")[0]) {
+		case "EHLO", "HELO":
+			text.PrintfLine("250-localhost")
+			if s.tlsConfig != nil && !secure {
+				text.PrintfLine("250-STARTTLS")
+			}
+			text.PrintfLine("250 AUTH PLAIN")
+		case "STARTTLS":
+			text.PrintfLine("220 Ready to start TLS")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if tlsConn.Handshake() != nil {
+				s.mu.Unlock()
+				return
+			}
+			conn, text, secure, s.usedTLS = tlsConn, textproto.NewConn(tlsConn), true, true
+		case "AUTH":
+			decoded, _ := base64.StdEncoding.DecodeString(strings.Fields(line)[2])
+			s.auth = string(decoded)
+			text.PrintfLine("235 Authenticated")
+		case "MAIL":
+			s.from = line
+			text.PrintfLine("250 OK")
+		case "RCPT":
+			s.recipients = append(s.recipients, line)
+			text.PrintfLine("250 OK")
+		case "DATA":
+			text.PrintfLine("354 Send data")
+			data, _ := text.ReadDotBytes()
+			s.data = string(data)
+			text.PrintfLine("250 Queued")
+		case "QUIT":
+			text.PrintfLine("221 Bye")
+			s.mu.Unlock()
+			return
+		default:
+			text.PrintfLine("502 Not implemented")
+		}
+		s.mu.Unlock()
+	}
+}
+
+// newTestCertificate returns a self-signed certificate for 127.0.0.1 and a pool trusting it.
+func newTestCertificate(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(certificate)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, pool
+}
+
+func TestEmailTools_SendEmail(t *testing.T) {
+	ctx := context.Background()
+	certificate, pool := newTestCertificate(t)
+	smtpServer := newFakeSMTPServer(t, &tls.Config{Certificates: []tls.Certificate{certificate}})
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "report.csv"), []byte("a,b\n1,2\n"), 0644))
+
+	emailTools := &EmailTools{
+		From:                "Agent <agent@example.com>",
+		SMTPHost:            smtpServer.host,
+		SMTPPort:            smtpServer.port,
+		SMTPUsername:        "agent",
+		SMTPPassword:        "secret",
+		TLSConfig:           &tls.Config{RootCAs: pool},
+		AllowedRecipients:   []string{"@example.com", "boss@corp.com"},
+		AttachmentDirectory: dir,
+	}
+	msg, err := emailTools.SendEmail(ctx, []string{"Bob <bob@example.com>"}, []string{"BOSS@corp.com"}, "Weekly report", "The report is **ready**.", true, []string{"report.csv"})
+	assert.NoError(t, err)
+	assert.Equal(t, `Sent email "Weekly report" to bob@example.com, BOSS@corp.com`, msg)
+
+	smtpServer.mu.Lock()
+	defer smtpServer.mu.Unlock()
+	assert.True(t, smtpServer.usedTLS)
+	assert.Equal(t, "\x00agent\x00secret", smtpServer.auth)
+	assert.Equal(t, "MAIL FROM:<agent@example.com>", smtpServer.from)
+	assert.Equal(t, []string{"RCPT TO:<bob@example.com>", "RCPT TO:<BOSS@corp.com>"}, smtpServer.recipients)
+	assert.Contains(t, smtpServer.data, "Content-Type: text/html")
+	assert.Contains(t, smtpServer.data, "<strong>ready</strong>")
+	email, err := parseEmail(strings.NewReader(smtpServer.data))
+	assert.NoError(t, err)
+	assert.Equal(t, `"Agent" <agent@example.com>`, email.From)
+	assert.Equal(t, []string{`"Bob" <bob@example.com>`}, email.To)
+	assert.Equal(t, []string{"<BOSS@corp.com>"}, email.Cc)
+	assert.Equal(t, "Weekly report", email.Subject)
+	assert.Equal(t, "The report is **ready**.", email.Body)
+	assert.Equal(t, []string{"report.csv"}, email.Attachments)
+}
+
+func TestEmailTools_SendEmailErrors(t *testing.T) {
+	ctx := context.Background()
+	plainServer := newFakeSMTPServer(t, nil)
+	emailTools := &EmailTools{From: "agent@example.com", SMTPHost: plainServer.host, SMTPPort: plainServer.port, AllowedRecipients: []string{"@example.com"}}
+
+	tests := []struct {
+		to          []string
+		subject     string
+		attachments []string
+		err         string
+	}{
+		{to: nil, err: "no recipients provided"},
+		{to: []string{"eve@evil.com"}, err: "recipient eve@evil.com is not allowed"},
+		{to: []string{"eve@example.com.evil.com"}, err: "recipient eve@example.com.evil.com is not allowed"},
+		{to: []string{"not an address"}, err: `invalid address "not an address": mail: no angle-addr`},
+		{to: []string{"bob@example.com"}, subject: "Hi\r\nBcc: eve@evil.com", err: "subject must be a single line"},
+		{to: []string{"bob@example.com"}, attachments: []string{"secret.txt"}, err: "attachments are disabled: no attachment directory configured"},
+		{to: []string{"bob@example.com"}, err: "SMTP server does not support STARTTLS"},
+	}
+	for _, test := range tests {
+		_, err := emailTools.SendEmail(ctx, test.to, nil, test.subject, "Hello", false, test.attachments)
+		assert.EqualError(t, err, test.err)
+	}
+
+	emailTools.AttachmentDirectory = t.TempDir()
+	_, err := emailTools.SendEmail(ctx, []string{"bob@example.com"}, nil, "Hi", "Hello", false, []string{"../secret.txt"})
+	assert.ErrorContains(t, err, "outside")
+	_, err = emailTools.SendEmail(ctx, []string{"bob@example.com"}, nil, "Hi", "Hello", false, []string{"missing.txt"})
+	assert.EqualError(t, err, "attachment not found: missing.txt")
+
+	emailTools.SMTPSecurity = "none"
+	msg, err := emailTools.SendEmail(ctx, []string{"bob@example.com"}, nil, "Hi", "Hello", false, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, `Sent email "Hi" to bob@example.com`, msg)
+	plainServer.mu.Lock()
+	assert.Contains(t, plainServer.data, "Content-Type: text/plain; charset=utf-8")
+	assert.NotContains(t, plainServer.data, "multipart")
+	plainServer.mu.Unlock()
+
+	_, err = (&EmailTools{}).SendEmail(ctx, []string{"bob@example.com"}, nil, "Hi", "Hello", false, nil)
+	assert.EqualError(t, err, "no sender address configured")
+}
+
+func TestEmailTools_DryRun(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("notes"), 0644))
+	emailTools := &EmailTools{From: "agent@example.com", DryRun: true, AttachmentDirectory: dir}
+
+	msg, err := emailTools.SendEmail(context.Background(), []string{"bob@example.com"}, []string{"Alice <alice@example.com>"}, "Notes", "See attached.", false, []string{"notes.txt"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Dry run, the email was not sent.\nFrom: <agent@example.com>\nTo: <bob@example.com>\nCc: \"Alice\" <alice@example.com>\nSubject: Notes\nAttachments: notes.txt (5 bytes)\n\nSee attached.", msg)
+}
+
+func TestEmailTools_IMAP(t *testing.T) {
+	ctx := context.Background()
+	backend := memory.New()
+	user, err := backend.Login(nil, "username", "password")
+	require.NoError(t, err)
+	inbox, err := user.GetMailbox("INBOX")
+	require.NoError(t, err)
+	message, err := buildEmail(&mail.Address{Name: "Bob", Address: "bob@example.com"}, []*mail.Address{{Address: "agent@example.com"}}, nil,
+		"Invoice", "Please find the invoice attached.", "<p>Please find the <b>invoice</b> attached.</p>", []emailAttachment{{name: "invoice.pdf", data: []byte("%PDF")}})
+	require.NoError(t, err)
+	require.NoError(t, inbox.CreateMessage(nil, time.Now(), bytes.NewBuffer(message)))
+	htmlOnly := "From: news@example.com\r\nSubject: News\r\nContent-Type: text/html\r\n\r\n<h1>Title</h1><p>Hello</p>"
+	require.NoError(t, inbox.CreateMessage([]string{imap.SeenFlag}, time.Now(), bytes.NewBufferString(htmlOnly)))
+
+	imapServer := server.New(backend)
+	imapServer.AllowInsecureAuth = true
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go imapServer.Serve(listener)
+	t.Cleanup(func() { imapServer.Close() })
+
+	emailTools := &EmailTools{
+		IMAPHost:     "127.0.0.1",
+		IMAPPort:     listener.Addr().(*net.TCPAddr).Port,
+		IMAPSecurity: "none",
+		IMAPUsername: "username",
+		IMAPPassword: "password",
+	}
+	summaries, err := emailTools.ListInbox(ctx, "", 2, false)
+	assert.NoError(t, err)
+	require.Len(t, summaries, 2)
+	assert.Equal(t, EmailSummary{UID: 8, From: "news@example.com", Subject: "News", Seen: true}, summaries[0])
+	assert.Equal(t, uint32(7), summaries[1].UID)
+	assert.Equal(t, "Bob <bob@example.com>", summaries[1].From)
+	assert.False(t, summaries[1].Seen)
+
+	email, err := emailTools.ReadEmail(ctx, 7, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "Invoice", email.Subject)
+	assert.Equal(t, "Please find the invoice attached.", email.Body)
+	assert.Equal(t, []string{"invoice.pdf"}, email.Attachments)
+	email, err = emailTools.ReadEmail(ctx, 8, "INBOX")
+	assert.NoError(t, err)
+	assert.Equal(t, "# Title\n\nHello", email.Body)
+
+	// Reading an email does not mark it as read
+	summaries, err = emailTools.ListInbox(ctx, "INBOX", 0, true)
+	assert.NoError(t, err)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, uint32(7), summaries[0].UID)
+
+	emailTools.MaxBodySize = 6
+	email, err = emailTools.ReadEmail(ctx, 7, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "Please", email.Body)
+	assert.True(t, email.Truncated)
+
+	_, err = emailTools.ReadEmail(ctx, 42, "")
+	assert.EqualError(t, err, "email 42 not found in INBOX")
+	_, err = emailTools.ListInbox(ctx, "Archive", 0, false)
+	assert.ErrorContains(t, err, "failed to open mailbox Archive")
+	emailTools.IMAPPassword = "wrong"
+	_, err = emailTools.ListInbox(ctx, "", 0, false)
+	assert.ErrorContains(t, err, "IMAP authentication failed")
+	_, err = (&EmailTools{}).ListInbox(ctx, "", 0, false)
+	assert.EqualError(t, err, "no IMAP host configured")
+	_, err = (&EmailTools{IMAPHost: "127.0.0.1", IMAPSecurity: "ssl"}).ReadEmail(ctx, 1, "")
+	assert.EqualError(t, err, `invalid IMAP security "ssl": expected tls, starttls or none`)
+}
+
+func TestEmailTools_Tools(t *testing.T) {
+	assert.Len(t, (&EmailTools{EnableAll: true}).Tools(), 1)
+	tools := (&EmailTools{EnableAll: true, IMAPHost: "imap.example.com"}).Tools()
+	assert.Len(t, tools, 3)
+	assert.Equal(t, "ReadEmail", tools[2].Name)
+}
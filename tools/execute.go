@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/Harsh-2909/hermes-go/utils"
+)
+
+// errToolTimeout is the cause of the context of a tool call cancelled by its own timeout.
+var errToolTimeout = errors.New("tool timeout")
+
+// Call executes the tool with the JSON-encoded arguments, with a timeout and panic recovery.
+// The timeout is the tool's Timeout, or defaultTimeout if it is zero; the tool runs without timeout if both are zero.
+// Execute receives a context derived from ctx which is cancelled on timeout. Tools should stop when it is done,
+// as Call returns on timeout or cancellation without waiting for Execute to return.
+// A panic in Execute, including in methods of tools created with CreateToolFromMethod, is returned as an error
+// and its stack trace is logged at debug level.
+func (t Tool) Call(ctx context.Context, args string, defaultTimeout time.Duration) (string, error) {
+	timeout := t.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, timeout, errToolTimeout)
+		defer cancel()
+	}
+
+	type result struct {
+		value string
+		err   error
+	}
+	// Buffered so that the goroutine does not leak if Call returns first
+	done := make(chan result, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				utils.Logger.Error("Tool panicked", "name", t.Name, "panic", r)
+				utils.Logger.Debug("Tool panic stack trace", "name", t.Name, "stack", string(debug.Stack()))
+				done <- result{err: fmt.Errorf("tool %s panicked: %v", t.Name, r)}
+			}
+		}()
+		value, err := t.Execute(ctx, args)
+		done <- result{value: value, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.value, r.err
+	case <-ctx.Done():
+		// Deadlines of the parent context are cancellations, not timeouts of the tool
+		if context.Cause(ctx) == errToolTimeout {
+			return "", fmt.Errorf("tool %s timed out after %s: %w", t.Name, timeout, ctx.Err())
+		}
+		return "", fmt.Errorf("tool %s was cancelled: %w", t.Name, ctx.Err())
+	}
+}
@@ -0,0 +1,119 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// PanicToolkit is a sample toolkit whose method panics.
+type PanicToolkit struct{}
+
+// Explode panics with the message.
+// @param message: The panic message
+func (p *PanicToolkit) Explode(ctx context.Context, message string) string {
+	panic(message)
+}
+
+// slowTool returns a tool which waits for delay or until its context is done.
+func slowTool(delay time.Duration) Tool {
+	return NewTool("slow", "Slow tool", nil, func(ctx context.Context, args string) (string, error) {
+		select {
+		case <-time.After(delay):
+			return "done", nil
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	})
+}
+
+func TestToolCall(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		result, err := slowTool(time.Millisecond).Call(context.Background(), "{}", time.Second)
+		assert.NoError(t, err)
+		assert.Equal(t, "done", result)
+	})
+
+	t.Run("DefaultTimeout", func(t *testing.T) {
+		start := time.Now()
+		_, err := slowTool(time.Minute).Call(context.Background(), "{}", 20*time.Millisecond)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "tool slow timed out after 20ms")
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("ToolTimeoutOverridesDefault", func(t *testing.T) {
+		tool := slowTool(time.Minute)
+		tool.Timeout = 10 * time.Millisecond
+		_, err := tool.Call(context.Background(), "{}", time.Minute)
+		assert.ErrorContains(t, err, "timed out after 10ms")
+	})
+
+	t.Run("NoTimeout", func(t *testing.T) {
+		result, err := slowTool(20*time.Millisecond).Call(context.Background(), "{}", 0)
+		assert.NoError(t, err)
+		assert.Equal(t, "done", result)
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+		}()
+		_, err := slowTool(time.Minute).Call(ctx, "{}", 0)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorContains(t, err, "tool slow was cancelled")
+	})
+
+	t.Run("ParentDeadline", func(t *testing.T) {
+		// A shorter deadline of the caller is not reported as a timeout of the tool
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		tool := NewTool("stuck", "Stuck tool", nil, func(ctx context.Context, args string) (string, error) {
+			time.Sleep(200 * time.Millisecond)
+			return "late", nil
+		})
+		_, err := tool.Call(ctx, "{}", time.Minute)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.EqualError(t, err, "tool stuck was cancelled: context deadline exceeded")
+	})
+
+	t.Run("IgnoresContext", func(t *testing.T) {
+		// Tools which ignore their context still time out
+		tool := NewTool("stuck", "Stuck tool", nil, func(ctx context.Context, args string) (string, error) {
+			time.Sleep(200 * time.Millisecond)
+			return "late", nil
+		})
+		_, err := tool.Call(context.Background(), "{}", 10*time.Millisecond)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		tool := NewTool("failing", "Failing tool", nil, func(ctx context.Context, args string) (string, error) {
+			return "", errors.New("boom")
+		})
+		_, err := tool.Call(context.Background(), "{}", time.Second)
+		assert.EqualError(t, err, "boom")
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		tool := NewTool("panicking", "Panicking tool", nil, func(ctx context.Context, args string) (string, error) {
+			var m map[string]int
+			m["x"] = 1
+			return "", nil
+		})
+		_, err := tool.Call(context.Background(), "{}", time.Second)
+		assert.ErrorContains(t, err, "tool panicking panicked: assignment to entry in nil map")
+	})
+
+	t.Run("MethodPanic", func(t *testing.T) {
+		tool, err := CreateToolFromMethod(&PanicToolkit{}, "Explode")
+		assert.NoError(t, err)
+		_, err = tool.Call(context.Background(), `{"message": "kaboom"}`, 0)
+		assert.EqualError(t, err, "tool Explode panicked: kaboom")
+	})
+}
@@ -0,0 +1,406 @@
+package tools
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// expressionConstants are the constants usable in expressions.
+var expressionConstants = map[string]float64{
+	"pi":  math.Pi,
+	"e":   math.E,
+	"tau": 2 * math.Pi,
+	"phi": math.Phi,
+}
+
+// expressionFunction is a function usable in expressions, with its number of arguments.
+type expressionFunction struct {
+	minArgs, maxArgs int // maxArgs is -1 for variadic functions
+	call             func(args []float64) (float64, error)
+}
+
+// unary returns a function of one argument without domain errors.
+func unary(f func(float64) float64) expressionFunction {
+	return expressionFunction{1, 1, func(args []float64) (float64, error) { return f(args[0]), nil }}
+}
+
+// expressionFunctions are the functions usable in expressions.
+var expressionFunctions = map[string]expressionFunction{
+	"sin":   unary(math.Sin),
+	"cos":   unary(math.Cos),
+	"tan":   unary(math.Tan),
+	"atan":  unary(math.Atan),
+	"sinh":  unary(math.Sinh),
+	"cosh":  unary(math.Cosh),
+	"tanh":  unary(math.Tanh),
+	"cbrt":  unary(math.Cbrt),
+	"abs":   unary(math.Abs),
+	"exp":   unary(math.Exp),
+	"floor": unary(math.Floor),
+	"ceil":  unary(math.Ceil),
+	"trunc": unary(math.Trunc),
+	"degrees": unary(func(x float64) float64 {
+		return x * 180 / math.Pi
+	}),
+	"radians": unary(func(x float64) float64 {
+		return x * math.Pi / 180
+	}),
+	"sign": unary(func(x float64) float64 {
+		switch {
+		case x > 0:
+			return 1
+		case x < 0:
+			return -1
+		}
+		return 0
+	}),
+	"asin": {1, 1, func(args []float64) (float64, error) {
+		if args[0] < -1 || args[0] > 1 {
+			return 0, fmt.Errorf("asin is only defined between -1 and 1, got %v", args[0])
+		}
+		return math.Asin(args[0]), nil
+	}},
+	"acos": {1, 1, func(args []float64) (float64, error) {
+		if args[0] < -1 || args[0] > 1 {
+			return 0, fmt.Errorf("acos is only defined between -1 and 1, got %v", args[0])
+		}
+		return math.Acos(args[0]), nil
+	}},
+	"atan2": {2, 2, func(args []float64) (float64, error) { return math.Atan2(args[0], args[1]), nil }},
+	"sqrt": {1, 1, func(args []float64) (float64, error) {
+		if args[0] < 0 {
+			return 0, fmt.Errorf("square root of negative number %v", args[0])
+		}
+		return math.Sqrt(args[0]), nil
+	}},
+	"ln": {1, 1, func(args []float64) (float64, error) { return logarithm(args[0], math.E) }},
+	"log": {1, 2, func(args []float64) (float64, error) {
+		if len(args) == 2 {
+			return logarithm(args[0], args[1])
+		}
+		return logarithm(args[0], 10)
+	}},
+	"log2":  {1, 1, func(args []float64) (float64, error) { return logarithm(args[0], 2) }},
+	"log10": {1, 1, func(args []float64) (float64, error) { return logarithm(args[0], 10) }},
+	"pow":   {2, 2, func(args []float64) (float64, error) { return math.Pow(args[0], args[1]), nil }},
+	"hypot": {2, 2, func(args []float64) (float64, error) { return math.Hypot(args[0], args[1]), nil }},
+	"round": {1, 2, func(args []float64) (float64, error) {
+		if len(args) == 1 {
+			return math.Round(args[0]), nil
+		}
+		scale := math.Pow(10, math.Trunc(args[1]))
+		return math.Round(args[0]*scale) / scale, nil
+	}},
+	"min": {1, -1, func(args []float64) (float64, error) {
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = math.Min(result, arg)
+		}
+		return result, nil
+	}},
+	"max": {1, -1, func(args []float64) (float64, error) {
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = math.Max(result, arg)
+		}
+		return result, nil
+	}},
+	"factorial": {1, 1, func(args []float64) (float64, error) { return factorial(args[0]) }},
+}
+
+// logarithm returns the logarithm of x in base.
+func logarithm(x, base float64) (float64, error) {
+	if x <= 0 {
+		return 0, fmt.Errorf("logarithm of non-positive number %v", x)
+	}
+	if base <= 0 || base == 1 {
+		return 0, fmt.Errorf("invalid logarithm base %v", base)
+	}
+	return math.Log(x) / math.Log(base), nil
+}
+
+// factorial returns the factorial of a non-negative integer.
+func factorial(x float64) (float64, error) {
+	if x < 0 || x != math.Trunc(x) {
+		return 0, fmt.Errorf("factorial is only defined for non-negative integers, got %v", x)
+	}
+	if x > 170 {
+		return 0, fmt.Errorf("factorial of %v is too large", x)
+	}
+	result := 1.0
+	for i := 2.0; i <= x; i++ {
+		result *= i
+	}
+	return result, nil
+}
+
+// expressionNames returns the sorted keys of a map, for error messages.
+func expressionNames[V any](m map[string]V) string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// expressionParser evaluates arithmetic expressions by recursive descent. From lowest to highest precedence:
+// "+" and "-", "*", "/" and "%", unary "-" and "+", "^" (right associative), and postfix "!".
+type expressionParser struct {
+	input     string
+	pos       int
+	variables map[string]float64
+}
+
+// evaluateExpression evaluates an arithmetic expression with variables.
+func evaluateExpression(expression string, variables map[string]float64) (float64, error) {
+	p := &expressionParser{input: expression, variables: variables}
+	result, err := p.parseSum()
+	if err != nil {
+		return 0, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return 0, p.errorf("unexpected %q", p.input[p.pos])
+	}
+	if math.IsNaN(result) || math.IsInf(result, 0) {
+		return 0, fmt.Errorf("result is not a finite number")
+	}
+	return result, nil
+}
+
+// errorf returns a syntax error at the current position.
+func (p *expressionParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid expression: %s at position %d", fmt.Sprintf(format, args...), p.pos+1)
+}
+
+func (p *expressionParser) skipSpaces() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// consume skips spaces and the given token if it is next, and reports whether it was.
+func (p *expressionParser) consume(token string) bool {
+	p.skipSpaces()
+	if strings.HasPrefix(p.input[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+func (p *expressionParser) parseSum() (float64, error) {
+	result, err := p.parseProduct()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		switch {
+		case p.consume("+"):
+			right, err := p.parseProduct()
+			if err != nil {
+				return 0, err
+			}
+			result += right
+		case p.consume("-"):
+			right, err := p.parseProduct()
+			if err != nil {
+				return 0, err
+			}
+			result -= right
+		default:
+			return result, nil
+		}
+	}
+}
+
+func (p *expressionParser) parseProduct() (float64, error) {
+	result, err := p.parseUnary()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		p.skipSpaces()
+		if strings.HasPrefix(p.input[p.pos:], "**") {
+			return result, nil // Power operator, handled by parsePower
+		}
+		var operator byte
+		switch {
+		case p.consume("*"):
+			operator = '*'
+		case p.consume("/"):
+			operator = '/'
+		case p.consume("%"):
+			operator = '%'
+		default:
+			return result, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return 0, err
+		}
+		switch operator {
+		case '*':
+			result *= right
+		case '/':
+			if right == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			result /= right
+		case '%':
+			if right == 0 {
+				return 0, fmt.Errorf("modulo by zero")
+			}
+			result = math.Mod(result, right)
+		}
+	}
+}
+
+func (p *expressionParser) parseUnary() (float64, error) {
+	switch {
+	case p.consume("-"):
+		value, err := p.parseUnary()
+		return -value, err
+	case p.consume("+"):
+		return p.parseUnary()
+	}
+	return p.parsePower()
+}
+
+func (p *expressionParser) parsePower() (float64, error) {
+	base, err := p.parsePostfix()
+	if err != nil {
+		return 0, err
+	}
+	if p.consume("^") || p.consume("**") {
+		// Right associative, and binding tighter than unary minus on its left: -2^2 is -4
+		exponent, err := p.parseUnary()
+		if err != nil {
+			return 0, err
+		}
+		return math.Pow(base, exponent), nil
+	}
+	return base, nil
+}
+
+func (p *expressionParser) parsePostfix() (float64, error) {
+	value, err := p.parsePrimary()
+	if err != nil {
+		return 0, err
+	}
+	for p.consume("!") {
+		if value, err = factorial(value); err != nil {
+			return 0, err
+		}
+	}
+	return value, nil
+}
+
+func (p *expressionParser) parsePrimary() (float64, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return 0, p.errorf("unexpected end of expression")
+	}
+	c := p.input[p.pos]
+	switch {
+	case c == '(':
+		p.pos++
+		value, err := p.parseSum()
+		if err != nil {
+			return 0, err
+		}
+		if !p.consume(")") {
+			return 0, p.errorf("missing closing parenthesis")
+		}
+		return value, nil
+	case c >= '0' && c <= '9' || c == '.':
+		return p.parseNumber()
+	case c == '_' || unicode.IsLetter(rune(c)):
+		start := p.pos
+		for p.pos < len(p.input) && (p.input[p.pos] == '_' || unicode.IsLetter(rune(p.input[p.pos])) || unicode.IsDigit(rune(p.input[p.pos]))) {
+			p.pos++
+		}
+		name := p.input[start:p.pos]
+		if p.consume("(") {
+			return p.parseCall(name)
+		}
+		if value, ok := p.variables[name]; ok {
+			return value, nil
+		}
+		if value, ok := expressionConstants[strings.ToLower(name)]; ok {
+			return value, nil
+		}
+		if _, ok := expressionFunctions[strings.ToLower(name)]; ok {
+			return 0, p.errorf("function %s must be called with parentheses", name)
+		}
+		return 0, fmt.Errorf("unknown variable %s; constants: %s", name, expressionNames(expressionConstants))
+	}
+	return 0, p.errorf("unexpected %q", c)
+}
+
+func (p *expressionParser) parseNumber() (float64, error) {
+	start := p.pos
+	for p.pos < len(p.input) && (p.input[p.pos] >= '0' && p.input[p.pos] <= '9' || p.input[p.pos] == '.') {
+		p.pos++
+	}
+	// Exponent, e.g. 1.5e-3
+	if p.pos < len(p.input) && (p.input[p.pos] == 'e' || p.input[p.pos] == 'E') {
+		end := p.pos + 1
+		if end < len(p.input) && (p.input[end] == '+' || p.input[end] == '-') {
+			end++
+		}
+		if end < len(p.input) && p.input[end] >= '0' && p.input[end] <= '9' {
+			for end < len(p.input) && p.input[end] >= '0' && p.input[end] <= '9' {
+				end++
+			}
+			p.pos = end
+		}
+	}
+	text := p.input[start:p.pos]
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		p.pos = start
+		return 0, p.errorf("invalid number %q", text)
+	}
+	return value, nil
+}
+
+// parseCall parses the arguments of a function call after its opening parenthesis, and calls it.
+func (p *expressionParser) parseCall(name string) (float64, error) {
+	function, ok := expressionFunctions[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown function %s; functions: %s", name, expressionNames(expressionFunctions))
+	}
+	var args []float64
+	if !p.consume(")") {
+		for {
+			arg, err := p.parseSum()
+			if err != nil {
+				return 0, err
+			}
+			args = append(args, arg)
+			if p.consume(")") {
+				break
+			}
+			if !p.consume(",") {
+				return 0, p.errorf("expected \",\" or \")\" in arguments of %s", name)
+			}
+		}
+	}
+	if len(args) < function.minArgs || (function.maxArgs >= 0 && len(args) > function.maxArgs) {
+		expected := strconv.Itoa(function.minArgs)
+		switch {
+		case function.maxArgs < 0:
+			expected = "at least " + expected
+		case function.maxArgs != function.minArgs:
+			expected += " or " + strconv.Itoa(function.maxArgs)
+		}
+		return 0, fmt.Errorf("%s expects %s arguments, got %d", name, expected, len(args))
+	}
+	return function.call(args)
+}
@@ -1,41 +1,111 @@
 package tools
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Harsh-2909/hermes-go/utils"
 	"github.com/google/uuid"
 )
 
 // FileSystemTools provides tools for interacting with the local file system.
+// All paths are confined to TargetDirectory: paths outside it, including through symbolic links, are rejected.
 type FileSystemTools struct {
-	EnableWriteFile  bool   // Enable the write_file tool
-	EnableReadFile   bool   // Enable the read_file tool
-	EnableAll        bool   // Enable all tools if true
-	TargetDirectory  string // Default directory for file operations
-	DefaultExtension string // Default file extension (e.g., "txt")
+	EnableWriteFile     bool   // Enable the write_file tool
+	EnableReadFile      bool   // Enable the read_file tool
+	EnableAppendFile    bool   // Enable the append_file tool
+	EnableListDirectory bool   // Enable the list_directory tool
+	EnableGlobFiles     bool   // Enable the glob_files tool
+	EnableSearchInFiles bool   // Enable the search_in_files tool
+	EnableReplaceInFile bool   // Enable the replace_in_file tool
+	EnableApplyDiff     bool   // Enable the apply_diff tool
+	EnableDeleteFile    bool   // Enable the delete_file tool
+	EnableFileInfo      bool   // Enable the file_info tool
+	EnableAll           bool   // Enable all tools if true
+	TargetDirectory     string // Root directory for file operations. Defaults to the current directory
+	DefaultExtension    string // Default file extension (e.g., "txt")
+	MaxReadSize         int    // Maximum number of bytes read from a file, and maximum size of the files searched. Defaults to 1000000
+	MaxResults          int    // Maximum number of entries returned by ListDirectory, GlobFiles and SearchInFiles. Defaults to 1000
+}
+
+// FileEntry is an entry of a directory listing.
+type FileEntry struct {
+	Path string `json:"path"`           // Path relative to the target directory
+	Type string `json:"type"`           // "file", "directory" or "symlink"
+	Size int64  `json:"size,omitempty"` // Size in bytes of files
+}
+
+// DirectoryListing is the result of the ListDirectory tool.
+type DirectoryListing struct {
+	Entries   []FileEntry `json:"entries"`
+	Truncated bool        `json:"truncated,omitempty"` // True if there were more than MaxResults entries
+}
+
+// GlobResult is the result of the GlobFiles tool.
+type GlobResult struct {
+	Files     []string `json:"files"`               // Paths relative to the target directory
+	Truncated bool     `json:"truncated,omitempty"` // True if there were more than MaxResults files
+}
+
+// TextMatch is a line matching a SearchInFiles pattern.
+type TextMatch struct {
+	Path string `json:"path"` // Path relative to the target directory
+	Line int    `json:"line"` // Line number, starting at 1
+	Text string `json:"text"`
+}
+
+// TextSearchResult is the result of the SearchInFiles tool.
+type TextSearchResult struct {
+	Matches   []TextMatch `json:"matches"`
+	Truncated bool        `json:"truncated,omitempty"` // True if there were more than MaxResults matches
+}
+
+// FileDetails is the result of the FileInfo tool.
+type FileDetails struct {
+	Path    string `json:"path"`     // Path relative to the target directory
+	Type    string `json:"type"`     // "file" or "directory"
+	Size    int64  `json:"size"`     // Size in bytes
+	Mode    string `json:"mode"`     // Permissions, e.g. "-rw-r--r--"
+	ModTime string `json:"mod_time"` // Modification time in RFC 3339 format
 }
 
 // Tools returns a list of available tools based on enable flags.
 func (f *FileSystemTools) Tools() []Tool {
 	var tools []Tool
 
-	if f.EnableWriteFile || f.EnableAll {
-		if writeTool, err := CreateToolFromMethod(f, "WriteFile"); err == nil {
-			tools = append(tools, writeTool)
-		} else {
-			utils.Logger.Error("Failed to create tool", "tool", "WriteFile", "error", err)
-		}
+	methods := []struct {
+		enabled bool
+		name    string
+	}{
+		{f.EnableWriteFile, "WriteFile"},
+		{f.EnableReadFile, "ReadFile"},
+		{f.EnableAppendFile, "AppendFile"},
+		{f.EnableListDirectory, "ListDirectory"},
+		{f.EnableGlobFiles, "GlobFiles"},
+		{f.EnableSearchInFiles, "SearchInFiles"},
+		{f.EnableReplaceInFile, "ReplaceInFile"},
+		{f.EnableApplyDiff, "ApplyDiff"},
+		{f.EnableDeleteFile, "DeleteFile"},
+		{f.EnableFileInfo, "FileInfo"},
 	}
-
-	if f.EnableReadFile || f.EnableAll {
-		if readTool, err := CreateToolFromMethod(f, "ReadFile"); err == nil {
-			tools = append(tools, readTool)
+	for _, method := range methods {
+		if !method.enabled && !f.EnableAll {
+			continue
+		}
+		if tool, err := CreateToolFromMethod(f, method.name); err == nil {
+			tools = append(tools, tool)
 		} else {
-			utils.Logger.Error("Failed to create tool", "tool", "ReadFile", "error", err)
+			utils.Logger.Error("Failed to create tool", "tool", method.name, "error", err)
 		}
 	}
 
@@ -45,14 +115,11 @@ func (f *FileSystemTools) Tools() []Tool {
 // WriteFile writes content to a local file.
 // @param content: Content to write to the file
 // @param [optional] filename: Name of the file. Defaults to UUID if not provided
-// @param [optional] directory: Directory to write file to. Uses TargetDirectory if not provided
+// @param [optional] directory: Directory to write file to, relative to the target directory. Uses the target directory if not provided
 // @param [optional] extension: File extension. Uses DefaultExtension if not provided
 // @return Path to the created file or error message
 func (f *FileSystemTools) WriteFile(ctx context.Context, content, filename, directory, extension string) (string, error) {
 	// Use defaults if parameters are empty
-	if directory == "" {
-		directory = f.TargetDirectory
-	}
 	if extension == "" {
 		extension = f.DefaultExtension
 	}
@@ -66,45 +133,696 @@ func (f *FileSystemTools) WriteFile(ctx context.Context, content, filename, dire
 		}
 	}
 
+	// Construct full file path
+	fullFilename := fmt.Sprintf("%s.%s", filename, extension)
+	filePath, err := f.resolve(filepath.Join(directory, fullFilename))
+	if err != nil {
+		return "", err
+	}
+
 	// Ensure directory exists
-	dirPath := filepath.Clean(directory)
-	if err := os.MkdirAll(dirPath, 0755); err != nil {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
 		return "", fmt.Errorf("failed to create directory: %v", err)
 	}
 
-	// Construct full file path
-	fullFilename := fmt.Sprintf("%s.%s", filename, extension)
-	filePath := filepath.Join(dirPath, fullFilename)
-
 	// Write content to file
 	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
 		return "", fmt.Errorf("failed to write file: %v", err)
 	}
 
-	return fmt.Sprintf("Successfully wrote file to: %s", filePath), nil
+	return fmt.Sprintf("Successfully wrote file to: %s", f.display(filePath)), nil
 }
 
 // ReadFile reads content from a local file.
 // @param filename: Name of the file
-// @param [optional] directory: Directory of the file. Uses TargetDirectory if not provided
+// @param [optional] directory: Directory of the file, relative to the target directory. Uses the target directory if not provided
+// @param [optional] start_line: First line to read, starting at 1. Reads the whole file if neither start_line nor end_line is provided
+// @param [optional] end_line: Last line to read, included. Defaults to the last line of the file
 // @return Content of the file or error message
-func (f *FileSystemTools) ReadFile(ctx context.Context, filename, directory string) (string, error) {
-	// Use default directory if not provided
-	if directory == "" {
-		directory = f.TargetDirectory
+func (f *FileSystemTools) ReadFile(ctx context.Context, filename, directory string, start_line, end_line int) (string, error) {
+	filePath, err := f.resolve(filepath.Join(directory, filename))
+	if err != nil {
+		return "", err
 	}
 
-	// Construct full file path
-	filePath := filepath.Join(directory, filename)
-
-	// Read file content
-	data, err := os.ReadFile(filePath)
+	file, err := os.Open(filePath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Sprintf("File not found: %s", filePath), nil
+			return fmt.Sprintf("File not found: %s", f.display(filePath)), nil
 		}
 		return "", fmt.Errorf("failed to read file: %v", err)
 	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %v", err)
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("%s is a directory", f.display(filePath))
+	}
+	maxSize := f.maxReadSize()
+
+	// Read content of the whole file
+	if start_line <= 0 && end_line <= 0 {
+		data, err := io.ReadAll(io.LimitReader(file, int64(maxSize)))
+		if err != nil {
+			return "", fmt.Errorf("failed to read file: %v", err)
+		}
+		if info.Size() > int64(maxSize) {
+			return string(data) + fmt.Sprintf("\n[File truncated to %d of %d bytes; use start_line and end_line to read more]", maxSize, info.Size()), nil
+		}
+		return string(data), nil
+	}
+
+	// Read content of a range of lines
+	if start_line <= 0 {
+		start_line = 1
+	}
+	if end_line > 0 && end_line < start_line {
+		return "", fmt.Errorf("end_line %d is before start_line %d", end_line, start_line)
+	}
+	var sb strings.Builder
+	reader := bufio.NewReader(file)
+	lineNumber := 0
+	for end_line <= 0 || lineNumber < end_line {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			lineNumber++
+			if lineNumber >= start_line {
+				if sb.Len()+len(line) > maxSize {
+					sb.WriteString(fmt.Sprintf("[Output truncated to %d bytes after line %d]", maxSize, lineNumber-1))
+					break
+				}
+				sb.WriteString(line)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read file: %v", err)
+		}
+	}
+	if lineNumber < start_line {
+		return "", fmt.Errorf("start_line %d is beyond the end of the file (%d lines)", start_line, lineNumber)
+	}
+	return sb.String(), nil
+}
+
+// AppendFile appends content to a local file, creating it if needed.
+// @param path: Path of the file, relative to the target directory
+// @param content: Content to append to the file
+// @return Path to the file or error message
+func (f *FileSystemTools) AppendFile(ctx context.Context, path, content string) (string, error) {
+	filePath, err := f.resolve(path)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %v", err)
+	}
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %v", err)
+	}
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		return "", fmt.Errorf("failed to append to file: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to append to file: %v", err)
+	}
+	return fmt.Sprintf("Successfully appended to file: %s", f.display(filePath)), nil
+}
 
-	return string(data), nil
+// ListDirectory lists the files and directories of a directory.
+// @param [optional] path: Path of the directory, relative to the target directory. Uses the target directory if not provided
+// @param [optional] recursive: If true, lists the content of subdirectories too
+// @return JSON object with the path, type and size of the entries
+func (f *FileSystemTools) ListDirectory(ctx context.Context, path string, recursive bool) (DirectoryListing, error) {
+	dirPath, err := f.resolve(path)
+	if err != nil {
+		return DirectoryListing{}, err
+	}
+	info, err := os.Stat(dirPath)
+	if err != nil {
+		return DirectoryListing{}, fmt.Errorf("directory not found: %s", f.display(dirPath))
+	}
+	if !info.IsDir() {
+		return DirectoryListing{}, fmt.Errorf("%s is not a directory", f.display(dirPath))
+	}
+
+	listing := DirectoryListing{Entries: []FileEntry{}}
+	err = filepath.WalkDir(dirPath, func(current string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if current == dirPath {
+			return nil
+		}
+		if len(listing.Entries) == f.maxResults() {
+			listing.Truncated = true
+			return fs.SkipAll
+		}
+		fileEntry := FileEntry{Path: f.relative(current), Type: "file"}
+		switch {
+		case entry.Type()&fs.ModeSymlink != 0:
+			fileEntry.Type = "symlink"
+		case entry.IsDir():
+			fileEntry.Type = "directory"
+		default:
+			if info, err := entry.Info(); err == nil {
+				fileEntry.Size = info.Size()
+			}
+		}
+		listing.Entries = append(listing.Entries, fileEntry)
+		if entry.IsDir() && (!recursive || entry.Name() == ".git") {
+			return fs.SkipDir
+		}
+		return ctx.Err()
+	})
+	if err != nil {
+		return DirectoryListing{}, fmt.Errorf("failed to list directory: %v", err)
+	}
+	return listing, nil
+}
+
+// GlobFiles finds the files matching a glob pattern.
+// @param pattern: Glob pattern matched against paths relative to the target directory, e.g. "**/*.go" or "docs/*.md". "**" matches any number of directories
+// @return JSON object with the paths of the matching files
+func (f *FileSystemTools) GlobFiles(ctx context.Context, pattern string) (GlobResult, error) {
+	matcher, err := globToRegexp(strings.TrimPrefix(filepath.ToSlash(pattern), "./"))
+	if err != nil {
+		return GlobResult{}, err
+	}
+	root, err := f.root()
+	if err != nil {
+		return GlobResult{}, err
+	}
+
+	result := GlobResult{Files: []string{}}
+	err = f.walkFiles(ctx, root, func(path string, entry fs.DirEntry) error {
+		if relative := f.relative(path); matcher.MatchString(relative) {
+			if len(result.Files) == f.maxResults() {
+				result.Truncated = true
+				return fs.SkipAll
+			}
+			result.Files = append(result.Files, relative)
+		}
+		return nil
+	})
+	if err != nil {
+		return GlobResult{}, fmt.Errorf("failed to find files: %v", err)
+	}
+	return result, nil
+}
+
+// SearchInFiles searches the lines of files matching a regular expression.
+// @param pattern: Regular expression in Go syntax, e.g. "func \\w+\\(" or "(?i)todo"
+// @param [optional] path: File or directory to search, relative to the target directory. Uses the target directory if not provided
+// @param [optional] include: Glob pattern of the files to search, e.g. "*.go". Patterns without "/" match file names
+// @return JSON object with the path, line number and text of the matching lines
+func (f *FileSystemTools) SearchInFiles(ctx context.Context, pattern, path, include string) (TextSearchResult, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return TextSearchResult{}, fmt.Errorf("invalid pattern: %v", err)
+	}
+	var includeMatcher *regexp.Regexp
+	if include != "" {
+		if includeMatcher, err = globToRegexp(filepath.ToSlash(include)); err != nil {
+			return TextSearchResult{}, err
+		}
+	}
+	searchPath, err := f.resolve(path)
+	if err != nil {
+		return TextSearchResult{}, err
+	}
+	if _, err := os.Stat(searchPath); err != nil {
+		return TextSearchResult{}, fmt.Errorf("path not found: %s", f.display(searchPath))
+	}
+
+	result := TextSearchResult{Matches: []TextMatch{}}
+	err = f.walkFiles(ctx, searchPath, func(filePath string, entry fs.DirEntry) error {
+		relative := f.relative(filePath)
+		if includeMatcher != nil {
+			name := relative
+			if !strings.Contains(include, "/") {
+				name = entry.Name()
+			}
+			if !includeMatcher.MatchString(name) {
+				return nil
+			}
+		}
+		matches, err := f.searchFile(filePath, relative, re)
+		if err != nil {
+			return nil // Unreadable files are skipped
+		}
+		for _, match := range matches {
+			if len(result.Matches) == f.maxResults() {
+				result.Truncated = true
+				return fs.SkipAll
+			}
+			result.Matches = append(result.Matches, match)
+		}
+		return nil
+	})
+	if err != nil {
+		return TextSearchResult{}, fmt.Errorf("failed to search files: %v", err)
+	}
+	return result, nil
+}
+
+// ReplaceInFile replaces exact text in a local file.
+// @param path: Path of the file, relative to the target directory
+// @param old_text: Exact text to replace, including whitespace. It must be unique in the file unless replace_all is true
+// @param new_text: Replacement text
+// @param [optional] replace_all: If true, replaces all occurrences of old_text
+// @return Number of replacements or error message
+func (f *FileSystemTools) ReplaceInFile(ctx context.Context, path, old_text, new_text string, replace_all bool) (string, error) {
+	if old_text == "" {
+		return "", fmt.Errorf("old_text must not be empty")
+	}
+	filePath, content, err := f.readForEdit(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("file not found: %s", f.display(filePath))
+		}
+		return "", err
+	}
+	count := strings.Count(content, old_text)
+	switch {
+	case count == 0:
+		return "", fmt.Errorf("old_text not found in %s", f.display(filePath))
+	case count > 1 && !replace_all:
+		return "", fmt.Errorf("old_text found %d times in %s; include more context to make it unique, or set replace_all", count, f.display(filePath))
+	}
+	if err := writeKeepingMode(filePath, strings.ReplaceAll(content, old_text, new_text)); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Replaced %d occurrence(s) in %s", count, f.display(filePath)), nil
+}
+
+// ApplyDiff applies a unified diff to a local file, creating it if the diff adds a new file.
+// @param path: Path of the file, relative to the target directory
+// @param diff: Unified diff with "@@ -start,count +start,count @@" hunks of context, removed ("-") and added ("+") lines
+// @return Number of hunks applied or error message
+func (f *FileSystemTools) ApplyDiff(ctx context.Context, path, diff string) (string, error) {
+	hunks, err := parseUnifiedDiff(diff)
+	if err != nil {
+		return "", err
+	}
+	filePath, content, err := f.readForEdit(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+		// New files are created from diffs without removed or context lines
+		content = ""
+	}
+	patched, err := applyHunks(content, hunks)
+	if err != nil {
+		return "", fmt.Errorf("failed to apply diff to %s: %v", f.display(filePath), err)
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %v", err)
+	}
+	if err := writeKeepingMode(filePath, patched); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Applied %d hunk(s) to %s", len(hunks), f.display(filePath)), nil
+}
+
+// DeleteFile deletes a local file.
+// @param path: Path of the file, relative to the target directory
+// @return Path to the deleted file or error message
+func (f *FileSystemTools) DeleteFile(ctx context.Context, path string) (string, error) {
+	filePath, err := f.resolve(path)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return "", fmt.Errorf("file not found: %s", f.display(filePath))
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("%s is a directory; only files can be deleted", f.display(filePath))
+	}
+	if err := os.Remove(filePath); err != nil {
+		return "", fmt.Errorf("failed to delete file: %v", err)
+	}
+	return fmt.Sprintf("Successfully deleted file: %s", f.display(filePath)), nil
+}
+
+// FileInfo returns the type, size, permissions and modification time of a file or directory.
+// @param path: Path of the file or directory, relative to the target directory
+// @return JSON object with the path, type, size, permissions and modification time
+func (f *FileSystemTools) FileInfo(ctx context.Context, path string) (FileDetails, error) {
+	filePath, err := f.resolve(path)
+	if err != nil {
+		return FileDetails{}, err
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return FileDetails{}, fmt.Errorf("file not found: %s", f.display(filePath))
+	}
+	details := FileDetails{
+		Path:    f.relative(filePath),
+		Type:    "file",
+		Size:    info.Size(),
+		Mode:    info.Mode().Perm().String(),
+		ModTime: info.ModTime().Format(time.RFC3339),
+	}
+	if info.IsDir() {
+		details.Type = "directory"
+	}
+	return details, nil
+}
+
+// root returns the absolute path of the target directory, with symbolic links resolved.
+func (f *FileSystemTools) root() (string, error) {
+	root, err := filepath.Abs(utils.FirstNonEmpty(f.TargetDirectory, "."))
+	if err != nil {
+		return "", fmt.Errorf("invalid target directory: %v", err)
+	}
+	return resolveSymlinks(root)
+}
+
+// resolve returns the real path of a path relative to the target directory, with symbolic links resolved.
+// Absolute paths are accepted if they are in the target directory. It returns an error if the path is outside it.
+func (f *FileSystemTools) resolve(path string) (string, error) {
+	root, err := f.root()
+	if err != nil {
+		return "", err
+	}
+	target := path
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(root, target)
+	}
+	real, err := resolveSymlinks(filepath.Clean(target))
+	if err != nil {
+		return "", err
+	}
+	relative, err := filepath.Rel(root, real)
+	if err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %s is outside the target directory", path)
+	}
+	return real, nil
+}
+
+// resolveSymlinks resolves the symbolic links of the longest existing prefix of an absolute path.
+func resolveSymlinks(path string) (string, error) {
+	var missing []string // Trailing elements of the path which do not exist
+	for current := path; ; {
+		real, err := filepath.EvalSymlinks(current)
+		if err == nil {
+			return filepath.Join(append([]string{real}, missing...)...), nil
+		}
+		if _, statErr := os.Lstat(current); statErr == nil {
+			// The path exists but cannot be resolved, e.g. a dangling symbolic link which could point anywhere
+			return "", fmt.Errorf("failed to resolve path %s: %v", path, err)
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return path, nil
+		}
+		missing = append([]string{filepath.Base(current)}, missing...)
+		current = parent
+	}
+}
+
+// relative returns a real path relative to the target directory, with "/" separators.
+func (f *FileSystemTools) relative(path string) string {
+	root, err := f.root()
+	if err != nil {
+		return path
+	}
+	relative, err := filepath.Rel(root, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(relative)
+}
+
+// display returns a real path as a path in the target directory, as configured, for messages.
+func (f *FileSystemTools) display(path string) string {
+	return filepath.Join(f.TargetDirectory, filepath.FromSlash(f.relative(path)))
+}
+
+// maxReadSize returns the maximum number of bytes read from a file.
+func (f *FileSystemTools) maxReadSize() int {
+	if f.MaxReadSize <= 0 {
+		return 1000000
+	}
+	return f.MaxReadSize
+}
+
+// maxResults returns the maximum number of entries returned by the listing and search tools.
+func (f *FileSystemTools) maxResults() int {
+	if f.MaxResults <= 0 {
+		return 1000
+	}
+	return f.MaxResults
+}
+
+// walkFiles calls fn for the regular files under path in lexical order, skipping .git directories.
+func (f *FileSystemTools) walkFiles(ctx context.Context, path string, fn func(path string, entry fs.DirEntry) error) error {
+	return filepath.WalkDir(path, func(current string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if current == path {
+				return err
+			}
+			return nil // Unreadable directories are skipped
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == ".git" && current != path {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		return fn(current, entry)
+	})
+}
+
+// searchFile returns the lines of a file matching re. Binary files and files larger than MaxReadSize are skipped.
+func (f *FileSystemTools) searchFile(path, relative string, re *regexp.Regexp) ([]TextMatch, error) {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() > int64(f.maxReadSize()) {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.IndexByte(data[:min(len(data), 8000)], 0) >= 0 {
+		return nil, nil
+	}
+	var matches []TextMatch
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if re.MatchString(line) {
+			if len(line) > 500 {
+				line = strings.ToValidUTF8(line[:500], "") + "..."
+			}
+			matches = append(matches, TextMatch{Path: relative, Line: i + 1, Text: line})
+		}
+	}
+	return matches, nil
+}
+
+// readForEdit resolves the path of a file to edit and reads its content.
+// The returned error satisfies os.IsNotExist if the file does not exist.
+func (f *FileSystemTools) readForEdit(path string) (string, string, error) {
+	filePath, err := f.resolve(path)
+	if err != nil {
+		return "", "", err
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return filePath, "", err
+		}
+		return "", "", fmt.Errorf("failed to read file: %v", err)
+	}
+	if info.IsDir() {
+		return "", "", fmt.Errorf("%s is a directory", f.display(filePath))
+	}
+	if info.Size() > int64(f.maxReadSize()) {
+		return "", "", fmt.Errorf("%s is larger than %d bytes", f.display(filePath), f.maxReadSize())
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read file: %v", err)
+	}
+	return filePath, string(data), nil
+}
+
+// writeKeepingMode writes content to a file, keeping the permissions of existing files.
+func writeKeepingMode(path, content string) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := os.WriteFile(path, []byte(content), mode); err != nil {
+		return fmt.Errorf("failed to write file: %v", err)
+	}
+	return nil
+}
+
+// globToRegexp converts a glob pattern to a regular expression matching slash-separated paths.
+// "*" matches within a path element, "**" across elements, "?" a single character, and "[...]" and "{a,b}" are supported.
+func globToRegexp(pattern string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				if i+2 < len(pattern) && pattern[i+2] == '/' {
+					sb.WriteString("(?:.*/)?") // "**/" matches zero or more directories
+					i += 2
+				} else {
+					sb.WriteString(".*")
+					i++
+				}
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid glob pattern %q: unterminated [", pattern)
+			}
+			class := pattern[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + class + "]")
+			i += end
+		case '{':
+			end := strings.IndexByte(pattern[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid glob pattern %q: unterminated {", pattern)
+			}
+			alternatives := strings.Split(pattern[i+1:i+end], ",")
+			for j, alternative := range alternatives {
+				alternatives[j] = regexp.QuoteMeta(alternative)
+			}
+			sb.WriteString("(?:" + strings.Join(alternatives, "|") + ")")
+			i += end
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	re, err := regexp.Compile(sb.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid glob pattern %q: %v", pattern, err)
+	}
+	return re, nil
+}
+
+// diffHunk is a hunk of a unified diff.
+type diffHunk struct {
+	oldStart int      // Line of the hunk in the original file, starting at 1
+	lines    []string // Lines of the hunk, starting with " ", "-" or "+"
+}
+
+var hunkHeaderRegexp = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+\d+(?:,\d+)? @@`)
+
+// parseUnifiedDiff parses the hunks of a unified diff for a single file. File headers are ignored.
+func parseUnifiedDiff(diff string) ([]diffHunk, error) {
+	var hunks []diffHunk
+	lines := strings.Split(strings.ReplaceAll(diff, "\r\n", "\n"), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for _, line := range lines {
+		if match := hunkHeaderRegexp.FindStringSubmatch(line); match != nil {
+			start, _ := strconv.Atoi(match[1])
+			hunks = append(hunks, diffHunk{oldStart: start})
+			continue
+		}
+		if len(hunks) == 0 {
+			continue // Headers such as "--- a/file" and "+++ b/file"
+		}
+		hunk := &hunks[len(hunks)-1]
+		switch {
+		case line == "":
+			hunk.lines = append(hunk.lines, " ") // Empty context lines are often stripped of their space
+		case line[0] == ' ' || line[0] == '-' || line[0] == '+':
+			hunk.lines = append(hunk.lines, line)
+		case line[0] == '\\':
+			// "\ No newline at end of file"
+		default:
+			return nil, fmt.Errorf("invalid diff line %q", line)
+		}
+	}
+	if len(hunks) == 0 {
+		return nil, fmt.Errorf("no hunks found in diff")
+	}
+	return hunks, nil
+}
+
+// applyHunks applies diff hunks to content. Hunks are located by their context, starting at the line in their header.
+func applyHunks(content string, hunks []diffHunk) (string, error) {
+	lines := strings.Split(content, "\n")
+	offset := 0 // Difference between the line numbers of the patched and original content
+	minLine := 0
+	for i, hunk := range hunks {
+		var oldLines, newLines []string
+		for _, line := range hunk.lines {
+			if line[0] != '+' {
+				oldLines = append(oldLines, line[1:])
+			}
+			if line[0] != '-' {
+				newLines = append(newLines, line[1:])
+			}
+		}
+
+		// Index of the first old line in the original content. Hunks without old lines insert after their start line
+		base := hunk.oldStart - 1
+		if len(oldLines) == 0 {
+			base = hunk.oldStart
+		}
+		expected := max(base+offset, minLine)
+		position := -1
+		// Search around the expected line, closest first
+		for distance := 0; position < 0 && (expected-distance >= minLine || expected+distance <= len(lines)); distance++ {
+			for _, candidate := range []int{expected - distance, expected + distance} {
+				if candidate >= minLine && candidate+len(oldLines) <= len(lines) && linesEqual(lines[candidate:candidate+len(oldLines)], oldLines) {
+					position = candidate
+					break
+				}
+			}
+		}
+		if position < 0 {
+			return "", fmt.Errorf("hunk %d does not match the file", i+1)
+		}
+
+		lines = append(append(append([]string{}, lines[:position]...), newLines...), lines[position+len(oldLines):]...)
+		offset = position - base + len(newLines) - len(oldLines)
+		minLine = position + len(newLines)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// linesEqual reports whether two slices of lines are equal, ignoring trailing carriage returns.
+func linesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if strings.TrimSuffix(a[i], "\r") != strings.TrimSuffix(b[i], "\r") {
+			return false
+		}
+	}
+	return true
 }
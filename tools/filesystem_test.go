@@ -63,7 +63,7 @@ func TestFileSystemTools_ReadFile_Exists(t *testing.T) {
 	_, err := ftools.WriteFile(ctx, content, filename, "", "")
 	assert.NoError(t, err)
 	expectedPath := filepath.Join(tempDir, filename+".txt")
-	readContent, err := ftools.ReadFile(ctx, filename+".txt", "")
+	readContent, err := ftools.ReadFile(ctx, filename+".txt", "", 0, 0)
 	assert.NoError(t, err)
 	assert.Equal(t, content, readContent)
 	os.Remove(expectedPath)
@@ -77,7 +77,255 @@ func TestFileSystemTools_ReadFile_NotExist(t *testing.T) {
 		TargetDirectory: tempDir,
 	}
 	filename := "nonexistentfile.txt"
-	msg, err := ftools.ReadFile(ctx, filename, "")
+	msg, err := ftools.ReadFile(ctx, filename, "", 0, 0)
 	assert.NoError(t, err)
 	assert.True(t, strings.Contains(msg, "File not found:"))
 }
+
+func TestFileSystemTools_PathConfinement(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	root := filepath.Join(tempDir, "root")
+	assert.NoError(t, os.MkdirAll(root, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "secret.txt"), []byte("secret"), 0644))
+	assert.NoError(t, os.Symlink(tempDir, filepath.Join(root, "escape")))
+	assert.NoError(t, os.Symlink(filepath.Join(tempDir, "missing.txt"), filepath.Join(root, "dangling.txt")))
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "sub"), 0755))
+	assert.NoError(t, os.Symlink(filepath.Join(root, "sub"), filepath.Join(root, "inside")))
+	ftools := &FileSystemTools{TargetDirectory: root, DefaultExtension: "txt"}
+
+	_, err := ftools.ReadFile(ctx, "../secret.txt", "", 0, 0)
+	assert.EqualError(t, err, "path ../secret.txt is outside the target directory")
+	_, err = ftools.ReadFile(ctx, "secret.txt", "escape", 0, 0)
+	assert.EqualError(t, err, "path escape/secret.txt is outside the target directory")
+	_, err = ftools.ReadFile(ctx, filepath.Join(tempDir, "secret.txt"), "", 0, 0)
+	assert.ErrorContains(t, err, "is outside the target directory")
+	_, err = ftools.WriteFile(ctx, "x", "passwd", "../../etc", "")
+	assert.ErrorContains(t, err, "is outside the target directory")
+	_, err = ftools.WriteFile(ctx, "x", "dangling.txt", "", "")
+	assert.ErrorContains(t, err, "failed to resolve path")
+	_, err = ftools.DeleteFile(ctx, "escape/secret.txt")
+	assert.ErrorContains(t, err, "is outside the target directory")
+	_, err = os.Stat(filepath.Join(tempDir, "missing.txt"))
+	assert.True(t, os.IsNotExist(err))
+
+	// Symbolic links within the target directory and absolute paths in it are allowed
+	msg, err := ftools.WriteFile(ctx, "hello", "a.txt", "inside", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "Successfully wrote file to: "+filepath.Join(root, "sub", "a.txt"), msg)
+	content, err := ftools.ReadFile(ctx, filepath.Join(root, "sub", "a.txt"), "", 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", content)
+}
+
+func TestFileSystemTools_ReadFile_Limits(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "lines.txt"), []byte("one\ntwo\nthree\nfour\n"), 0644))
+	ftools := &FileSystemTools{TargetDirectory: tempDir, MaxReadSize: 10}
+
+	content, err := ftools.ReadFile(ctx, "lines.txt", "", 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "one\ntwo\nth\n[File truncated to 10 of 19 bytes; use start_line and end_line to read more]", content)
+
+	content, err = ftools.ReadFile(ctx, "lines.txt", "", 2, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "two\nthree\n", content)
+
+	content, err = ftools.ReadFile(ctx, "lines.txt", "", 4, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "four\n", content)
+
+	content, err = ftools.ReadFile(ctx, "lines.txt", "", 0, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, "one\ntwo\n[Output truncated to 10 bytes after line 2]", content)
+
+	_, err = ftools.ReadFile(ctx, "lines.txt", "", 9, 0)
+	assert.EqualError(t, err, "start_line 9 is beyond the end of the file (4 lines)")
+	_, err = ftools.ReadFile(ctx, "lines.txt", "", 3, 2)
+	assert.EqualError(t, err, "end_line 2 is before start_line 3")
+}
+
+func newFileTree(t *testing.T) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		"README.md":         "# Project\nTODO: write docs\n",
+		"main.go":           "package main\n\nfunc main() {\n\t// todo: run\n}\n",
+		"pkg/util.go":       "package pkg\n\nfunc Helper() {}\n",
+		"pkg/sub/deep.go":   "package sub\n",
+		"pkg/sub/notes.txt": "nothing to see\n",
+		".git/config":       "TODO in git\n",
+		"image.bin":         "TODO\x00binary",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestFileSystemTools_ListDirectory(t *testing.T) {
+	ctx := context.Background()
+	ftools := &FileSystemTools{TargetDirectory: newFileTree(t)}
+
+	listing, err := ftools.ListDirectory(ctx, "pkg", false)
+	assert.NoError(t, err)
+	assert.Equal(t, DirectoryListing{Entries: []FileEntry{
+		{Path: "pkg/sub", Type: "directory"},
+		{Path: "pkg/util.go", Type: "file", Size: 30},
+	}}, listing)
+
+	listing, err = ftools.ListDirectory(ctx, "pkg", true)
+	assert.NoError(t, err)
+	assert.Len(t, listing.Entries, 4)
+	assert.Equal(t, "pkg/sub/deep.go", listing.Entries[1].Path)
+
+	ftools.MaxResults = 3
+	listing, err = ftools.ListDirectory(ctx, "", true)
+	assert.NoError(t, err)
+	assert.Len(t, listing.Entries, 3)
+	assert.True(t, listing.Truncated)
+
+	_, err = ftools.ListDirectory(ctx, "main.go", false)
+	assert.EqualError(t, err, filepath.Join(ftools.TargetDirectory, "main.go")+" is not a directory")
+}
+
+func TestFileSystemTools_GlobFiles(t *testing.T) {
+	ctx := context.Background()
+	ftools := &FileSystemTools{TargetDirectory: newFileTree(t)}
+
+	testCases := []struct {
+		pattern  string
+		expected []string
+	}{
+		{"*.go", []string{"main.go"}},
+		{"**/*.go", []string{"main.go", "pkg/sub/deep.go", "pkg/util.go"}},
+		{"pkg/**", []string{"pkg/sub/deep.go", "pkg/sub/notes.txt", "pkg/util.go"}},
+		{"./pkg/*.{go,txt}", []string{"pkg/util.go"}},
+		{"**/[!m]*.go", []string{"pkg/sub/deep.go", "pkg/util.go"}},
+		{"*.py", []string{}},
+	}
+	for _, tc := range testCases {
+		result, err := ftools.GlobFiles(ctx, tc.pattern)
+		assert.NoError(t, err, tc.pattern)
+		assert.Equal(t, tc.expected, result.Files, tc.pattern)
+	}
+
+	_, err := ftools.GlobFiles(ctx, "[abc")
+	assert.ErrorContains(t, err, "unterminated [")
+}
+
+func TestFileSystemTools_SearchInFiles(t *testing.T) {
+	ctx := context.Background()
+	ftools := &FileSystemTools{TargetDirectory: newFileTree(t)}
+
+	result, err := ftools.SearchInFiles(ctx, "(?i)todo", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []TextMatch{
+		{Path: "README.md", Line: 2, Text: "TODO: write docs"},
+		{Path: "main.go", Line: 4, Text: "\t// todo: run"},
+	}, result.Matches)
+
+	result, err = ftools.SearchInFiles(ctx, `^func \w+`, "pkg", "*.go")
+	assert.NoError(t, err)
+	assert.Equal(t, []TextMatch{{Path: "pkg/util.go", Line: 3, Text: "func Helper() {}"}}, result.Matches)
+
+	result, err = ftools.SearchInFiles(ctx, "package", "", "pkg/sub/*")
+	assert.NoError(t, err)
+	assert.Equal(t, []TextMatch{{Path: "pkg/sub/deep.go", Line: 1, Text: "package sub"}}, result.Matches)
+
+	_, err = ftools.SearchInFiles(ctx, "(", "", "")
+	assert.ErrorContains(t, err, "invalid pattern")
+}
+
+func TestFileSystemTools_Edit(t *testing.T) {
+	ctx := context.Background()
+	dir := newFileTree(t)
+	ftools := &FileSystemTools{TargetDirectory: dir}
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		assert.NoError(t, err)
+		return string(data)
+	}
+
+	_, err := ftools.ReplaceInFile(ctx, "pkg/util.go", "package", "package", false)
+	assert.NoError(t, err)
+	_, err = ftools.ReplaceInFile(ctx, "main.go", "missing", "x", false)
+	assert.EqualError(t, err, "old_text not found in "+filepath.Join(dir, "main.go"))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "twice.txt"), []byte("a a"), 0600))
+	_, err = ftools.ReplaceInFile(ctx, "twice.txt", "a", "b", false)
+	assert.EqualError(t, err, "old_text found 2 times in "+filepath.Join(dir, "twice.txt")+"; include more context to make it unique, or set replace_all")
+	msg, err := ftools.ReplaceInFile(ctx, "twice.txt", "a", "b", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "Replaced 2 occurrence(s) in "+filepath.Join(dir, "twice.txt"), msg)
+	assert.Equal(t, "b b", read("twice.txt"))
+	info, _ := os.Stat(filepath.Join(dir, "twice.txt"))
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	msg, err = ftools.AppendFile(ctx, "logs/app.log", "first\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "Successfully appended to file: "+filepath.Join(dir, "logs", "app.log"), msg)
+	_, err = ftools.AppendFile(ctx, "logs/app.log", "second\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "first\nsecond\n", read("logs/app.log"))
+
+	_, err = ftools.DeleteFile(ctx, "pkg")
+	assert.EqualError(t, err, filepath.Join(dir, "pkg")+" is a directory; only files can be deleted")
+	_, err = ftools.DeleteFile(ctx, "twice.txt")
+	assert.NoError(t, err)
+	_, err = ftools.FileInfo(ctx, "twice.txt")
+	assert.EqualError(t, err, "file not found: "+filepath.Join(dir, "twice.txt"))
+
+	details, err := ftools.FileInfo(ctx, "main.go")
+	assert.NoError(t, err)
+	assert.Equal(t, "main.go", details.Path)
+	assert.Equal(t, "file", details.Type)
+	assert.Equal(t, int64(44), details.Size)
+	assert.Equal(t, "-rw-r--r--", details.Mode)
+}
+
+func TestFileSystemTools_ApplyDiff(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	ftools := &FileSystemTools{TargetDirectory: dir}
+	original := "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "numbers.txt"), []byte(original), 0644))
+
+	// The first hunk is three lines off, and the second is located from the offset of the first
+	diff := `--- a/numbers.txt
++++ b/numbers.txt
+@@ -4,3 +4,3 @@
+ one
+-two
++TWO
+ three
+@@ -8,1 +8,3 @@
+ eight
++nine
++ten
+`
+	msg, err := ftools.ApplyDiff(ctx, "numbers.txt", diff)
+	assert.NoError(t, err)
+	assert.Equal(t, "Applied 2 hunk(s) to "+filepath.Join(dir, "numbers.txt"), msg)
+	data, _ := os.ReadFile(filepath.Join(dir, "numbers.txt"))
+	assert.Equal(t, "one\nTWO\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\n", string(data))
+
+	_, err = ftools.ApplyDiff(ctx, "numbers.txt", "@@ -1,2 +1,2 @@\n one\n-missing\n+x\n")
+	assert.EqualError(t, err, "failed to apply diff to "+filepath.Join(dir, "numbers.txt")+": hunk 1 does not match the file")
+	_, err = ftools.ApplyDiff(ctx, "numbers.txt", "just text")
+	assert.EqualError(t, err, "no hunks found in diff")
+
+	_, err = ftools.ApplyDiff(ctx, "new/file.txt", "--- /dev/null\n+++ b/new/file.txt\n@@ -0,0 +1,2 @@\n+hello\n+world\n")
+	assert.NoError(t, err)
+	data, _ = os.ReadFile(filepath.Join(dir, "new", "file.txt"))
+	assert.Equal(t, "hello\nworld\n", string(data))
+}
+
+func TestFileSystemTools_Tools(t *testing.T) {
+	assert.Len(t, (&FileSystemTools{EnableAll: true}).Tools(), 10)
+	tools := (&FileSystemTools{EnableReadFile: true, EnableGlobFiles: true}).Tools()
+	assert.Len(t, tools, 2)
+	assert.Equal(t, "ReadFile", tools[0].Name)
+	assert.Equal(t, "GlobFiles", tools[1].Name)
+}
@@ -0,0 +1,262 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Harsh-2909/hermes-go/utils"
+)
+
+// GitTools provides tools for inspecting a local git repository with the git binary.
+// The Commit and CreateBranch tools modify the repository, so EnableAll does not enable them.
+type GitTools struct {
+	RepositoryPath     string        // Required path of the repository
+	EnableStatus       bool          // Enable the Status tool
+	EnableLog          bool          // Enable the Log tool
+	EnableDiff         bool          // Enable the Diff tool
+	EnableShow         bool          // Enable the Show tool
+	EnableBlame        bool          // Enable the Blame tool
+	EnableListBranches bool          // Enable the ListBranches tool
+	EnableAll          bool          // Enable all read-only tools if true
+	EnableCommit       bool          // Enable the Commit tool
+	EnableCreateBranch bool          // Enable the CreateBranch tool
+	AuthorName         string        // Name of the author of commits. Uses the git configuration if empty
+	AuthorEmail        string        // Email of the author of commits. Uses the git configuration if empty
+	MaxOutputSize      int           // Maximum size in bytes of the output of a tool; longer output is truncated. Defaults to 20000
+	Timeout            time.Duration // Maximum duration of a git command. Defaults to 30 seconds
+}
+
+// Tools returns a list of available tools based on enable flags.
+func (g *GitTools) Tools() []Tool {
+	var tools []Tool
+
+	methods := []struct {
+		enabled bool
+		name    string
+	}{
+		{g.EnableStatus || g.EnableAll, "Status"},
+		{g.EnableLog || g.EnableAll, "Log"},
+		{g.EnableDiff || g.EnableAll, "Diff"},
+		{g.EnableShow || g.EnableAll, "Show"},
+		{g.EnableBlame || g.EnableAll, "Blame"},
+		{g.EnableListBranches || g.EnableAll, "ListBranches"},
+		{g.EnableCommit, "Commit"},
+		{g.EnableCreateBranch, "CreateBranch"},
+	}
+	for _, method := range methods {
+		if !method.enabled {
+			continue
+		}
+		if tool, err := CreateToolFromMethod(g, method.name); err == nil {
+			tools = append(tools, tool)
+		} else {
+			utils.Logger.Error("Failed to create tool", "tool", method.name, "error", err)
+		}
+	}
+
+	return tools
+}
+
+// Status shows the current branch and the changed files of the repository.
+// @return Output of git status in short format
+func (g *GitTools) Status(ctx context.Context) (string, error) {
+	return g.run(ctx, "status", "--short", "--branch")
+}
+
+// Log shows the commit history.
+// @param [optional] max_count: Maximum number of commits. Defaults to 20
+// @param [optional] revision: Revision or range to show the history of, e.g. "main" or "v1.0..HEAD". Defaults to HEAD
+// @param [optional] path: Only show commits changing this file or directory
+// @return Commits with their hash, date, author and subject
+func (g *GitTools) Log(ctx context.Context, max_count int, revision, path string) (string, error) {
+	if max_count <= 0 {
+		max_count = 20
+	}
+	args := []string{"log", "--max-count=" + strconv.Itoa(max_count), "--date=short", "--format=%h %ad %an: %s"}
+	if revision != "" {
+		if err := checkRevision(revision); err != nil {
+			return "", err
+		}
+		args = append(args, revision)
+	}
+	args = append(args, "--")
+	if path != "" {
+		args = append(args, path)
+	}
+	return g.run(ctx, args...)
+}
+
+// Diff shows changes between the working tree, the index and commits.
+// @param [optional] revision: Revision or range to compare with, e.g. "HEAD~1" or "main..feature". Compares the working tree with the index if not provided
+// @param [optional] paths: Only show changes of these files or directories
+// @param [optional] staged: If true, shows the staged changes instead of the working tree changes
+// @return Diff in unified format
+func (g *GitTools) Diff(ctx context.Context, revision string, paths []string, staged bool) (string, error) {
+	args := []string{"diff"}
+	if staged {
+		args = append(args, "--cached")
+	}
+	if revision != "" {
+		if err := checkRevision(revision); err != nil {
+			return "", err
+		}
+		args = append(args, revision)
+	}
+	args = append(append(args, "--"), paths...)
+	return g.run(ctx, args...)
+}
+
+// Show shows a commit with its message and changes.
+// @param [optional] revision: Revision to show, e.g. a commit hash or tag. Defaults to HEAD
+// @return Commit metadata, message, changed files and diff
+func (g *GitTools) Show(ctx context.Context, revision string) (string, error) {
+	if revision == "" {
+		revision = "HEAD"
+	}
+	if err := checkRevision(revision); err != nil {
+		return "", err
+	}
+	return g.run(ctx, "show", "--stat", "--patch", "--date=iso", revision, "--")
+}
+
+// Blame shows the commit, author and date of the last change of each line of a file.
+// @param path: Path of the file, relative to the repository
+// @param [optional] start_line: First line, starting at 1. Defaults to the first line of the file
+// @param [optional] end_line: Last line, included. Defaults to the last line of the file
+// @return Annotated lines of the file
+func (g *GitTools) Blame(ctx context.Context, path string, start_line, end_line int) (string, error) {
+	if path == "" {
+		return "", fmt.Errorf("no path provided")
+	}
+	args := []string{"blame", "--date=short"}
+	if start_line > 0 || end_line > 0 {
+		lineRange := strconv.Itoa(max(start_line, 1)) + ","
+		if end_line > 0 {
+			lineRange += strconv.Itoa(end_line)
+		}
+		args = append(args, "-L", lineRange)
+	}
+	return g.run(ctx, append(args, "--", path)...)
+}
+
+// ListBranches lists the local and remote branches, marking the current branch with "*".
+// @return Branches with their latest commit
+func (g *GitTools) ListBranches(ctx context.Context) (string, error) {
+	return g.run(ctx, "branch", "--all", "--format=%(HEAD) %(refname:short) %(objectname:short) %(contents:subject)")
+}
+
+// Commit records changes in a new commit.
+// @param message: Commit message
+// @param [optional] paths: Files or directories to stage before committing. Commits the already staged changes if not provided
+// @param [optional] all: If true, stages all changes of tracked files before committing
+// @return Summary of the commit
+func (g *GitTools) Commit(ctx context.Context, message string, paths []string, all bool) (string, error) {
+	if strings.TrimSpace(message) == "" {
+		return "", fmt.Errorf("no commit message provided")
+	}
+	if len(paths) > 0 {
+		if _, err := g.run(ctx, append([]string{"add", "--"}, paths...)...); err != nil {
+			return "", err
+		}
+	}
+	args := []string{"commit", "--message", message}
+	if all {
+		args = append(args, "--all")
+	}
+	return g.run(ctx, args...)
+}
+
+// CreateBranch creates a branch.
+// @param name: Name of the branch
+// @param [optional] start_point: Revision the branch starts at. Defaults to HEAD
+// @param [optional] checkout: If true, switches to the new branch
+// @return Confirmation message
+func (g *GitTools) CreateBranch(ctx context.Context, name, start_point string, checkout bool) (string, error) {
+	if strings.HasPrefix(name, "-") {
+		return "", fmt.Errorf("invalid branch name %q", name)
+	}
+	if _, err := g.run(ctx, "check-ref-format", "--branch", name); err != nil {
+		return "", fmt.Errorf("invalid branch name %q", name)
+	}
+	args := []string{"branch", name}
+	if checkout {
+		args = []string{"switch", "--create", name}
+	}
+	if start_point != "" {
+		if err := checkRevision(start_point); err != nil {
+			return "", err
+		}
+		args = append(args, start_point)
+	}
+	if _, err := g.run(ctx, args...); err != nil {
+		return "", err
+	}
+	if checkout {
+		return fmt.Sprintf("Created and switched to branch %s", name), nil
+	}
+	return fmt.Sprintf("Created branch %s", name), nil
+}
+
+// checkRevision returns an error if a revision could be read as an option of git.
+func checkRevision(revision string) error {
+	if strings.HasPrefix(revision, "-") {
+		return fmt.Errorf("invalid revision %q", revision)
+	}
+	return nil
+}
+
+// run runs a git command in the repository and returns its output, truncated to MaxOutputSize.
+func (g *GitTools) run(ctx context.Context, args ...string) (string, error) {
+	if g.RepositoryPath == "" {
+		return "", fmt.Errorf("no repository path configured")
+	}
+	timeout := g.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	maxOutputSize := g.MaxOutputSize
+	if maxOutputSize <= 0 {
+		maxOutputSize = 20000
+	}
+	stdout := &limitedBuffer{limit: maxOutputSize}
+	stderr := &limitedBuffer{limit: 2000}
+
+	// Pagers, colors and prompts are disabled as the output is not read by a terminal
+	fullArgs := append([]string{"--no-pager", "-c", "color.ui=never", "-C", g.RepositoryPath}, args...)
+	cmd := exec.CommandContext(ctx, "git", fullArgs...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_OPTIONAL_LOCKS=0", "LC_ALL=C")
+	if g.AuthorName != "" {
+		cmd.Env = append(cmd.Env, "GIT_AUTHOR_NAME="+g.AuthorName, "GIT_COMMITTER_NAME="+g.AuthorName)
+	}
+	if g.AuthorEmail != "" {
+		cmd.Env = append(cmd.Env, "GIT_AUTHOR_EMAIL="+g.AuthorEmail, "GIT_COMMITTER_EMAIL="+g.AuthorEmail)
+	}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = time.Second
+
+	utils.Logger.Debug("Running git", "args", args, "repository", g.RepositoryPath)
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("git %s timed out after %v", args[0], timeout)
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			message := strings.TrimSpace(stderr.String())
+			if message == "" {
+				message = strings.TrimSpace(stdout.String())
+			}
+			return "", fmt.Errorf("git %s failed: %s", args[0], message)
+		}
+		return "", fmt.Errorf("failed to run git: %v", err)
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,147 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newGitRepository creates a repository with two commits on the main branch.
+func newGitRepository(t *testing.T) (*GitTools, string) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("requires git")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=Tester", "-c", "user.email=tester@example.com"}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE=2024-01-02T10:00:00Z", "GIT_COMMITTER_DATE=2024-01-02T10:00:00Z")
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	write := func(name, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	git("init", "--quiet", "--initial-branch=main")
+	write("README.md", "# Project\n")
+	write("src/main.go", "package main\n")
+	git("add", ".")
+	git("commit", "--quiet", "-m", "Initial commit")
+	write("src/main.go", "package main\n\nfunc main() {}\n")
+	git("commit", "--quiet", "-am", "Add main function")
+
+	return &GitTools{RepositoryPath: dir, AuthorName: "Agent", AuthorEmail: "agent@example.com"}, dir
+}
+
+func TestGitTools_Read(t *testing.T) {
+	ctx := context.Background()
+	gitTools, dir := newGitRepository(t)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Project\n\nDocs\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("notes\n"), 0644))
+
+	status, err := gitTools.Status(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "## main\n M README.md\n?? notes.txt\n", status)
+
+	log, err := gitTools.Log(ctx, 0, "", "")
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(log), "\n")
+	assert.Len(t, lines, 2)
+	assert.Regexp(t, `^[0-9a-f]+ 2024-01-02 Tester: Add main function$`, lines[0])
+
+	log, err = gitTools.Log(ctx, 1, "HEAD~1", "README.md")
+	assert.NoError(t, err)
+	assert.Contains(t, log, "Initial commit")
+
+	diff, err := gitTools.Diff(ctx, "", nil, false)
+	assert.NoError(t, err)
+	assert.Contains(t, diff, "+Docs")
+	diff, err = gitTools.Diff(ctx, "HEAD~1", []string{"src"}, false)
+	assert.NoError(t, err)
+	assert.Contains(t, diff, "+func main() {}")
+	assert.NotContains(t, diff, "README.md")
+	diff, err = gitTools.Diff(ctx, "", nil, true)
+	assert.NoError(t, err)
+	assert.Empty(t, diff)
+
+	show, err := gitTools.Show(ctx, "")
+	assert.NoError(t, err)
+	assert.Contains(t, show, "Add main function")
+	assert.Contains(t, show, "src/main.go | 2 ++")
+
+	blame, err := gitTools.Blame(ctx, "src/main.go", 3, 3)
+	assert.NoError(t, err)
+	assert.Regexp(t, `^[0-9a-f]+ \(Tester 2024-01-02 3\) func main\(\) \{\}\n$`, blame)
+
+	branches, err := gitTools.ListBranches(ctx)
+	assert.NoError(t, err)
+	assert.Regexp(t, `^\* main [0-9a-f]+ Add main function\n$`, branches)
+}
+
+func TestGitTools_Errors(t *testing.T) {
+	ctx := context.Background()
+	gitTools, _ := newGitRepository(t)
+
+	_, err := gitTools.Show(ctx, "--output=/tmp/x")
+	assert.EqualError(t, err, `invalid revision "--output=/tmp/x"`)
+	_, err = gitTools.Show(ctx, "missing")
+	assert.ErrorContains(t, err, "git show failed: fatal: bad revision 'missing'")
+	_, err = gitTools.Diff(ctx, "", []string{"../outside"}, false)
+	assert.ErrorContains(t, err, "outside repository")
+	_, err = gitTools.Blame(ctx, "", 0, 0)
+	assert.EqualError(t, err, "no path provided")
+
+	gitTools.MaxOutputSize = 10
+	log, err := gitTools.Log(ctx, 0, "", "")
+	assert.NoError(t, err)
+	assert.Regexp(t, `^.{10}\n\[truncated \d+ bytes\]$`, log)
+
+	_, err = (&GitTools{}).Status(ctx)
+	assert.EqualError(t, err, "no repository path configured")
+}
+
+func TestGitTools_Write(t *testing.T) {
+	ctx := context.Background()
+	gitTools, dir := newGitRepository(t)
+
+	_, err := gitTools.CreateBranch(ctx, "bad..name", "", false)
+	assert.EqualError(t, err, `invalid branch name "bad..name"`)
+	_, err = gitTools.CreateBranch(ctx, "-D", "", false)
+	assert.EqualError(t, err, `invalid branch name "-D"`)
+	msg, err := gitTools.CreateBranch(ctx, "old", "HEAD~1", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "Created branch old", msg)
+	msg, err = gitTools.CreateBranch(ctx, "feature", "", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "Created and switched to branch feature", msg)
+
+	_, err = gitTools.Commit(ctx, "Nothing", nil, false)
+	assert.ErrorContains(t, err, "git commit failed")
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "new.txt"), []byte("new\n"), 0644))
+	msg, err = gitTools.Commit(ctx, "Add new file", []string{"new.txt"}, false)
+	assert.NoError(t, err)
+	assert.Contains(t, msg, "[feature ")
+	assert.Contains(t, msg, "Add new file")
+
+	log, err := gitTools.Log(ctx, 1, "", "")
+	assert.NoError(t, err)
+	assert.Contains(t, log, "Agent: Add new file")
+	branches, err := gitTools.ListBranches(ctx)
+	assert.NoError(t, err)
+	assert.Contains(t, branches, "* feature")
+	assert.Contains(t, branches, "  old")
+}
+
+func TestGitTools_Tools(t *testing.T) {
+	assert.Len(t, (&GitTools{EnableAll: true}).Tools(), 6)
+	tools := (&GitTools{EnableAll: true, EnableCommit: true, EnableCreateBranch: true}).Tools()
+	assert.Len(t, tools, 8)
+	assert.Equal(t, "CreateBranch", tools[7].Name)
+}
@@ -1,3 +1,5 @@
+//go:generate go run ../cmd/toolgen
+
 package tools
 
 import (
@@ -12,9 +14,12 @@ import (
 	"reflect"
 	"slices"
 	"strings"
+	"time"
 )
 
 // CreateToolFromMethod creates a Tool from a method of a toolkit instance.
+// The description and parameters of the tool are documented in the method's doc comment (see ParseToolDoc).
+// Metadata generated at build time by cmd/toolgen is used if registered, otherwise the package source code is parsed.
 //
 // TODO: Changes to be made:
 // - Refactor this function to use go/types package instead of go/ast.
@@ -50,6 +55,96 @@ func CreateToolFromMethod(toolkit interface{}, methodName string) (Tool, error)
 		paramTypes[i-2] = methodType.In(i)
 	}
 
+	// Get the method documentation, generated at build time or parsed from source
+	metadata, err := lookupToolMetadata(toolkit, methodName, len(paramTypes))
+	if err != nil {
+		return Tool{}, err
+	}
+	description := metadata.Description
+	paramNames := make([]string, len(metadata.Params))
+	required := make([]string, 0)
+	for i, param := range metadata.Params {
+		paramNames[i] = param.Name
+		// Pointer parameters are always optional
+		if param.Required && paramTypes[i].Kind() != reflect.Pointer {
+			required = append(required, param.Name)
+		}
+	}
+
+	// Build JSON schema parameters
+	properties := make(map[string]interface{})
+	for i, name := range paramNames {
+		schema, err := paramSchema(paramTypes[i], metadata.Params[i])
+		if err != nil {
+			return Tool{}, fmt.Errorf("parameter %s: %v", name, err)
+		}
+		properties[name] = schema
+	}
+	parameters := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+
+	// Create the Execute function
+	execute := func(ctx context.Context, args string) (string, error) {
+		var argMap map[string]interface{}
+		if err := json.Unmarshal([]byte(args), &argMap); err != nil {
+			return "", fmt.Errorf("failed to unmarshal args: %v", err)
+		}
+
+		// Build argument list
+		argValues := []reflect.Value{reflect.ValueOf(toolkit), reflect.ValueOf(ctx)}
+		for i, name := range paramNames {
+			val, ok := argMap[name]
+			if !ok || val == nil {
+				// check if the parameter is optional
+				if slices.Contains(required, name) {
+					return "", fmt.Errorf("missing required parameter: %s", name)
+				}
+				// Use the zero value for missing optional parameters, e.g. nil for pointers
+				argValues = append(argValues, reflect.Zero(paramTypes[i]))
+				continue
+			}
+			converted, err := convertJSONValueToGoType(val, paramTypes[i])
+			if err != nil {
+				return "", fmt.Errorf("type conversion failed for %s: %v", name, err)
+			}
+			argValues = append(argValues, converted)
+		}
+
+		// Call the method
+		results := method.Func.Call(argValues)
+		if hasError {
+			if err, ok := results[1].Interface().(error); ok && err != nil {
+				return "", err
+			}
+			result := results[0].Interface()
+			jsonResult, err := json.Marshal(result)
+			if err != nil {
+				return "", fmt.Errorf("failed to marshal result: %v", err)
+			}
+			return string(jsonResult), nil
+		}
+		result := results[0].Interface()
+		jsonResult, err := json.Marshal(result)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal result: %v", err)
+		}
+		return string(jsonResult), nil
+	}
+
+	return Tool{
+		Name:        methodName,
+		Description: description,
+		Parameters:  parameters,
+		Execute:     execute,
+	}, nil
+}
+
+// parseToolMetadataFromSource parses the doc comment of a toolkit method from the source code of its package.
+// It requires the source code to be available at runtime; see RegisterToolMetadata for compiled binaries.
+func parseToolMetadataFromSource(toolkit interface{}, methodName string) (ToolMetadata, error) {
 	// Get package path and type name from the toolkit
 	pkgPath := reflect.TypeOf(toolkit).Elem().PkgPath()
 	typeName := reflect.TypeOf(toolkit).Elem().Name()
@@ -57,7 +152,7 @@ func CreateToolFromMethod(toolkit interface{}, methodName string) (Tool, error)
 	// Find the source directory using go/build
 	bpkg, err := build.Import(pkgPath, "", build.FindOnly)
 	if err != nil {
-		return Tool{}, fmt.Errorf("failed to find package %s: %v", pkgPath, err)
+		return ToolMetadata{}, fmt.Errorf("failed to find package %s: %v", pkgPath, err)
 	}
 	srcDir := bpkg.Dir
 
@@ -65,7 +160,7 @@ func CreateToolFromMethod(toolkit interface{}, methodName string) (Tool, error)
 	fset := token.NewFileSet()
 	pkgs, err := parser.ParseDir(fset, srcDir, nil, parser.ParseComments)
 	if err != nil {
-		return Tool{}, fmt.Errorf("failed to parse package %s: %v", pkgPath, err)
+		return ToolMetadata{}, fmt.Errorf("failed to parse package %s: %v", pkgPath, err)
 	}
 
 	// Assume the first package (typically one package per directory)
@@ -76,7 +171,7 @@ func CreateToolFromMethod(toolkit interface{}, methodName string) (Tool, error)
 		break
 	}
 	if astPkg == nil {
-		return Tool{}, fmt.Errorf("no package found in %s", srcDir)
+		return ToolMetadata{}, fmt.Errorf("no package found in %s", srcDir)
 	}
 
 	// Find the method declaration
@@ -105,14 +200,14 @@ func CreateToolFromMethod(toolkit interface{}, methodName string) (Tool, error)
 		}
 	}
 	if file == "" {
-		return Tool{}, fmt.Errorf("method %s not found on type %s", methodName, typeName)
+		return ToolMetadata{}, fmt.Errorf("method %s not found on type %s", methodName, typeName)
 	}
 
 	// Parse the source file
 	fset = token.NewFileSet()
 	pkgs, err = parser.ParseDir(fset, filepath.Dir(file), nil, parser.ParseComments)
 	if err != nil {
-		return Tool{}, fmt.Errorf("failed to parse source file: %v", err)
+		return ToolMetadata{}, fmt.Errorf("failed to parse source file: %v", err)
 	}
 
 	var astFile *ast.File
@@ -128,7 +223,7 @@ func CreateToolFromMethod(toolkit interface{}, methodName string) (Tool, error)
 		}
 	}
 	if astFile == nil {
-		return Tool{}, fmt.Errorf("source file not found")
+		return ToolMetadata{}, fmt.Errorf("source file not found")
 	}
 
 	// Find the method declaration
@@ -140,211 +235,96 @@ func CreateToolFromMethod(toolkit interface{}, methodName string) (Tool, error)
 		}
 	}
 	if funcDecl == nil || funcDecl.Doc == nil {
-		return Tool{}, fmt.Errorf("method %s has no doc comments", methodName)
-	}
-
-	// Parse doc comments
-	doc := funcDecl.Doc.Text()
-	lines := strings.Split(doc, "\n")
-	description := strings.TrimSpace(lines[0]) // First line is the description
-
-	paramDescs := make(map[string]string)
-	required := make([]string, 0)
-	for _, line := range lines[1:] {
-		line = strings.TrimSpace(line)
-
-		// Checking for @param or @params prefix. Either is supported
-		var prefix string
-		if strings.HasPrefix(line, "@param ") {
-			prefix = "@param "
-		} else if strings.HasPrefix(line, "@params ") {
-			prefix = "@params "
-		}
-		if prefix != "" {
-			paramLine := strings.TrimPrefix(line, prefix)
-			isOptional := false
-
-			// Checks for optional parameter
-			if strings.HasPrefix(paramLine, "[optional] ") {
-				isOptional = true
-				paramLine = strings.TrimPrefix(paramLine, "[optional] ")
-			}
-			parts := strings.SplitN(paramLine, ":", 2)
-			if len(parts) == 2 {
-				name := strings.TrimSpace(parts[0])
-				desc := strings.TrimSpace(parts[1])
-				paramDescs[name] = desc
-				if !isOptional {
-					required = append(required, name)
-				}
-			}
-		}
+		return ToolMetadata{}, fmt.Errorf("method %s has no doc comments", methodName)
 	}
 
 	// Get parameter names from AST (skip receiver and ctx)
-	paramNames := make([]string, 0, len(paramTypes))
+	paramNames := make([]string, 0)
 	for _, field := range funcDecl.Type.Params.List[1:] { // Skip ctx
 		for _, name := range field.Names {
 			paramNames = append(paramNames, name.Name)
 		}
 	}
-	if len(paramNames) != len(paramTypes) {
-		return Tool{}, fmt.Errorf("parameter count mismatch")
+	return ParseToolDoc(funcDecl.Doc.Text(), paramNames), nil
+}
+
+// durationType is the type of time.Duration parameters, which are passed as strings such as "1h30m".
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// paramSchema returns the JSON Schema of a method parameter of type t, documented by param.
+// Structs, slices and maps use JSONSchemaFor, so struct fields can use its struct tags.
+// time.Duration parameters are strings parsed with time.ParseDuration, and param.Enum restricts string values.
+func paramSchema(t reflect.Type, param ParamMetadata) (map[string]interface{}, error) {
+	elem := t
+	for elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
 	}
 
-	// Build JSON schema parameters
-	properties := make(map[string]interface{})
-	for i, name := range paramNames {
-		schemaType, ok := goTypeToJSONSchemaType(paramTypes[i])
-		if !ok {
-			return Tool{}, fmt.Errorf("unsupported parameter type: %v", paramTypes[i])
-		}
-		properties[name] = map[string]interface{}{
-			"type":        schemaType,
-			"description": paramDescs[name],
+	var schema map[string]interface{}
+	if elem == durationType {
+		schema = map[string]interface{}{"type": "string", "pattern": durationPattern}
+		param.Description = strings.TrimSpace(param.Description + " (duration, e.g. \"1h30m\" or \"90s\")")
+	} else {
+		var err error
+		if schema, err = JSONSchemaFor(t); err != nil {
+			return nil, err
 		}
 	}
-	parameters := map[string]interface{}{
-		"type":       "object",
-		"properties": properties,
-		"required":   required,
-	}
+	schema["description"] = param.Description
 
-	// Create the Execute function
-	execute := func(ctx context.Context, args string) (string, error) {
-		var argMap map[string]interface{}
-		if err := json.Unmarshal([]byte(args), &argMap); err != nil {
-			return "", fmt.Errorf("failed to unmarshal args: %v", err)
+	if len(param.Enum) > 0 {
+		values := make([]interface{}, len(param.Enum))
+		for i, value := range param.Enum {
+			values[i] = value
 		}
-
-		// Build argument list
-		argValues := []reflect.Value{reflect.ValueOf(toolkit), reflect.ValueOf(ctx)}
-		for i, name := range paramNames {
-			val, ok := argMap[name]
-			if !ok {
-				// check if the parameter is optional
-				if slices.Contains(required, name) {
-					return "", fmt.Errorf("missing required parameter: %s", name)
-				}
-				// Use default value for missing optional parameters.
-				switch paramTypes[i].Kind() {
-				case reflect.String:
-					argValues = append(argValues, reflect.ValueOf(""))
-				case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-					argValues = append(argValues, reflect.ValueOf(0))
-				case reflect.Float32, reflect.Float64:
-					argValues = append(argValues, reflect.ValueOf(0.0))
-				case reflect.Bool:
-					argValues = append(argValues, reflect.ValueOf(false))
-				case reflect.Slice:
-					argValues = append(argValues, reflect.ValueOf([]interface{}{}))
-				case reflect.Map:
-					argValues = append(argValues, reflect.ValueOf(make(map[string]interface{})))
-				default:
-					return "", fmt.Errorf("optional Parameter %s with type %s not supported", name, paramTypes[i])
-				}
-			} else {
-				converted, err := convertJSONValueToGoType(val, paramTypes[i])
-				if err != nil {
-					return "", fmt.Errorf("type conversion failed for %s: %v", name, err)
-				}
-				argValues = append(argValues, reflect.ValueOf(converted))
-			}
+		switch {
+		case schema["type"] == "string" && elem != durationType:
+			schema["enum"] = values
+		case schema["type"] == "array" && schema["items"].(map[string]interface{})["type"] == "string":
+			schema["items"].(map[string]interface{})["enum"] = values
+		default:
+			return nil, fmt.Errorf("enum is only supported for string and string slice parameters")
 		}
-
-		// Call the method
-		results := method.Func.Call(argValues)
-		if hasError {
-			if err, ok := results[1].Interface().(error); ok && err != nil {
-				return "", err
-			}
-			result := results[0].Interface()
-			jsonResult, err := json.Marshal(result)
-			if err != nil {
-				return "", fmt.Errorf("failed to marshal result: %v", err)
-			}
-			return string(jsonResult), nil
-		}
-		result := results[0].Interface()
-		jsonResult, err := json.Marshal(result)
-		if err != nil {
-			return "", fmt.Errorf("failed to marshal result: %v", err)
-		}
-		return string(jsonResult), nil
 	}
-
-	return Tool{
-		Name:        methodName,
-		Description: description,
-		Parameters:  parameters,
-		Execute:     execute,
-	}, nil
+	return schema, nil
 }
 
-func goTypeToJSONSchemaType(t reflect.Type) (string, bool) {
-	switch t.Kind() {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return "integer", true
-	case reflect.Float32, reflect.Float64:
-		return "number", true
-	case reflect.String:
-		return "string", true
-	case reflect.Bool:
-		return "boolean", true
-	case reflect.Slice:
-		return "array", true
-	case reflect.Map:
-		return "object", true
-	default:
-		return "", false
-	}
-}
+// durationPattern matches the durations accepted by time.ParseDuration.
+const durationPattern = `^[-+]?([0-9]*(\.[0-9]*)?(ns|us|µs|ms|s|m|h))+$|^0$`
 
-func convertJSONValueToGoType(val interface{}, t reflect.Type) (interface{}, error) {
-	switch t.Kind() {
-	case reflect.Int:
-		if f, ok := val.(float64); ok {
-			return int(f), nil
-		}
-	case reflect.Float64:
-		if f, ok := val.(float64); ok {
-			return f, nil
-		}
-	case reflect.String:
-		if s, ok := val.(string); ok {
-			return s, nil
-		}
-	case reflect.Bool:
-		if b, ok := val.(bool); ok {
-			return b, nil
+// convertJSONValueToGoType converts a decoded JSON value to a value of type t, the type of a method parameter.
+// Values are converted with encoding/json, so any type which can be unmarshalled from JSON is supported
+// (e.g., int64, float32, uint, structs, slices and maps of structs, time.Time).
+// time.Duration values are parsed from strings with time.ParseDuration.
+func convertJSONValueToGoType(val interface{}, t reflect.Type) (reflect.Value, error) {
+	if t.Kind() == reflect.Pointer {
+		elem, err := convertJSONValueToGoType(val, t.Elem())
+		if err != nil {
+			return reflect.Value{}, err
 		}
-	case reflect.Slice:
-		if arr, ok := val.([]interface{}); ok {
-			slice := reflect.MakeSlice(t, len(arr), len(arr))
-			for i, v := range arr {
-				elem, err := convertJSONValueToGoType(v, t.Elem())
-				if err != nil {
-					return nil, err
-				}
-				slice.Index(i).Set(reflect.ValueOf(elem))
-			}
-			return slice.Interface(), nil
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, nil
+	}
+	if t == durationType {
+		s, ok := val.(string)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("cannot convert %v to duration: expected a string such as \"1h30m\"", val)
 		}
-	case reflect.Map:
-		if m, ok := val.(map[string]interface{}); ok {
-			mapType := reflect.MapOf(reflect.TypeOf(""), t.Elem())
-			newMap := reflect.MakeMap(mapType)
-			for k, v := range m {
-				elem, err := convertJSONValueToGoType(v, t.Elem())
-				if err != nil {
-					return nil, err
-				}
-				newMap.SetMapIndex(reflect.ValueOf(k), reflect.ValueOf(elem))
-			}
-			return newMap.Interface(), nil
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return reflect.Value{}, err
 		}
-	default:
-		return nil, fmt.Errorf("unsupported type: %v", t)
+		return reflect.ValueOf(d), nil
+	}
+
+	encoded, err := json.Marshal(val)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	converted := reflect.New(t)
+	if err := json.Unmarshal(encoded, converted.Interface()); err != nil {
+		return reflect.Value{}, fmt.Errorf("cannot convert %s to %v", encoded, t)
 	}
-	return nil, fmt.Errorf("cannot convert %v to %v", val, t)
+	return converted.Elem(), nil
 }
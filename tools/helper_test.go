@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -267,3 +269,192 @@ func TestCreateToolFromMethod(t *testing.T) {
 		assert.Error(t, err) // Verify error for method with no doc comments
 	})
 }
+
+// Address is a struct parameter type for testing RichToolkit.
+type Address struct {
+	Street string `json:"street" description:"Street name and number"`
+	City   string `json:"city"`
+	Zip    string `json:"zip,omitempty"`
+}
+
+// Unit is a named string type for testing RichToolkit.
+type Unit string
+
+// RichToolkit is a sample toolkit for testing the parameter types supported by CreateToolFromMethod.
+type RichToolkit struct{}
+
+// SaveAddress saves an address.
+// @param address: The address to save
+func (r *RichToolkit) SaveAddress(ctx context.Context, address Address) string {
+	return address.Street + ", " + address.City
+}
+
+// Greet greets someone, optionally by title.
+// @param name: Name of the person
+// @param title: Title of the person
+func (r *RichToolkit) Greet(ctx context.Context, name string, title *string) string {
+	if title == nil {
+		return "Hello, " + name
+	}
+	return "Hello, " + *title + " " + name
+}
+
+// CountCities counts the distinct cities of the addresses.
+// @param addresses: The addresses
+// @param [optional] byName: Addresses by person name
+func (r *RichToolkit) CountCities(ctx context.Context, addresses []Address, byName map[string]Address) int {
+	cities := make(map[string]bool)
+	for _, a := range addresses {
+		cities[a.City] = true
+	}
+	for _, a := range byName {
+		cities[a.City] = true
+	}
+	return len(cities)
+}
+
+// Schedule schedules an event.
+// @param start: Start time of the event
+// @param duration: Duration of the event
+func (r *RichToolkit) Schedule(ctx context.Context, start time.Time, duration time.Duration) string {
+	return start.Add(duration).UTC().Format(time.RFC3339)
+}
+
+// Convert converts a temperature.
+// @param value: The temperature
+// @param unit: The unit to convert to
+// @enum unit: celsius, fahrenheit
+func (r *RichToolkit) Convert(ctx context.Context, value float32, unit Unit) string {
+	return fmt.Sprintf("%.1f %s", value, unit)
+}
+
+// Numbers sums numbers of various types.
+// @param a: A 64-bit integer
+// @param b: An unsigned integer
+// @param c: An 8-bit integer
+// @param [optional] d: An optional 16-bit unsigned integer
+func (r *RichToolkit) Numbers(ctx context.Context, a int64, b uint, c int8, d uint16) int64 {
+	return a + int64(b) + int64(c) + int64(d)
+}
+
+// BadEnum has an enum on a non-string parameter.
+// @param n: A number
+// @enum n: 1, 2
+func (r *RichToolkit) BadEnum(ctx context.Context, n int) int {
+	return n
+}
+
+// BadType has a parameter which cannot be represented in JSON.
+// @param ch: A channel
+func (r *RichToolkit) BadType(ctx context.Context, ch chan int) int {
+	return 0
+}
+
+func TestCreateToolFromMethodSchemas(t *testing.T) {
+	tests := []struct {
+		method     string
+		properties string
+		required   []string
+		wantErr    string
+	}{
+		{
+			method: "SaveAddress",
+			properties: `{"address": {"type": "object", "description": "The address to save", "required": ["street", "city"], "properties": {
+				"street": {"type": "string", "description": "Street name and number"},
+				"city": {"type": "string"},
+				"zip": {"type": "string"}}}}`,
+			required: []string{"address"},
+		},
+		{
+			method:     "Greet",
+			properties: `{"name": {"type": "string", "description": "Name of the person"}, "title": {"type": "string", "description": "Title of the person"}}`,
+			required:   []string{"name"},
+		},
+		{
+			method: "CountCities",
+			properties: `{
+				"addresses": {"type": "array", "description": "The addresses", "items": {"type": "object", "required": ["street", "city"], "properties": {
+					"street": {"type": "string", "description": "Street name and number"}, "city": {"type": "string"}, "zip": {"type": "string"}}}},
+				"byName": {"type": "object", "description": "Addresses by person name", "additionalProperties": {"type": "object", "required": ["street", "city"], "properties": {
+					"street": {"type": "string", "description": "Street name and number"}, "city": {"type": "string"}, "zip": {"type": "string"}}}}}`,
+			required: []string{"addresses"},
+		},
+		{
+			method: "Schedule",
+			properties: `{
+				"start": {"type": "string", "format": "date-time", "description": "Start time of the event"},
+				"duration": {"type": "string", "pattern": "` + strings.ReplaceAll(durationPattern, `\`, `\\`) + `", "description": "Duration of the event (duration, e.g. \"1h30m\" or \"90s\")"}}`,
+			required: []string{"start", "duration"},
+		},
+		{
+			method:     "Convert",
+			properties: `{"value": {"type": "number", "description": "The temperature"}, "unit": {"type": "string", "description": "The unit to convert to", "enum": ["celsius", "fahrenheit"]}}`,
+			required:   []string{"value", "unit"},
+		},
+		{
+			method: "Numbers",
+			properties: `{"a": {"type": "integer", "description": "A 64-bit integer"}, "b": {"type": "integer", "minimum": 0, "description": "An unsigned integer"},
+				"c": {"type": "integer", "description": "An 8-bit integer"}, "d": {"type": "integer", "minimum": 0, "description": "An optional 16-bit unsigned integer"}}`,
+			required: []string{"a", "b", "c"},
+		},
+		{method: "BadEnum", wantErr: "enum is only supported for string"},
+		{method: "BadType", wantErr: "unsupported type: chan int"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			tool, err := CreateToolFromMethod(&RichToolkit{}, tt.method)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			properties, _ := json.Marshal(tool.Parameters["properties"])
+			assert.JSONEq(t, tt.properties, string(properties))
+			assert.Equal(t, tt.required, tool.Parameters["required"])
+			_, err = CompileSchema(tool.Parameters)
+			assert.NoError(t, err, "Generated schema should compile")
+		})
+	}
+}
+
+func TestCreateToolFromMethodConversions(t *testing.T) {
+	tests := []struct {
+		method  string
+		args    string
+		want    string
+		wantErr string
+	}{
+		{method: "SaveAddress", args: `{"address": {"street": "1 Main St", "city": "Springfield"}}`, want: `"1 Main St, Springfield"`},
+		{method: "SaveAddress", args: `{"address": "1 Main St"}`, wantErr: "type conversion failed for address"},
+		{method: "Greet", args: `{"name": "Ann"}`, want: `"Hello, Ann"`},
+		{method: "Greet", args: `{"name": "Ann", "title": null}`, want: `"Hello, Ann"`},
+		{method: "Greet", args: `{"name": "Ann", "title": "Dr."}`, want: `"Hello, Dr. Ann"`},
+		{method: "CountCities", args: `{"addresses": [{"street": "a", "city": "X"}, {"street": "b", "city": "Y"}], "byName": {"ann": {"street": "c", "city": "Z"}}}`, want: `3`},
+		{method: "CountCities", args: `{"addresses": []}`, want: `0`},
+		{method: "Schedule", args: `{"start": "2024-01-01T10:00:00Z", "duration": "1h30m"}`, want: `"2024-01-01T11:30:00Z"`},
+		{method: "Schedule", args: `{"start": "2024-01-01T10:00:00+02:00", "duration": "90s"}`, want: `"2024-01-01T08:01:30Z"`},
+		{method: "Schedule", args: `{"start": "yesterday", "duration": "1h"}`, wantErr: "type conversion failed for start"},
+		{method: "Schedule", args: `{"start": "2024-01-01T10:00:00Z", "duration": 60}`, wantErr: "type conversion failed for duration"},
+		{method: "Schedule", args: `{"start": "2024-01-01T10:00:00Z", "duration": "1 hour"}`, wantErr: "type conversion failed for duration"},
+		{method: "Convert", args: `{"value": 21.5, "unit": "celsius"}`, want: `"21.5 celsius"`},
+		{method: "Numbers", args: `{"a": 10000000000, "b": 2, "c": -3}`, want: `9999999999`},
+		{method: "Numbers", args: `{"a": 1, "b": 2, "c": 3, "d": 4}`, want: `10`},
+		{method: "Numbers", args: `{"a": 1, "b": -2, "c": 3}`, wantErr: "type conversion failed for b"},
+		{method: "Numbers", args: `{"a": 1, "b": 2, "c": 300}`, wantErr: "type conversion failed for c"},
+		{method: "Numbers", args: `{"a": 1.5, "b": 2, "c": 3}`, wantErr: "type conversion failed for a"},
+		{method: "Numbers", args: `{"b": 2, "c": 3}`, wantErr: "missing required parameter: a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.args, func(t *testing.T) {
+			tool, err := CreateToolFromMethod(&RichToolkit{}, tt.method)
+			assert.NoError(t, err)
+			result, err := tool.Execute(context.Background(), tt.args)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Harsh-2909/hermes-go/models/media"
+	"github.com/Harsh-2909/hermes-go/utils"
+	"github.com/google/uuid"
+)
+
+// ImageGenerator is the model used by ImageGenerationTools to generate images.
+// It is satisfied by any models.ImageGenerator (e.g., OpenAIImageGenerator).
+type ImageGenerator interface {
+	Init()
+	GenerateImages(ctx context.Context, request media.ImageGenerationRequest) ([]*media.Image, error)
+}
+
+// ImageGenerationTools provides a tool for generating images from text prompts.
+// Generated images are added to the run's Artifacts, so they are returned in the agent's response.
+type ImageGenerationTools struct {
+	Generator       ImageGenerator // Required model used to generate images
+	OutputDirectory string         // Directory where generated images are saved. Images are not saved if empty
+	DefaultSize     string         // Size used when the model does not provide one (e.g., "1024x1024")
+	DefaultQuality  string         // Quality used when the model does not provide one (e.g., "standard")
+	MaxImages       int            // Maximum number of images per tool call. Defaults to 4
+}
+
+// Tools returns the list of tools in the toolkit.
+func (t *ImageGenerationTools) Tools() []Tool {
+	var tools []Tool
+	if generateTool, err := CreateToolFromMethod(t, "GenerateImage"); err == nil {
+		tools = append(tools, generateTool)
+	} else {
+		utils.Logger.Error("Failed to create tool", "tool", "GenerateImage", "error", err)
+	}
+	return tools
+}
+
+// GenerateImage generates images from a text description.
+// @param prompt: Detailed description of the image to generate
+// @param [optional] size: Size of the image, e.g. "1024x1024", "1792x1024" or "1024x1792"
+// @param [optional] count: Number of images to generate. Defaults to 1
+// @param [optional] quality: Quality of the image, e.g. "standard" or "hd"
+// @return Summary of the generated images, including their file paths if saved
+func (t *ImageGenerationTools) GenerateImage(ctx context.Context, prompt, size string, count int, quality string) (string, error) {
+	if t.Generator == nil {
+		return "", fmt.Errorf("no image generator configured")
+	}
+	t.Generator.Init()
+
+	maxImages := t.MaxImages
+	if maxImages <= 0 {
+		maxImages = 4
+	}
+	if count < 1 {
+		count = 1
+	}
+	if count > maxImages {
+		return "", fmt.Errorf("cannot generate more than %d images at once", maxImages)
+	}
+
+	images, err := t.Generator.GenerateImages(ctx, media.ImageGenerationRequest{
+		Prompt:  prompt,
+		Size:    utils.FirstNonEmpty(size, t.DefaultSize),
+		Count:   count,
+		Quality: utils.FirstNonEmpty(quality, t.DefaultQuality),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var paths []string
+	if t.OutputDirectory != "" {
+		for _, img := range images {
+			path, err := t.saveImage(img)
+			if err != nil {
+				return "", err
+			}
+			paths = append(paths, path)
+		}
+	}
+	AddImages(ctx, images...)
+
+	if len(paths) == 0 {
+		return fmt.Sprintf("Generated %d image(s).", len(images)), nil
+	}
+	return fmt.Sprintf("Generated %d image(s) saved to: %s", len(images), strings.Join(paths, ", ")), nil
+}
+
+// saveImage writes the image to OutputDirectory with a unique name and sets its FilePath.
+func (t *ImageGenerationTools) saveImage(img *media.Image) (string, error) {
+	base64Content, err := img.Content()
+	if err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(base64Content)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode image: %v", err)
+	}
+	if err := os.MkdirAll(t.OutputDirectory, 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %v", err)
+	}
+
+	extension := "png"
+	switch http.DetectContentType(data) {
+	case "image/jpeg":
+		extension = "jpg"
+	case "image/webp":
+		extension = "webp"
+	case "image/gif":
+		extension = "gif"
+	}
+	filePath := filepath.Join(filepath.Clean(t.OutputDirectory), fmt.Sprintf("%s.%s", uuid.New().String(), extension))
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write image: %v", err)
+	}
+	img.FilePath = filePath
+	return filePath, nil
+}
@@ -0,0 +1,106 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Harsh-2909/hermes-go/models/media"
+	"github.com/stretchr/testify/assert"
+)
+
+// pngHeader is the signature of a PNG file, used as fake image content.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+// mockImageGenerator is a fake ImageGenerator which records the last request.
+type mockImageGenerator struct {
+	isInit  bool
+	request media.ImageGenerationRequest
+	err     error
+}
+
+func (m *mockImageGenerator) Init() { m.isInit = true }
+func (m *mockImageGenerator) GenerateImages(ctx context.Context, request media.ImageGenerationRequest) ([]*media.Image, error) {
+	m.request = request
+	if m.err != nil {
+		return nil, m.err
+	}
+	images := make([]*media.Image, request.Count)
+	for i := range images {
+		images[i] = &media.Image{Base64: base64.StdEncoding.EncodeToString(pngHeader)}
+	}
+	return images, nil
+}
+
+func TestImageGenerationTools_Tools(t *testing.T) {
+	toolkit := &ImageGenerationTools{Generator: &mockImageGenerator{}}
+	tools := toolkit.Tools()
+	assert.Len(t, tools, 1)
+	assert.Equal(t, "GenerateImage", tools[0].Name)
+	assert.Equal(t, []string{"prompt"}, tools[0].Parameters["required"])
+	properties := tools[0].Parameters["properties"].(map[string]interface{})
+	assert.Equal(t, "integer", properties["count"].(map[string]interface{})["type"])
+}
+
+func TestImageGenerationTools_GenerateImage(t *testing.T) {
+	tempDir := t.TempDir()
+	generator := &mockImageGenerator{}
+	toolkit := &ImageGenerationTools{
+		Generator:       generator,
+		OutputDirectory: tempDir,
+		DefaultSize:     "1024x1024",
+		MaxImages:       2,
+	}
+
+	ctx, artifacts := WithArtifacts(context.Background())
+	result, err := toolkit.GenerateImage(ctx, "A cat in space", "", 2, "hd")
+	assert.NoError(t, err)
+	assert.True(t, generator.isInit, "Generator should be initialized")
+	assert.Equal(t, media.ImageGenerationRequest{Prompt: "A cat in space", Size: "1024x1024", Count: 2, Quality: "hd"}, generator.request)
+	assert.True(t, strings.HasPrefix(result, "Generated 2 image(s) saved to: "))
+
+	// Images are saved to the output directory and collected as artifacts
+	images := artifacts.Images()
+	assert.Len(t, images, 2)
+	for _, img := range images {
+		assert.Equal(t, tempDir, filepath.Dir(img.FilePath))
+		assert.Equal(t, ".png", filepath.Ext(img.FilePath))
+		data, err := os.ReadFile(img.FilePath)
+		assert.NoError(t, err)
+		assert.Equal(t, pngHeader, data)
+		assert.Contains(t, result, img.FilePath)
+	}
+
+	// Too many images
+	_, err = toolkit.GenerateImage(ctx, "A cat in space", "", 3, "")
+	assert.Error(t, err)
+
+	// Generator errors are returned
+	generator.err = fmt.Errorf("content policy violation")
+	_, err = toolkit.GenerateImage(ctx, "A cat in space", "", 1, "")
+	assert.EqualError(t, err, "content policy violation")
+	assert.Len(t, artifacts.Images(), 2)
+}
+
+func TestImageGenerationTools_GenerateImage_NoOutputDirectory(t *testing.T) {
+	toolkit := &ImageGenerationTools{Generator: &mockImageGenerator{}}
+	result, err := toolkit.GenerateImage(context.Background(), "A cat in space", "", 0, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "Generated 1 image(s).", result)
+
+	_, err = (&ImageGenerationTools{}).GenerateImage(context.Background(), "A cat in space", "", 1, "")
+	assert.Error(t, err, "should error without a generator")
+}
+
+func TestAddImages(t *testing.T) {
+	img := &media.Image{URL: "http://example.com/image.png"}
+	assert.False(t, AddImages(context.Background(), img), "should not add images without a collector")
+
+	ctx, artifacts := WithArtifacts(context.Background())
+	assert.True(t, AddImages(ctx, img))
+	assert.Equal(t, []*media.Image{img}, artifacts.Images())
+}
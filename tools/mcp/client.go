@@ -0,0 +1,231 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/Harsh-2909/hermes-go/utils"
+)
+
+// Client is a JSON-RPC client of an MCP server, connected through a Transport.
+// It is safe for concurrent use once connected.
+type Client struct {
+	Transport  Transport      // Required transport to the server (e.g., StdioTransport or HTTPTransport)
+	ClientInfo Implementation // Name and version sent to the server. Defaults to "hermes-go"
+
+	// OnNotification, if set, is called with the notifications sent by the server (e.g., "notifications/tools/list_changed").
+	// It is called from the goroutine reading messages, so it must not block on requests to the server.
+	OnNotification func(method string, params json.RawMessage)
+
+	// Internal fields
+
+	mu         sync.Mutex
+	nextID     int64
+	pending    map[string]chan *message // Channels waiting for the responses of requests, by request ID
+	closed     bool
+	done       chan struct{} // Closed once the connection has ended
+	serverInfo InitializeResult
+}
+
+// Connect starts the transport and performs the initialize handshake with the server.
+func (c *Client) Connect(ctx context.Context) error {
+	if c.Transport == nil {
+		return fmt.Errorf("no transport configured")
+	}
+	if c.ClientInfo.Name == "" {
+		c.ClientInfo = Implementation{Name: "hermes-go", Version: "0.1.0"}
+	}
+	if err := c.Transport.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start transport: %w", err)
+	}
+	c.pending = make(map[string]chan *message)
+	c.done = make(chan struct{})
+	go c.readLoop()
+
+	var result InitializeResult
+	err := c.Call(ctx, "initialize", InitializeParams{
+		ProtocolVersion: ProtocolVersion,
+		Capabilities:    map[string]interface{}{},
+		ClientInfo:      c.ClientInfo,
+	}, &result)
+	if err != nil {
+		c.Close()
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	if !slices.Contains(supportedProtocolVersions, result.ProtocolVersion) {
+		c.Close()
+		return fmt.Errorf("unsupported protocol version %q", result.ProtocolVersion)
+	}
+	c.serverInfo = result
+	if err := c.Notify(ctx, "notifications/initialized", nil); err != nil {
+		c.Close()
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	utils.Logger.Debug("Connected to MCP server", "server", result.ServerInfo.Name, "version", result.ServerInfo.Version, "protocol", result.ProtocolVersion)
+	return nil
+}
+
+// ServerInfo returns the result of the initialize handshake, describing the server and its capabilities.
+func (c *Client) ServerInfo() InitializeResult {
+	return c.serverInfo
+}
+
+// Call sends a request to the server and decodes its result into result, which may be nil.
+// If ctx is done before the response, the request is cancelled with a notifications/cancelled notification.
+func (c *Client) Call(ctx context.Context, method string, params interface{}, result interface{}) error {
+	c.mu.Lock()
+	if c.closed || c.pending == nil {
+		c.mu.Unlock()
+		return fmt.Errorf("client is not connected")
+	}
+	c.nextID++
+	id := strconv.FormatInt(c.nextID, 10)
+	responseCh := make(chan *message, 1)
+	c.pending[id] = responseCh
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}()
+
+	if err := c.send(ctx, json.RawMessage(id), method, params); err != nil {
+		return err
+	}
+	select {
+	case response := <-responseCh:
+		if response.Error != nil {
+			return response.Error
+		}
+		if result != nil && len(response.Result) > 0 {
+			if err := json.Unmarshal(response.Result, result); err != nil {
+				return fmt.Errorf("failed to decode %s result: %v", method, err)
+			}
+		}
+		return nil
+	case <-c.done:
+		return fmt.Errorf("connection closed")
+	case <-ctx.Done():
+		c.Notify(context.Background(), "notifications/cancelled", map[string]interface{}{
+			"requestId": json.RawMessage(id),
+			"reason":    ctx.Err().Error(),
+		})
+		return ctx.Err()
+	}
+}
+
+// Notify sends a notification to the server.
+func (c *Client) Notify(ctx context.Context, method string, params interface{}) error {
+	return c.send(ctx, nil, method, params)
+}
+
+// send encodes and sends a request, or a notification if id is nil.
+func (c *Client) send(ctx context.Context, id json.RawMessage, method string, params interface{}) error {
+	msg := message{JSONRPC: "2.0", ID: id, Method: method}
+	if params != nil {
+		encoded, err := json.Marshal(params)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s params: %v", method, err)
+		}
+		msg.Params = encoded
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %v", method, err)
+	}
+	if err := c.Transport.Send(ctx, data); err != nil {
+		return fmt.Errorf("failed to send %s: %w", method, err)
+	}
+	return nil
+}
+
+// readLoop handles the messages received from the server until the connection ends.
+func (c *Client) readLoop() {
+	defer close(c.done)
+	for data := range c.Transport.Messages() {
+		var msg message
+		if err := json.Unmarshal(data, &msg); err != nil {
+			utils.Logger.Warn("Invalid message from MCP server", "error", err)
+			continue
+		}
+		switch {
+		case msg.isRequest():
+			go c.handleRequest(&msg)
+		case msg.isNotification():
+			if c.OnNotification != nil {
+				c.OnNotification(msg.Method, msg.Params)
+			}
+		case len(msg.ID) > 0:
+			// The request is no longer pending once answered, so that a duplicate response can't block on its channel
+			c.mu.Lock()
+			responseCh, ok := c.pending[string(msg.ID)]
+			delete(c.pending, string(msg.ID))
+			c.mu.Unlock()
+			if ok {
+				responseCh <- &msg
+			}
+		}
+	}
+}
+
+// handleRequest replies to a request from the server. Only ping is supported.
+func (c *Client) handleRequest(request *message) {
+	response := message{JSONRPC: "2.0", ID: request.ID}
+	if request.Method == "ping" {
+		response.Result = json.RawMessage("{}")
+	} else {
+		response.Error = &RPCError{Code: CodeMethodNotFound, Message: "method not found: " + request.Method}
+	}
+	data, err := json.Marshal(response)
+	if err != nil {
+		return
+	}
+	if err := c.Transport.Send(context.Background(), data); err != nil {
+		utils.Logger.Warn("Failed to reply to MCP server", "method", request.Method, "error", err)
+	}
+}
+
+// ListTools returns all the tools of the server, following pagination cursors.
+func (c *Client) ListTools(ctx context.Context) ([]ToolInfo, error) {
+	var tools []ToolInfo
+	params := ListToolsParams{}
+	for {
+		var result ListToolsResult
+		if err := c.Call(ctx, "tools/list", params, &result); err != nil {
+			return nil, err
+		}
+		tools = append(tools, result.Tools...)
+		if result.NextCursor == "" {
+			return tools, nil
+		}
+		params.Cursor = result.NextCursor
+	}
+}
+
+// CallTool calls a tool of the server with JSON-encoded arguments.
+// Tool failures are reported in the result with IsError, while protocol failures are returned as errors.
+func (c *Client) CallTool(ctx context.Context, name string, arguments json.RawMessage) (*CallToolResult, error) {
+	var result CallToolResult
+	if err := c.Call(ctx, "tools/call", CallToolParams{Name: name, Arguments: arguments}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Close ends the connection to the server.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	if c.closed || c.pending == nil {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	c.mu.Unlock()
+	err := c.Transport.Close()
+	<-c.done
+	return err
+}
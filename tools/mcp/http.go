@@ -0,0 +1,366 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// sessionHeader is the HTTP header carrying the MCP session ID.
+const sessionHeader = "Mcp-Session-Id"
+
+// httpStreams holds the state shared by the HTTP transports: the channel of received messages
+// and the background goroutines reading event streams, stopped on Close.
+type httpStreams struct {
+	messages chan []byte
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	mu       sync.RWMutex // Guards closing messages while messages are delivered
+	closed   bool
+}
+
+// start initializes the streams.
+func (s *httpStreams) start() {
+	s.messages = make(chan []byte, 16)
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+}
+
+// deliver passes a received message, or each message of a batch, to the messages channel.
+// It returns false if the streams are closed.
+func (s *httpStreams) deliver(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return true
+	}
+	batch := []json.RawMessage{data}
+	if data[0] == '[' {
+		if err := json.Unmarshal(data, &batch); err != nil {
+			return true
+		}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return false
+	}
+	for _, msg := range batch {
+		select {
+		case s.messages <- msg:
+		case <-s.ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
+// readEvents reads the messages of an event stream in the background until it ends or the streams are closed.
+// done is called once the stream ends.
+func (s *httpStreams) readEvents(body io.ReadCloser, done func()) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer done()
+		defer body.Close()
+		readSSE(body, func(event, data string) bool {
+			if event != "message" {
+				return true
+			}
+			return s.deliver([]byte(data))
+		})
+	}()
+}
+
+// stop stops the background goroutines and closes the messages channel.
+func (s *httpStreams) stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+	s.mu.Lock()
+	s.closed = true
+	close(s.messages)
+	s.mu.Unlock()
+	s.cancel = nil
+}
+
+// HTTPTransport connects to an MCP server with the streamable HTTP transport: messages are POSTed to the endpoint,
+// which replies with JSON or an event stream. Notifications sent by the server outside of requests are received
+// from an event stream opened with GET, if the server supports it.
+type HTTPTransport struct {
+	URL        string            // Required URL of the MCP endpoint (e.g., "http://localhost:8080/mcp")
+	Headers    map[string]string // Additional headers sent with each request (e.g., "Authorization")
+	HTTPClient *http.Client      // HTTP client used for requests. Defaults to http.DefaultClient
+
+	// Internal fields
+
+	streams   httpStreams
+	mu        sync.Mutex
+	sessionID string
+	listening bool // True once the GET event stream has been opened
+}
+
+// Start prepares the transport. The connection is established by the first message sent.
+func (t *HTTPTransport) Start(ctx context.Context) error {
+	if t.URL == "" {
+		return fmt.Errorf("no URL configured")
+	}
+	if t.HTTPClient == nil {
+		t.HTTPClient = http.DefaultClient
+	}
+	t.streams.start()
+	return nil
+}
+
+// newRequest creates a request to the endpoint with the configured headers and the session ID.
+func (t *HTTPTransport) newRequest(ctx context.Context, method string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, t.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range t.Headers {
+		req.Header.Set(key, value)
+	}
+	t.mu.Lock()
+	if t.sessionID != "" {
+		req.Header.Set(sessionHeader, t.sessionID)
+	}
+	t.mu.Unlock()
+	return req, nil
+}
+
+// Send POSTs a message to the endpoint. Responses in an event stream are read in the background.
+func (t *HTTPTransport) Send(ctx context.Context, message []byte) error {
+	if t.streams.messages == nil {
+		return fmt.Errorf("transport not started")
+	}
+	req, err := t.newRequest(ctx, http.MethodPost, message)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+
+	// The response may be an event stream read in the background, which is closed when ctx is done or on Close
+	reqCtx, cancel := context.WithCancel(t.streams.ctx)
+	stop := context.AfterFunc(ctx, cancel)
+	done := func() {
+		stop()
+		cancel()
+	}
+	resp, err := t.HTTPClient.Do(req.WithContext(reqCtx))
+	if err != nil {
+		done()
+		return fmt.Errorf("failed to send message: %v", err)
+	}
+	if sessionID := resp.Header.Get(sessionHeader); sessionID != "" {
+		t.mu.Lock()
+		t.sessionID = sessionID
+		t.mu.Unlock()
+	}
+	if resp.StatusCode >= 300 {
+		defer done()
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("server returned %s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch {
+	case resp.StatusCode == http.StatusAccepted:
+		resp.Body.Close()
+		done()
+	case mediaType == "text/event-stream":
+		t.streams.readEvents(resp.Body, done)
+	default:
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		done()
+		if err != nil {
+			return fmt.Errorf("failed to read response: %v", err)
+		}
+		t.streams.deliver(body)
+	}
+	t.listen()
+	return nil
+}
+
+// listen opens the GET event stream for server notifications once a session is established.
+// Servers which do not support it reply with 405 Method Not Allowed, which is ignored.
+func (t *HTTPTransport) listen() {
+	t.mu.Lock()
+	if t.listening || t.sessionID == "" {
+		t.mu.Unlock()
+		return
+	}
+	t.listening = true
+	t.mu.Unlock()
+
+	req, err := t.newRequest(t.streams.ctx, http.MethodGet, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	t.streams.wg.Add(1)
+	go func() {
+		defer t.streams.wg.Done()
+		resp, err := t.HTTPClient.Do(req)
+		if err != nil {
+			return
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return
+		}
+		t.streams.readEvents(resp.Body, func() {})
+	}()
+}
+
+// Messages returns the messages received from the server.
+func (t *HTTPTransport) Messages() <-chan []byte {
+	return t.streams.messages
+}
+
+// Close terminates the session with a DELETE request and stops reading event streams.
+func (t *HTTPTransport) Close() error {
+	t.mu.Lock()
+	sessionID := t.sessionID
+	t.mu.Unlock()
+	if sessionID != "" {
+		if req, err := t.newRequest(context.Background(), http.MethodDelete, nil); err == nil {
+			if resp, err := t.HTTPClient.Do(req); err == nil {
+				resp.Body.Close()
+			}
+		}
+	}
+	t.streams.stop()
+	return nil
+}
+
+// SSETransport connects to an MCP server with the HTTP+SSE transport of protocol version 2024-11-05:
+// messages from the server are received from an event stream opened with GET, which first sends the
+// endpoint to which the client POSTs its messages.
+type SSETransport struct {
+	URL        string            // Required URL of the event stream (e.g., "http://localhost:8080/sse")
+	Headers    map[string]string // Additional headers sent with each request (e.g., "Authorization")
+	HTTPClient *http.Client      // HTTP client used for requests. Defaults to http.DefaultClient
+
+	// Internal fields
+
+	streams  httpStreams
+	endpoint string
+}
+
+// Start opens the event stream and waits for the endpoint event.
+func (t *SSETransport) Start(ctx context.Context) error {
+	if t.URL == "" {
+		return fmt.Errorf("no URL configured")
+	}
+	if t.HTTPClient == nil {
+		t.HTTPClient = http.DefaultClient
+	}
+	t.streams.start()
+
+	req, err := http.NewRequestWithContext(t.streams.ctx, http.MethodGet, t.URL, nil)
+	if err != nil {
+		t.streams.stop()
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	for key, value := range t.Headers {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := t.HTTPClient.Do(req)
+	if err != nil {
+		t.streams.stop()
+		return fmt.Errorf("failed to open event stream: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		t.streams.stop()
+		return fmt.Errorf("server returned %s", resp.Status)
+	}
+
+	endpoint := make(chan string, 1)
+	t.streams.wg.Add(1)
+	go func() {
+		defer t.streams.wg.Done()
+		defer resp.Body.Close()
+		readSSE(resp.Body, func(event, data string) bool {
+			switch event {
+			case "endpoint":
+				select {
+				case endpoint <- data:
+				default:
+				}
+				return true
+			case "message":
+				return t.streams.deliver([]byte(data))
+			}
+			return true
+		})
+		close(endpoint)
+	}()
+
+	select {
+	case data, ok := <-endpoint:
+		if !ok {
+			t.streams.stop()
+			return fmt.Errorf("event stream closed before the endpoint event")
+		}
+		base, _ := url.Parse(t.URL)
+		ref, err := url.Parse(data)
+		if err != nil {
+			t.streams.stop()
+			return fmt.Errorf("invalid endpoint %q: %v", data, err)
+		}
+		t.endpoint = base.ResolveReference(ref).String()
+		return nil
+	case <-ctx.Done():
+		t.streams.stop()
+		return ctx.Err()
+	}
+}
+
+// Send POSTs a message to the endpoint. The response is received from the event stream.
+func (t *SSETransport) Send(ctx context.Context, message []byte) error {
+	if t.endpoint == "" {
+		return fmt.Errorf("transport not started")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(message))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	for key, value := range t.Headers {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := t.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send message: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("server returned %s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+	return nil
+}
+
+// Messages returns the messages received from the event stream.
+func (t *SSETransport) Messages() <-chan []byte {
+	return t.streams.messages
+}
+
+// Close closes the event stream.
+func (t *SSETransport) Close() error {
+	t.streams.stop()
+	return nil
+}
@@ -0,0 +1,136 @@
+// Package mcp connects hermes-go tools to the Model Context Protocol (https://modelcontextprotocol.io).
+// ToolKit uses the tools of an MCP server as agent tools, over stdio or HTTP,
+// and Server publishes hermes-go toolkits to MCP clients.
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ProtocolVersion is the latest MCP protocol version supported, sent when initializing a connection.
+const ProtocolVersion = "2025-06-18"
+
+// supportedProtocolVersions lists the protocol versions which can be negotiated, from newest to oldest.
+var supportedProtocolVersions = []string{ProtocolVersion, "2025-03-26", "2024-11-05"}
+
+// JSON-RPC error codes.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+// message is a JSON-RPC 2.0 message: a request (Method and ID), a notification (Method without ID)
+// or a response (ID with Result or Error).
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+// isRequest reports whether the message is a request expecting a response.
+func (m *message) isRequest() bool {
+	return m.Method != "" && len(m.ID) > 0
+}
+
+// isNotification reports whether the message is a notification.
+func (m *message) isNotification() bool {
+	return m.Method != "" && len(m.ID) == 0
+}
+
+// RPCError is a JSON-RPC error returned by an MCP server.
+type RPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// Error implements the error interface.
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("MCP error %d: %s", e.Code, e.Message)
+}
+
+// Implementation identifies an MCP client or server.
+type Implementation struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// InitializeParams are the parameters of the initialize request sent by the client.
+type InitializeParams struct {
+	ProtocolVersion string                 `json:"protocolVersion"`
+	Capabilities    map[string]interface{} `json:"capabilities"`
+	ClientInfo      Implementation         `json:"clientInfo"`
+}
+
+// InitializeResult is the result of the initialize request, describing the server.
+type InitializeResult struct {
+	ProtocolVersion string                 `json:"protocolVersion"`
+	Capabilities    map[string]interface{} `json:"capabilities"`
+	ServerInfo      Implementation         `json:"serverInfo"`
+	Instructions    string                 `json:"instructions,omitempty"`
+}
+
+// ToolInfo describes a tool of an MCP server.
+type ToolInfo struct {
+	Name        string                 `json:"name"`
+	Title       string                 `json:"title,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	InputSchema map[string]interface{} `json:"inputSchema"`
+}
+
+// ListToolsParams are the parameters of the tools/list request.
+type ListToolsParams struct {
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// ListToolsResult is the result of the tools/list request. NextCursor is set if there are more tools to list.
+type ListToolsResult struct {
+	Tools      []ToolInfo `json:"tools"`
+	NextCursor string     `json:"nextCursor,omitempty"`
+}
+
+// CallToolParams are the parameters of the tools/call request.
+type CallToolParams struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+// CallToolResult is the result of the tools/call request.
+// IsError is true when the tool failed, in which case Content describes the error.
+type CallToolResult struct {
+	Content           []Content       `json:"content"`
+	StructuredContent json.RawMessage `json:"structuredContent,omitempty"`
+	IsError           bool            `json:"isError,omitempty"`
+}
+
+// Content is an item of the content of a tool result.
+// Type is "text", "image", "audio", "resource" (embedded resource) or "resource_link".
+type Content struct {
+	Type     string    `json:"type"`
+	Text     string    `json:"text,omitempty"`     // Text of "text" content
+	Data     string    `json:"data,omitempty"`     // Base64-encoded data of "image" and "audio" content
+	MimeType string    `json:"mimeType,omitempty"` // MIME type of "image", "audio" and "resource_link" content
+	URI      string    `json:"uri,omitempty"`      // URI of "resource_link" content
+	Name     string    `json:"name,omitempty"`     // Name of "resource_link" content
+	Resource *Resource `json:"resource,omitempty"` // Embedded resource of "resource" content
+}
+
+// Resource is a resource embedded in a tool result, with either text or base64-encoded binary content.
+type Resource struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Blob     string `json:"blob,omitempty"`
+}
+
+// TextContent returns a "text" Content.
+func TextContent(text string) Content {
+	return Content{Type: "text", Text: text}
+}
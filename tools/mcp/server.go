@@ -0,0 +1,413 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Harsh-2909/hermes-go/tools"
+	"github.com/Harsh-2909/hermes-go/utils"
+)
+
+// Server publishes the tools of hermes-go toolkits to MCP clients, over stdio with ServeStdio
+// or over the streamable HTTP transport as an http.Handler.
+// Tool failures, including invalid arguments, timeouts and panics, are returned as tool results with isError,
+// so that the model using the tools can react to them.
+//
+// Example:
+//
+//	server := &mcp.Server{ToolKits: []tools.ToolKit{&tools.CalculatorTools{EnableAll: true}}}
+//	http.Handle("/mcp", server)
+type Server struct {
+	ToolKits     []tools.ToolKit // Toolkits whose tools are served
+	Info         Implementation  // Name and version sent to clients. Defaults to "hermes-go"
+	Instructions string          // Optional instructions describing how to use the server, sent to clients
+	ToolTimeout  time.Duration   // Default maximum duration of a tool call, for tools without Timeout; 0 means no timeout
+
+	// Internal fields
+
+	mu      sync.Mutex
+	tools   []tools.Tool
+	schemas map[string]*tools.Schema
+	version uint64
+	loaded  bool
+}
+
+// connectionKey is the context key of the connection of a message.
+type connectionKey struct{}
+
+// connection keeps the cancel functions of the requests in progress on a connection, by request ID,
+// as request IDs are only unique per connection.
+type connection struct {
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+}
+
+// loadTools returns the tools of the toolkits, reloading them when a tools.DynamicToolKit changed.
+func (s *Server) loadTools() ([]tools.Tool, map[string]*tools.Schema) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var version uint64
+	for _, toolkit := range s.ToolKits {
+		if dynamic, ok := toolkit.(tools.DynamicToolKit); ok {
+			version += dynamic.ToolsVersion()
+		}
+	}
+	if s.loaded && version == s.version {
+		return s.tools, s.schemas
+	}
+	s.tools = nil
+	s.schemas = make(map[string]*tools.Schema)
+	for _, toolkit := range s.ToolKits {
+		for _, tool := range toolkit.Tools() {
+			s.tools = append(s.tools, tool)
+			if tool.Parameters == nil {
+				continue
+			}
+			if schema, err := tools.CompileSchema(tool.Parameters); err == nil {
+				s.schemas[tool.Name] = schema
+			} else {
+				utils.Logger.Warn("Invalid tool parameters schema; arguments will not be validated", "tool", tool.Name, "error", err)
+			}
+		}
+	}
+	s.version = version
+	s.loaded = true
+	return s.tools, s.schemas
+}
+
+// HandleMessage handles a JSON-RPC message, or a batch of messages, and returns the response to send back.
+// It returns nil if there is no response, i.e. for notifications.
+// Cancellation notifications only apply to requests of the same Serve connection, and are ignored otherwise.
+func (s *Server) HandleMessage(ctx context.Context, data []byte) []byte {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var batch []json.RawMessage
+		if err := json.Unmarshal(data, &batch); err != nil {
+			return encodeResponse(errorResponse(nil, CodeParseError, "parse error: "+err.Error()))
+		}
+		var responses []json.RawMessage
+		for _, item := range batch {
+			if response := s.handle(ctx, item); response != nil {
+				responses = append(responses, encodeResponse(response))
+			}
+		}
+		if len(responses) == 0 {
+			return nil
+		}
+		encoded, _ := json.Marshal(responses)
+		return encoded
+	}
+	if response := s.handle(ctx, data); response != nil {
+		return encodeResponse(response)
+	}
+	return nil
+}
+
+// handle handles a single message and returns its response, or nil for notifications.
+func (s *Server) handle(ctx context.Context, data []byte) *message {
+	var msg message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return errorResponse(nil, CodeParseError, "parse error: "+err.Error())
+	}
+	if msg.isNotification() {
+		if msg.Method == "notifications/cancelled" {
+			var params struct {
+				RequestID json.RawMessage `json:"requestId"`
+			}
+			if conn, ok := ctx.Value(connectionKey{}).(*connection); ok && json.Unmarshal(msg.Params, &params) == nil {
+				conn.cancel(string(params.RequestID))
+			}
+		}
+		return nil
+	}
+	if !msg.isRequest() {
+		// Responses are not expected, as the server sends no requests
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if conn, ok := ctx.Value(connectionKey{}).(*connection); ok {
+		if !conn.track(string(msg.ID), cancel) {
+			return errorResponse(msg.ID, CodeInvalidRequest, "request ID "+string(msg.ID)+" is already in progress")
+		}
+		defer conn.untrack(string(msg.ID))
+	}
+
+	var result interface{}
+	var err error
+	switch msg.Method {
+	case "initialize":
+		result, err = s.initialize(msg.Params)
+	case "ping":
+		result = struct{}{}
+	case "tools/list":
+		result = s.listTools()
+	case "tools/call":
+		result, err = s.callTool(ctx, msg.Params)
+	default:
+		return errorResponse(msg.ID, CodeMethodNotFound, "method not found: "+msg.Method)
+	}
+	if err != nil {
+		var rpcErr *RPCError
+		if errors.As(err, &rpcErr) {
+			return &message{JSONRPC: "2.0", ID: msg.ID, Error: rpcErr}
+		}
+		return errorResponse(msg.ID, CodeInternalError, err.Error())
+	}
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return errorResponse(msg.ID, CodeInternalError, "failed to encode result: "+err.Error())
+	}
+	return &message{JSONRPC: "2.0", ID: msg.ID, Result: encoded}
+}
+
+// track registers the cancel function of a request in progress.
+// It returns false if a request with the same ID is already in progress.
+func (c *connection) track(id string, cancel context.CancelFunc) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cancels == nil {
+		c.cancels = make(map[string]context.CancelFunc)
+	}
+	if _, ok := c.cancels[id]; ok {
+		return false
+	}
+	c.cancels[id] = cancel
+	return true
+}
+
+// untrack removes a request that is no longer in progress.
+func (c *connection) untrack(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.cancels, id)
+}
+
+// cancel cancels a request in progress.
+func (c *connection) cancel(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cancel, ok := c.cancels[id]; ok {
+		cancel()
+	}
+}
+
+// initialize negotiates the protocol version: the client's version if supported, the latest version otherwise.
+func (s *Server) initialize(params json.RawMessage) (InitializeResult, error) {
+	var initParams InitializeParams
+	if err := json.Unmarshal(params, &initParams); err != nil {
+		return InitializeResult{}, &RPCError{Code: CodeInvalidParams, Message: "invalid params: " + err.Error()}
+	}
+	version := ProtocolVersion
+	if slices.Contains(supportedProtocolVersions, initParams.ProtocolVersion) {
+		version = initParams.ProtocolVersion
+	}
+	info := s.Info
+	if info.Name == "" {
+		info = Implementation{Name: "hermes-go", Version: "0.1.0"}
+	}
+	utils.Logger.Debug("MCP client connected", "client", initParams.ClientInfo.Name, "protocol", version)
+	return InitializeResult{
+		ProtocolVersion: version,
+		Capabilities:    map[string]interface{}{"tools": map[string]interface{}{"listChanged": false}},
+		ServerInfo:      info,
+		Instructions:    s.Instructions,
+	}, nil
+}
+
+// listTools returns the descriptions of the tools.
+func (s *Server) listTools() ListToolsResult {
+	toolList, _ := s.loadTools()
+	infos := make([]ToolInfo, 0, len(toolList))
+	for _, tool := range toolList {
+		schema := tool.Parameters
+		if schema == nil {
+			schema = map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+		}
+		infos = append(infos, ToolInfo{Name: tool.Name, Description: tool.Description, InputSchema: schema})
+	}
+	return ListToolsResult{Tools: infos}
+}
+
+// callTool executes a tool. Unknown tools are protocol errors, while tool failures are results with IsError.
+func (s *Server) callTool(ctx context.Context, params json.RawMessage) (*CallToolResult, error) {
+	var callParams CallToolParams
+	if err := json.Unmarshal(params, &callParams); err != nil {
+		return nil, &RPCError{Code: CodeInvalidParams, Message: "invalid params: " + err.Error()}
+	}
+	toolList, schemas := s.loadTools()
+	index := slices.IndexFunc(toolList, func(tool tools.Tool) bool { return tool.Name == callParams.Name })
+	if index < 0 {
+		return nil, &RPCError{Code: CodeInvalidParams, Message: "unknown tool: " + callParams.Name}
+	}
+	tool := toolList[index]
+
+	args := string(callParams.Arguments)
+	if len(bytes.TrimSpace(callParams.Arguments)) == 0 || string(callParams.Arguments) == "null" {
+		args = "{}"
+	}
+	if schema, ok := schemas[tool.Name]; ok {
+		if err := schema.ValidateJSON(args); err != nil {
+			var validationErr *tools.ValidationError
+			if errors.As(err, &validationErr) {
+				validationErr.Tool = tool.Name
+			}
+			return errorResult(err), nil
+		}
+	}
+
+	ctx, artifacts := tools.WithArtifacts(ctx)
+	utils.Logger.Debug("Executing tool", "name", tool.Name)
+	output, err := tool.Call(ctx, args, s.ToolTimeout)
+	if err != nil {
+		utils.Logger.Error("Tool execution failed", "name", tool.Name, "error", err)
+		return errorResult(err), nil
+	}
+	result := &CallToolResult{Content: []Content{TextContent(output)}}
+	for _, image := range artifacts.Images() {
+		data, err := image.Content()
+		if err != nil {
+			utils.Logger.Warn("Failed to read tool image", "name", tool.Name, "error", err)
+			continue
+		}
+		mimeType, _ := image.GetMediaType()
+		result.Content = append(result.Content, Content{Type: "image", Data: data, MimeType: mimeType})
+	}
+	return result, nil
+}
+
+// errorResult returns the result of a failed tool call.
+func errorResult(err error) *CallToolResult {
+	return &CallToolResult{Content: []Content{TextContent(err.Error())}, IsError: true}
+}
+
+// errorResponse returns a JSON-RPC error response.
+func errorResponse(id json.RawMessage, code int, text string) *message {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &message{JSONRPC: "2.0", ID: id, Error: &RPCError{Code: code, Message: text}}
+}
+
+// encodeResponse encodes a response message.
+func encodeResponse(response *message) []byte {
+	encoded, _ := json.Marshal(response)
+	return encoded
+}
+
+// ServeStdio serves the tools over the stdin and stdout of the process until stdin is closed or ctx is done.
+// Nothing else may be written to stdout, so the logger should write to stderr, e.g. with
+// `utils.DefaultLogger.Writer = os.Stderr`.
+func (s *Server) ServeStdio(ctx context.Context) error {
+	return s.Serve(ctx, os.Stdin, os.Stdout)
+}
+
+// Serve serves the tools over newline-delimited JSON-RPC messages read from r, writing responses to w,
+// until r is closed or ctx is done. Requests are handled concurrently, so that slow tools do not block others.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ctx = context.WithValue(ctx, connectionKey{}, &connection{})
+
+	var writeMu sync.Mutex
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	lines := make(chan []byte)
+	readErr := make(chan error, 1)
+	go func() {
+		reader := bufio.NewReader(r)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if line = bytes.TrimSpace(line); len(line) > 0 {
+				select {
+				case lines <- line:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				if err == io.EOF {
+					err = nil
+				}
+				readErr <- err
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case line := <-lines:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				response := s.HandleMessage(ctx, line)
+				if response == nil {
+					return
+				}
+				writeMu.Lock()
+				defer writeMu.Unlock()
+				if _, err := w.Write(append(response, '\n')); err != nil {
+					utils.Logger.Error("Failed to write MCP response", "error", err)
+				}
+			}()
+		case err := <-readErr:
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// ServeHTTP serves the tools with the streamable HTTP transport. The server is stateless: each POSTed message is
+// answered with a JSON response, and GET requests for server-sent events are not supported.
+// Cancellation notifications are ignored, as clients cancel a request by closing its HTTP request.
+// Requests from browsers on other origins are rejected, to prevent DNS rebinding attacks on local servers.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if origin := r.Header.Get("Origin"); origin != "" {
+		if u, err := url.Parse(origin); err != nil || u.Host != r.Host {
+			http.Error(w, "origin not allowed", http.StatusForbidden)
+			return
+		}
+	}
+	switch r.Method {
+	case http.MethodPost:
+	case http.MethodDelete:
+		w.WriteHeader(http.StatusOK)
+		return
+	default:
+		w.Header().Set("Allow", "POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+		http.Error(w, "content type must be application/json", http.StatusUnsupportedMediaType)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, 10<<20))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read request: %v", err), http.StatusBadRequest)
+		return
+	}
+	response := s.HandleMessage(r.Context(), body)
+	if response == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response)
+}
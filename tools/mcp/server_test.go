@@ -0,0 +1,205 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Harsh-2909/hermes-go/models/media"
+	"github.com/Harsh-2909/hermes-go/tools"
+	"github.com/stretchr/testify/assert"
+)
+
+// pngBase64 is a 1x1 PNG image.
+const pngBase64 = "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNk+M9QDwADhgGAWjR9awAAAABJRU5ErkJggg=="
+
+// newTestServer returns a Server with calculator tools and tools testing failures and images.
+func newTestServer() *Server {
+	failTool := tools.NewTool("fail", "Always fails", nil, func(ctx context.Context, args string) (string, error) {
+		return "", errors.New("something went wrong")
+	})
+	panicTool := tools.NewTool("panic", "Panics", nil, func(ctx context.Context, args string) (string, error) {
+		panic("boom")
+	})
+	slowTool := tools.NewTool("slow", "Waits until cancelled", nil, func(ctx context.Context, args string) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	imageTool := tools.NewTool("image", "Returns an image", nil, func(ctx context.Context, args string) (string, error) {
+		tools.AddImages(ctx, &media.Image{Base64: pngBase64})
+		return "A red dot", nil
+	})
+	return &Server{
+		ToolKits:    []tools.ToolKit{&tools.CalculatorTools{EnableAll: true}, failTool, panicTool, slowTool, imageTool},
+		Info:        Implementation{Name: "test", Version: "1.0.0"},
+		ToolTimeout: time.Second,
+	}
+}
+
+// testServerTools checks the tools of newTestServer through a client connected with transport.
+func testServerTools(t *testing.T, transport Transport) {
+	client := &Client{Transport: transport}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if !assert.NoError(t, client.Connect(ctx)) {
+		return
+	}
+	defer client.Close()
+	assert.Equal(t, "test", client.ServerInfo().ServerInfo.Name)
+
+	toolList, err := client.ListTools(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, toolList, 16)
+	assert.Equal(t, "Add", toolList[0].Name)
+	assert.Equal(t, []interface{}{"a", "b"}, toolList[0].InputSchema["required"])
+
+	result, err := client.CallTool(ctx, "Add", []byte(`{"a": 2, "b": 3}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &CallToolResult{Content: []Content{TextContent("5")}}, result)
+
+	// Tool failures are results with isError
+	result, err = client.CallTool(ctx, "fail", []byte(`{}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &CallToolResult{Content: []Content{TextContent("something went wrong")}, IsError: true}, result)
+
+	result, err = client.CallTool(ctx, "Add", []byte(`{"a": "two"}`))
+	assert.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "invalid arguments for tool Add")
+
+	// Unknown tools are protocol errors
+	_, err = client.CallTool(ctx, "unknown", nil)
+	assert.EqualError(t, err, "MCP error -32602: unknown tool: unknown")
+
+	// Images added by tools are returned as image content
+	result, err = client.CallTool(ctx, "image", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []Content{TextContent("A red dot"), {Type: "image", Data: pngBase64, MimeType: "image/png"}}, result.Content)
+}
+
+func TestServerStdio(t *testing.T) {
+	testServerTools(t, &StdioTransport{Command: os.Args[0], Env: []string{"MCP_HERMES_SERVER=1"}})
+}
+
+func TestServerHTTP(t *testing.T) {
+	httpServer := httptest.NewServer(newTestServer())
+	defer httpServer.Close()
+	testServerTools(t, &HTTPTransport{URL: httpServer.URL})
+
+	resp, err := http.Get(httpServer.URL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	req, _ := http.NewRequest(http.MethodPost, httpServer.URL, strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Origin", "http://evil.example.com")
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, "Requests from other origins should be rejected")
+}
+
+func TestServerHandleMessage(t *testing.T) {
+	server := newTestServer()
+	ctx := context.Background()
+	testCases := []struct {
+		name     string
+		message  string
+		expected string
+	}{
+		{"Parse error", `{invalid`, `{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"parse error: invalid character 'i' looking for beginning of object key string"}}`},
+		{"Unknown method", `{"jsonrpc":"2.0","id":1,"method":"prompts/list"}`, `{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found: prompts/list"}}`},
+		{"Notification", `{"jsonrpc":"2.0","method":"notifications/initialized"}`, ``},
+		{"Ping", `{"jsonrpc":"2.0","id":"a","method":"ping"}`, `{"jsonrpc":"2.0","id":"a","result":{}}`},
+		{"Batch", `[{"jsonrpc":"2.0","id":1,"method":"ping"},{"jsonrpc":"2.0","method":"notifications/initialized"},{"jsonrpc":"2.0","id":2,"method":"ping"}]`,
+			`[{"jsonrpc":"2.0","id":1,"result":{}},{"jsonrpc":"2.0","id":2,"result":{}}]`},
+		{"Older protocol version", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","clientInfo":{"name":"old"}}}`,
+			`{"jsonrpc":"2.0","id":1,"result":{"protocolVersion":"2024-11-05","capabilities":{"tools":{"listChanged":false}},"serverInfo":{"name":"test","version":"1.0.0"}}}`},
+		{"Unknown protocol version", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"1999-01-01"}}`,
+			`{"jsonrpc":"2.0","id":1,"result":{"protocolVersion":"` + ProtocolVersion + `","capabilities":{"tools":{"listChanged":false}},"serverInfo":{"name":"test","version":"1.0.0"}}}`},
+		{"Panic", `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"panic"}}`,
+			`{"jsonrpc":"2.0","id":1,"result":{"content":[{"type":"text","text":"tool panic panicked: boom"}],"isError":true}}`},
+		{"Timeout", `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"slow","arguments":{}}}`,
+			`{"jsonrpc":"2.0","id":1,"result":{"content":[{"type":"text","text":"tool slow timed out after 1s: context deadline exceeded"}],"isError":true}}`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, string(server.HandleMessage(ctx, []byte(tc.message))))
+		})
+	}
+}
+
+// serveConnection serves a connection to server until ctx is done,
+// and returns functions to send a message and to receive the next response within a timeout.
+func serveConnection(ctx context.Context, server *Server) (func(string), func(time.Duration) *message) {
+	requestReader, requestWriter := io.Pipe()
+	responseReader, responseWriter := io.Pipe()
+	go server.Serve(ctx, requestReader, responseWriter)
+	context.AfterFunc(ctx, func() { requestWriter.Close(); responseReader.Close() })
+
+	responses := make(chan *message, 10)
+	go func() {
+		scanner := bufio.NewScanner(responseReader)
+		for scanner.Scan() {
+			var msg message
+			json.Unmarshal(scanner.Bytes(), &msg)
+			responses <- &msg
+		}
+	}()
+	send := func(data string) { requestWriter.Write([]byte(data + "\n")) }
+	receive := func(timeout time.Duration) *message {
+		select {
+		case msg := <-responses:
+			return msg
+		case <-time.After(timeout):
+			return nil
+		}
+	}
+	return send, receive
+}
+
+func TestServerCancel(t *testing.T) {
+	server := newTestServer()
+	server.ToolTimeout = time.Minute
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Request IDs are per connection, so two connections can use the same ID
+	const request = `{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"slow"}}`
+	const cancelled = `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":7}}`
+	sendA, receiveA := serveConnection(ctx, server)
+	sendB, receiveB := serveConnection(ctx, server)
+	sendA(request)
+	sendB(request)
+
+	// A request ID that is already in progress is rejected
+	sendA(request)
+	response := receiveA(5 * time.Second)
+	if assert.NotNil(t, response) && assert.NotNil(t, response.Error) {
+		assert.Equal(t, "request ID 7 is already in progress", response.Error.Message)
+	}
+
+	// Cancelling a request on one connection leaves the other one running
+	assert.Eventually(t, func() bool {
+		sendA(cancelled)
+		response = receiveA(10 * time.Millisecond)
+		return response != nil
+	}, 5*time.Second, time.Millisecond)
+	assert.Contains(t, string(response.Result), "was cancelled")
+	assert.Nil(t, receiveB(100*time.Millisecond), "The request on the other connection should not be cancelled")
+
+	sendB(cancelled)
+	response = receiveB(5 * time.Second)
+	if assert.NotNil(t, response) {
+		assert.Contains(t, string(response.Result), "was cancelled")
+	}
+}
@@ -0,0 +1,209 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Harsh-2909/hermes-go/models/media"
+	"github.com/Harsh-2909/hermes-go/tools"
+	"github.com/Harsh-2909/hermes-go/utils"
+)
+
+// ToolKit provides the tools of an MCP server as agent tools. Tool calls are proxied to the server with tools/call.
+// The list of tools is reloaded when the server sends a notifications/tools/list_changed notification,
+// and the Agent picks up the new tools before its next model call (see tools.DynamicToolKit).
+//
+// Example:
+//
+//	toolkit := &mcp.ToolKit{Transport: &mcp.StdioTransport{Command: "npx", Args: []string{"-y", "@modelcontextprotocol/server-everything"}}}
+//	if err := toolkit.Connect(ctx); err != nil {
+//		log.Fatal(err)
+//	}
+//	defer toolkit.Close()
+//	agent := agent.Agent{Model: model, Tools: []tools.ToolKit{toolkit}}
+type ToolKit struct {
+	Transport      Transport     // Required transport to the server
+	ToolPrefix     string        // Optional prefix of the tool names, to avoid conflicts between servers (e.g., "github_")
+	ConnectTimeout time.Duration // Timeout of the connection made by Tools when Connect was not called. Defaults to 30 seconds
+
+	// Internal fields
+
+	client  *Client
+	mu      sync.Mutex
+	tools   []tools.Tool
+	version atomic.Uint64
+}
+
+// Connect connects to the server and lists its tools.
+func (tk *ToolKit) Connect(ctx context.Context) error {
+	tk.mu.Lock()
+	if tk.client != nil {
+		tk.mu.Unlock()
+		return nil
+	}
+	client := &Client{Transport: tk.Transport, OnNotification: tk.handleNotification}
+	tk.client = client
+	tk.mu.Unlock()
+
+	if err := client.Connect(ctx); err != nil {
+		tk.mu.Lock()
+		tk.client = nil
+		tk.mu.Unlock()
+		return err
+	}
+	if err := tk.Refresh(ctx); err != nil {
+		// Disconnect so that Connect and Tools try again, instead of staying connected without tools
+		tk.mu.Lock()
+		if tk.client == client {
+			tk.client = nil
+		}
+		tk.mu.Unlock()
+		client.Close()
+		return err
+	}
+	return nil
+}
+
+// Refresh reloads the list of tools from the server.
+func (tk *ToolKit) Refresh(ctx context.Context) error {
+	tk.mu.Lock()
+	client := tk.client
+	tk.mu.Unlock()
+	if client == nil {
+		return fmt.Errorf("not connected")
+	}
+	infos, err := client.ListTools(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list tools: %w", err)
+	}
+	toolList := make([]tools.Tool, 0, len(infos))
+	for _, info := range infos {
+		toolList = append(toolList, tk.newTool(client, info))
+	}
+	tk.mu.Lock()
+	tk.tools = toolList
+	tk.mu.Unlock()
+	tk.version.Add(1)
+	return nil
+}
+
+// handleNotification reloads the tools when the server reports that they changed.
+func (tk *ToolKit) handleNotification(method string, params json.RawMessage) {
+	if method != "notifications/tools/list_changed" {
+		return
+	}
+	// Notifications are handled while reading messages, so the tools are listed in the background
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := tk.Refresh(ctx); err != nil {
+			utils.Logger.Error("Failed to refresh MCP tools", "error", err)
+		}
+	}()
+}
+
+// Tools returns the tools of the server, connecting first if Connect was not called.
+func (tk *ToolKit) Tools() []tools.Tool {
+	tk.mu.Lock()
+	connected := tk.client != nil
+	tk.mu.Unlock()
+	if !connected {
+		timeout := tk.ConnectTimeout
+		if timeout <= 0 {
+			timeout = 30 * time.Second
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		if err := tk.Connect(ctx); err != nil {
+			utils.Logger.Error("Failed to connect to MCP server", "error", err)
+			return nil
+		}
+	}
+	tk.mu.Lock()
+	defer tk.mu.Unlock()
+	return append([]tools.Tool(nil), tk.tools...)
+}
+
+// ToolsVersion returns a number which increases each time the list of tools is reloaded.
+func (tk *ToolKit) ToolsVersion() uint64 {
+	return tk.version.Load()
+}
+
+// Close closes the connection to the server.
+func (tk *ToolKit) Close() error {
+	tk.mu.Lock()
+	client := tk.client
+	tk.client = nil
+	tk.mu.Unlock()
+	if client == nil {
+		return nil
+	}
+	return client.Close()
+}
+
+// newTool returns the agent tool calling the tool of the server described by info.
+func (tk *ToolKit) newTool(client *Client, info ToolInfo) tools.Tool {
+	description := info.Description
+	if description == "" {
+		description = info.Title
+	}
+	parameters := info.InputSchema
+	if parameters == nil {
+		parameters = map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+	}
+	return tools.NewTool(tk.ToolPrefix+info.Name, description, parameters, func(ctx context.Context, args string) (string, error) {
+		if strings.TrimSpace(args) == "" {
+			args = "{}"
+		}
+		result, err := client.CallTool(ctx, info.Name, json.RawMessage(args))
+		if err != nil {
+			return "", err
+		}
+		return resultToString(ctx, result)
+	})
+}
+
+// resultToString converts the result of a tool call to the text returned to the model.
+// Images are added to the run's Artifacts; a result with IsError is returned as an error.
+func resultToString(ctx context.Context, result *CallToolResult) (string, error) {
+	var parts []string
+	for _, content := range result.Content {
+		switch content.Type {
+		case "text":
+			parts = append(parts, content.Text)
+		case "image":
+			tools.AddImages(ctx, &media.Image{Base64: content.Data})
+			parts = append(parts, fmt.Sprintf("[Image: %s]", content.MimeType))
+		case "audio":
+			parts = append(parts, fmt.Sprintf("[Audio: %s]", content.MimeType))
+		case "resource":
+			if content.Resource == nil {
+				continue
+			}
+			if content.Resource.Text != "" {
+				parts = append(parts, content.Resource.Text)
+			} else {
+				parts = append(parts, fmt.Sprintf("[Resource: %s]", content.Resource.URI))
+			}
+		case "resource_link":
+			parts = append(parts, fmt.Sprintf("[Resource: %s]", content.URI))
+		}
+	}
+	if len(parts) == 0 && len(result.StructuredContent) > 0 {
+		parts = append(parts, string(result.StructuredContent))
+	}
+	text := strings.Join(parts, "\n")
+	if result.IsError {
+		if text == "" {
+			text = "tool call failed"
+		}
+		return "", errors.New(text)
+	}
+	return text, nil
+}
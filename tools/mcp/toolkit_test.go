@@ -0,0 +1,235 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Harsh-2909/hermes-go/tools"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeServer is a minimal MCP server used to test the client and transports.
+// notify sends messages from the server outside of requests, and the first failList tools/list requests fail.
+type fakeServer struct {
+	mu       sync.Mutex
+	tools    []ToolInfo
+	notify   func(data []byte)
+	failList int
+}
+
+func newFakeServer() *fakeServer {
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"text": map[string]interface{}{"type": "string"}},
+		"required":   []interface{}{"text"},
+	}
+	return &fakeServer{tools: []ToolInfo{
+		{Name: "echo", Description: "Echoes the text", InputSchema: schema},
+		{Name: "image", Description: "Returns an image"},
+		{Name: "fail", Description: "Always fails"},
+		{Name: "add_tool", Description: "Adds a tool"},
+	}}
+}
+
+// handle returns the response to a message, or nil for notifications.
+func (s *fakeServer) handle(data []byte) []byte {
+	var msg message
+	if err := json.Unmarshal(data, &msg); err != nil || !msg.isRequest() {
+		return nil
+	}
+	var result interface{}
+	switch msg.Method {
+	case "initialize":
+		result = InitializeResult{
+			ProtocolVersion: ProtocolVersion,
+			Capabilities:    map[string]interface{}{"tools": map[string]interface{}{"listChanged": true}},
+			ServerInfo:      Implementation{Name: "fake", Version: "1.0.0"},
+		}
+	case "tools/list":
+		// Tools are listed one per page to test pagination
+		var params ListToolsParams
+		json.Unmarshal(msg.Params, &params)
+		s.mu.Lock()
+		if s.failList > 0 {
+			s.failList--
+			s.mu.Unlock()
+			response, _ := json.Marshal(message{JSONRPC: "2.0", ID: msg.ID, Error: &RPCError{Code: CodeInternalError, Message: "tools unavailable"}})
+			return response
+		}
+		index := 0
+		if params.Cursor != "" {
+			json.Unmarshal([]byte(params.Cursor), &index)
+		}
+		page := ListToolsResult{Tools: s.tools[index : index+1]}
+		if index+1 < len(s.tools) {
+			cursor, _ := json.Marshal(index + 1)
+			page.NextCursor = string(cursor)
+		}
+		s.mu.Unlock()
+		result = page
+	case "tools/call":
+		var params struct {
+			Name      string            `json:"name"`
+			Arguments map[string]string `json:"arguments"`
+		}
+		json.Unmarshal(msg.Params, &params)
+		switch params.Name {
+		case "echo":
+			result = CallToolResult{Content: []Content{TextContent(params.Arguments["text"])}}
+		case "image":
+			result = CallToolResult{Content: []Content{TextContent("A red dot"), {Type: "image", Data: "aW1hZ2U=", MimeType: "image/png"}}}
+		case "fail":
+			result = CallToolResult{Content: []Content{TextContent("something went wrong")}, IsError: true}
+		case "add_tool":
+			s.mu.Lock()
+			s.tools = append(s.tools, ToolInfo{Name: "new_tool", Description: "A new tool"})
+			s.mu.Unlock()
+			s.notify([]byte(`{"jsonrpc":"2.0","method":"notifications/tools/list_changed"}`))
+			result = CallToolResult{Content: []Content{TextContent("added")}}
+		default:
+			response, _ := json.Marshal(message{JSONRPC: "2.0", ID: msg.ID, Error: &RPCError{Code: CodeInvalidParams, Message: "unknown tool"}})
+			return response
+		}
+	default:
+		response, _ := json.Marshal(message{JSONRPC: "2.0", ID: msg.ID, Error: &RPCError{Code: CodeMethodNotFound, Message: "method not found"}})
+		return response
+	}
+	encoded, _ := json.Marshal(result)
+	response, _ := json.Marshal(message{JSONRPC: "2.0", ID: msg.ID, Result: encoded})
+	return response
+}
+
+// pipeTransport is an in-memory Transport connected to a fakeServer.
+// Each response is received 1 + duplicates times.
+type pipeTransport struct {
+	server     *fakeServer
+	messages   chan []byte
+	duplicates int
+}
+
+func (t *pipeTransport) Start(ctx context.Context) error {
+	t.messages = make(chan []byte, 16)
+	t.server.notify = func(data []byte) { t.messages <- data }
+	return nil
+}
+func (t *pipeTransport) Send(ctx context.Context, data []byte) error {
+	if response := t.server.handle(data); response != nil {
+		for i := 0; i <= t.duplicates; i++ {
+			t.messages <- response
+		}
+	}
+	return nil
+}
+func (t *pipeTransport) Messages() <-chan []byte { return t.messages }
+func (t *pipeTransport) Close() error {
+	close(t.messages)
+	return nil
+}
+
+// findTool returns the tool with the given name.
+func findTool(t *testing.T, toolList []tools.Tool, name string) tools.Tool {
+	for _, tool := range toolList {
+		if tool.Name == name {
+			return tool
+		}
+	}
+	t.Fatalf("tool %s not found", name)
+	return tools.Tool{}
+}
+
+func TestToolKit(t *testing.T) {
+	toolkit := &ToolKit{Transport: &pipeTransport{server: newFakeServer()}, ToolPrefix: "fake_"}
+	defer toolkit.Close()
+
+	toolList := toolkit.Tools()
+	assert.Len(t, toolList, 4, "Tools should connect and list all pages")
+	assert.Equal(t, "fake", toolkit.client.ServerInfo().ServerInfo.Name)
+	assert.Equal(t, uint64(1), toolkit.ToolsVersion())
+
+	echo := findTool(t, toolList, "fake_echo")
+	assert.Equal(t, "Echoes the text", echo.Description)
+	assert.Equal(t, []interface{}{"text"}, echo.Parameters["required"])
+	result, err := echo.Execute(context.Background(), `{"text": "hello"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", result)
+
+	// Images are returned as artifacts
+	ctx, artifacts := tools.WithArtifacts(context.Background())
+	result, err = findTool(t, toolList, "fake_image").Execute(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "A red dot\n[Image: image/png]", result)
+	assert.Len(t, artifacts.Images(), 1)
+	assert.Equal(t, "aW1hZ2U=", artifacts.Images()[0].Base64)
+
+	_, err = findTool(t, toolList, "fake_fail").Execute(context.Background(), "{}")
+	assert.EqualError(t, err, "something went wrong")
+
+	// The tools are reloaded when the server notifies that they changed
+	_, err = findTool(t, toolList, "fake_add_tool").Execute(context.Background(), "{}")
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool { return toolkit.ToolsVersion() == 2 }, time.Second, 10*time.Millisecond)
+	toolList = toolkit.Tools()
+	assert.Len(t, toolList, 5)
+	findTool(t, toolList, "fake_new_tool")
+}
+
+func TestClientDuplicateResponses(t *testing.T) {
+	client := &Client{Transport: &pipeTransport{server: newFakeServer(), duplicates: 2}}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if !assert.NoError(t, client.Connect(ctx)) {
+		return
+	}
+	defer client.Close()
+	for i := 0; i < 20; i++ {
+		result, err := client.CallTool(ctx, "echo", []byte(`{"text": "hi"}`))
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, []Content{TextContent("hi")}, result.Content)
+	}
+}
+
+func TestToolKitListError(t *testing.T) {
+	server := newFakeServer()
+	server.failList = 1
+	toolkit := &ToolKit{Transport: &pipeTransport{server: server}}
+	defer toolkit.Close()
+
+	err := toolkit.Connect(context.Background())
+	assert.EqualError(t, err, "failed to list tools: MCP error -32603: tools unavailable")
+	assert.Nil(t, toolkit.client, "The toolkit should not stay connected without tools")
+
+	// The next call connects again
+	assert.Len(t, toolkit.Tools(), 4)
+	assert.Equal(t, uint64(1), toolkit.ToolsVersion())
+}
+
+func TestToolKitConnectionError(t *testing.T) {
+	toolkit := &ToolKit{Transport: &StdioTransport{Command: "/nonexistent/mcp-server"}}
+	assert.Nil(t, toolkit.Tools())
+	assert.Error(t, toolkit.Connect(context.Background()))
+}
+
+func TestResultToString(t *testing.T) {
+	result, err := resultToString(context.Background(), &CallToolResult{
+		Content: []Content{
+			{Type: "resource", Resource: &Resource{URI: "file:///a.txt", Text: "content of a"}},
+			{Type: "resource", Resource: &Resource{URI: "file:///b.bin", Blob: "AAAA"}},
+			{Type: "resource_link", URI: "file:///c.txt"},
+			{Type: "audio", MimeType: "audio/wav"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "content of a\n[Resource: file:///b.bin]\n[Resource: file:///c.txt]\n[Audio: audio/wav]", result)
+
+	result, err = resultToString(context.Background(), &CallToolResult{StructuredContent: json.RawMessage(`{"temperature":21}`)})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"temperature":21}`, result)
+
+	_, err = resultToString(context.Background(), &CallToolResult{IsError: true})
+	assert.EqualError(t, err, "tool call failed")
+}
@@ -0,0 +1,165 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Harsh-2909/hermes-go/utils"
+)
+
+// Transport carries JSON-RPC messages between a Client and an MCP server.
+type Transport interface {
+	Start(ctx context.Context) error                // Start connects to the server
+	Send(ctx context.Context, message []byte) error // Send sends a JSON-RPC message to the server
+	Messages() <-chan []byte                        // Messages returns the messages received from the server; it is closed when the connection ends
+	Close() error                                   // Close ends the connection
+}
+
+// StdioTransport runs an MCP server as a subprocess and exchanges newline-delimited messages over its stdin and stdout.
+// The stderr of the server is logged at debug level.
+type StdioTransport struct {
+	Command string   // Required command of the server (e.g., "npx")
+	Args    []string // Arguments of the command (e.g., "-y", "@modelcontextprotocol/server-everything")
+	Env     []string // Environment variables added to the current environment, as "KEY=value"
+	Dir     string   // Working directory of the server. Defaults to the current directory
+
+	// Internal fields
+
+	cmd      *exec.Cmd
+	stdin    io.WriteCloser
+	messages chan []byte
+	writeMu  sync.Mutex
+	done     chan struct{} // Closed when the server's stdout is closed
+}
+
+// Start starts the server process.
+func (t *StdioTransport) Start(ctx context.Context) error {
+	if t.Command == "" {
+		return fmt.Errorf("no command configured")
+	}
+	cmd := exec.Command(t.Command, t.Args...)
+	cmd.Env = append(os.Environ(), t.Env...)
+	cmd.Dir = t.Dir
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("failed to open stdin: %v", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to open stdout: %v", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("failed to open stderr: %v", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start %s: %v", t.Command, err)
+	}
+	t.cmd = cmd
+	t.stdin = stdin
+	t.messages = make(chan []byte, 16)
+	t.done = make(chan struct{})
+
+	go func() {
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			utils.Logger.Debug("MCP server stderr", "command", t.Command, "line", scanner.Text())
+		}
+	}()
+	go func() {
+		defer close(t.done)
+		defer close(t.messages)
+		reader := bufio.NewReader(stdout)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if line = bytes.TrimSpace(line); len(line) > 0 {
+				t.messages <- line
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Send writes a message to the stdin of the server.
+func (t *StdioTransport) Send(ctx context.Context, message []byte) error {
+	if t.stdin == nil {
+		return fmt.Errorf("transport not started")
+	}
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	if _, err := t.stdin.Write(append(bytes.TrimSpace(message), '\n')); err != nil {
+		return fmt.Errorf("failed to write to server: %v", err)
+	}
+	return nil
+}
+
+// Messages returns the messages written by the server to its stdout.
+func (t *StdioTransport) Messages() <-chan []byte {
+	return t.messages
+}
+
+// Close closes the stdin of the server and waits for it to exit, killing it after 5 seconds.
+func (t *StdioTransport) Close() error {
+	if t.cmd == nil {
+		return nil
+	}
+	t.stdin.Close()
+	select {
+	case <-t.done:
+	case <-time.After(5 * time.Second):
+		t.cmd.Process.Kill()
+	}
+	t.cmd.Wait()
+	return nil
+}
+
+// readSSE reads a stream of server-sent events from r and calls handle with the type and data of each event.
+// The type defaults to "message". It returns when r ends or handle returns false.
+func readSSE(r io.Reader, handle func(event, data string) bool) error {
+	reader := bufio.NewReader(r)
+	var event string
+	var data []string
+	for {
+		line, err := reader.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" && err == nil {
+			// A blank line dispatches the event
+			if len(data) > 0 && !handle(utils.FirstNonEmpty(event, "message"), strings.Join(data, "\n")) {
+				return nil
+			}
+			event, data = "", nil
+			continue
+		}
+		if field, value, found := strings.Cut(line, ":"); found && field != "" {
+			value = strings.TrimPrefix(value, " ")
+			switch field {
+			case "event":
+				event = value
+			case "data":
+				data = append(data, value)
+			}
+		} else if line != "" && !found {
+			// A field without a colon has an empty value
+			if line == "data" {
+				data = append(data, "")
+			}
+		}
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
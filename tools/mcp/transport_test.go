@@ -0,0 +1,187 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Harsh-2909/hermes-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMain runs the test binary as a stdio MCP server when MCP_HERMES_SERVER (a Server) or MCP_FAKE_SERVER is set.
+func TestMain(m *testing.M) {
+	if os.Getenv("MCP_HERMES_SERVER") == "1" {
+		// Logs must not be written to stdout, which carries the protocol messages
+		utils.DefaultLogger.Writer = os.Stderr
+		if err := newTestServer().ServeStdio(context.Background()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if os.Getenv("MCP_FAKE_SERVER") == "1" {
+		server := newFakeServer()
+		var mu sync.Mutex
+		write := func(data []byte) {
+			mu.Lock()
+			defer mu.Unlock()
+			os.Stdout.Write(append(data, '\n'))
+		}
+		server.notify = write
+		fmt.Fprintln(os.Stderr, "fake server started")
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			if response := server.handle(scanner.Bytes()); response != nil {
+				write(response)
+			}
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// testEcho connects a client with transport and calls the echo tool.
+func testEcho(t *testing.T, transport Transport) {
+	client := &Client{Transport: transport}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if !assert.NoError(t, client.Connect(ctx)) {
+		return
+	}
+	defer client.Close()
+
+	toolList, err := client.ListTools(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, toolList, 4)
+	result, err := client.CallTool(ctx, "echo", []byte(`{"text":"hello"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []Content{TextContent("hello")}, result.Content)
+
+	err = client.Call(ctx, "resources/list", nil, nil)
+	assert.EqualError(t, err, "MCP error -32601: method not found")
+}
+
+func TestStdioTransport(t *testing.T) {
+	testEcho(t, &StdioTransport{Command: os.Args[0], Env: []string{"MCP_FAKE_SERVER=1"}})
+}
+
+// newStreamableHTTPServer returns a test server implementing the streamable HTTP transport.
+// Responses to tools/call are sent as event streams, and notifications on the GET event stream.
+func newStreamableHTTPServer(t *testing.T, server *fakeServer) *httptest.Server {
+	notifications := make(chan []byte, 16)
+	server.notify = func(data []byte) { notifications <- data }
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			response := server.handle(body)
+			if response == nil {
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			w.Header().Set(sessionHeader, "session-1")
+			if strings.Contains(string(body), `"tools/call"`) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				fmt.Fprintf(w, ": comment\nevent: message\ndata: %s\n\n", response)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(response)
+		case http.MethodGet:
+			assert.Equal(t, "session-1", r.Header.Get(sessionHeader))
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			for {
+				select {
+				case data := <-notifications:
+					fmt.Fprintf(w, "data: %s\n\n", data)
+					w.(http.Flusher).Flush()
+				case <-r.Context().Done():
+					return
+				}
+			}
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+}
+
+func TestHTTPTransport(t *testing.T) {
+	httpServer := newStreamableHTTPServer(t, newFakeServer())
+	defer httpServer.Close()
+	testEcho(t, &HTTPTransport{URL: httpServer.URL})
+
+	// Notifications are received from the GET event stream
+	toolkit := &ToolKit{Transport: &HTTPTransport{URL: httpServer.URL}}
+	defer toolkit.Close()
+	toolList := toolkit.Tools()
+	_, err := findTool(t, toolList, "add_tool").Execute(context.Background(), "{}")
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool { return len(toolkit.Tools()) == 5 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestHTTPTransportError(t *testing.T) {
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	defer httpServer.Close()
+	client := &Client{Transport: &HTTPTransport{URL: httpServer.URL}}
+	err := client.Connect(context.Background())
+	assert.ErrorContains(t, err, "401 Unauthorized: unauthorized")
+}
+
+func TestSSETransport(t *testing.T) {
+	server := newFakeServer()
+	events := make(chan []byte, 16)
+	server.notify = func(data []byte) { events <- data }
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/sse":
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "event: endpoint\ndata: /message?session=1\n\n")
+			w.(http.Flusher).Flush()
+			for {
+				select {
+				case data := <-events:
+					fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+					w.(http.Flusher).Flush()
+				case <-r.Context().Done():
+					return
+				}
+			}
+		case r.Method == http.MethodPost && r.URL.Path == "/message":
+			assert.Equal(t, "1", r.URL.Query().Get("session"))
+			body, _ := io.ReadAll(r.Body)
+			if response := server.handle(body); response != nil {
+				events <- response
+			}
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer httpServer.Close()
+	testEcho(t, &SSETransport{URL: httpServer.URL + "/sse"})
+}
+
+func TestReadSSE(t *testing.T) {
+	stream := ": comment\nevent: endpoint\ndata: /message\n\ndata: first\ndata: second\r\n\r\nevent: ignored\n\ndata: last"
+	var events []string
+	err := readSSE(strings.NewReader(stream), func(event, data string) bool {
+		events = append(events, event+"="+data)
+		return true
+	})
+	assert.NoError(t, err)
+	// The last event is not dispatched, as it is not followed by a blank line
+	assert.Equal(t, []string{"endpoint=/message", "message=first\nsecond"}, events)
+}
@@ -0,0 +1,134 @@
+package tools
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ToolMetadata holds the documentation of a toolkit method, used by CreateToolFromMethod to build its Tool.
+// It is generated at build time by cmd/toolgen, or parsed from the method's doc comment at runtime.
+type ToolMetadata struct {
+	Description string          // Description of the tool, from the first line of the doc comment
+	Params      []ParamMetadata // Parameters of the method after ctx, in order
+}
+
+// ParamMetadata holds the documentation of a toolkit method parameter.
+type ParamMetadata struct {
+	Name        string   // Name of the parameter
+	Description string   // Description from the @param doc comment line
+	Required    bool     // True if documented with @param but not marked [optional]
+	Enum        []string // Allowed values of a string parameter, from the @enum doc comment line
+}
+
+var (
+	// generatedToolMetadata holds the metadata registered with RegisterToolMetadata, by methodKey
+	generatedToolMetadata sync.Map
+	// parsedToolMetadata caches the metadata parsed from source code, by methodKey
+	parsedToolMetadata sync.Map
+)
+
+// RegisterToolMetadata registers the metadata of a toolkit method, so that CreateToolFromMethod does not need
+// the source code of the toolkit at runtime. toolkit is a value of the toolkit type, typically a nil pointer.
+// It is called by the code generated with cmd/toolgen:
+//
+//	//go:generate go run github.com/Harsh-2909/hermes-go/cmd/toolgen
+func RegisterToolMetadata(toolkit interface{}, methodName string, metadata ToolMetadata) {
+	generatedToolMetadata.Store(methodKey(reflect.TypeOf(toolkit), methodName), metadata)
+}
+
+// methodKey returns the key identifying a method of a toolkit type, e.g. "github.com/x/y.Toolkit.Method".
+func methodKey(t reflect.Type, methodName string) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.PkgPath() + "." + t.Name() + "." + methodName
+}
+
+// lookupToolMetadata returns the metadata of a toolkit method with paramCount parameters after ctx.
+// Generated metadata is preferred; if there is none or it is outdated, the metadata is parsed from source and cached.
+func lookupToolMetadata(toolkit interface{}, methodName string, paramCount int) (ToolMetadata, error) {
+	key := methodKey(reflect.TypeOf(toolkit), methodName)
+	if metadata, ok := generatedToolMetadata.Load(key); ok {
+		if metadata := metadata.(ToolMetadata); len(metadata.Params) == paramCount {
+			return metadata, nil
+		}
+	}
+	if metadata, ok := parsedToolMetadata.Load(key); ok {
+		return metadata.(ToolMetadata), nil
+	}
+	metadata, err := parseToolMetadataFromSource(toolkit, methodName)
+	if err != nil {
+		return ToolMetadata{}, err
+	}
+	if len(metadata.Params) != paramCount {
+		return ToolMetadata{}, fmt.Errorf("parameter count mismatch")
+	}
+	parsedToolMetadata.Store(key, metadata)
+	return metadata, nil
+}
+
+// ParseToolDoc parses the doc comment of a toolkit method with the given parameter names (after ctx).
+// The first line is the description of the tool. Parameters are documented with lines of the form:
+//
+//	@param name: Description of the parameter
+//	@param [optional] name: Description of an optional parameter
+//	@enum name: first, second, third
+//
+// "@params" is accepted as well. Parameters without an @param line are optional and have no description.
+// The @enum line restricts a string parameter to a comma separated list of values.
+func ParseToolDoc(doc string, paramNames []string) ToolMetadata {
+	lines := strings.Split(doc, "\n")
+	description := strings.TrimSpace(lines[0]) // First line is the description
+
+	paramDescs := make(map[string]string)
+	required := make(map[string]bool)
+	enums := make(map[string][]string)
+	for _, line := range lines[1:] {
+		line = strings.TrimSpace(line)
+
+		if enumLine, ok := strings.CutPrefix(line, "@enum "); ok {
+			if name, values, found := strings.Cut(enumLine, ":"); found {
+				name = strings.TrimSpace(name)
+				for _, value := range strings.Split(values, ",") {
+					if value = strings.TrimSpace(value); value != "" {
+						enums[name] = append(enums[name], value)
+					}
+				}
+			}
+			continue
+		}
+
+		// Checking for @param or @params prefix. Either is supported
+		var prefix string
+		if strings.HasPrefix(line, "@param ") {
+			prefix = "@param "
+		} else if strings.HasPrefix(line, "@params ") {
+			prefix = "@params "
+		}
+		if prefix == "" {
+			continue
+		}
+		paramLine := strings.TrimPrefix(line, prefix)
+		isOptional := false
+
+		// Checks for optional parameter
+		if strings.HasPrefix(paramLine, "[optional] ") {
+			isOptional = true
+			paramLine = strings.TrimPrefix(paramLine, "[optional] ")
+		}
+		parts := strings.SplitN(paramLine, ":", 2)
+		if len(parts) == 2 {
+			name := strings.TrimSpace(parts[0])
+			paramDescs[name] = strings.TrimSpace(parts[1])
+			required[name] = !isOptional
+		}
+	}
+
+	params := make([]ParamMetadata, len(paramNames))
+	for i, name := range paramNames {
+		params[i] = ParamMetadata{Name: name, Description: paramDescs[name], Required: required[name], Enum: enums[name]}
+	}
+	return ToolMetadata{Description: description, Params: params}
+}
@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// GeneratedToolkit is a sample toolkit whose metadata is registered as if generated by toolgen.
+type GeneratedToolkit struct{}
+
+// Echo returns the message. This doc comment is ignored, as generated metadata is registered.
+// @param message: Ignored description
+func (g *GeneratedToolkit) Echo(ctx context.Context, message string) string {
+	return message
+}
+
+// Outdated returns the message.
+// @param message: The message to return
+func (g *GeneratedToolkit) Outdated(ctx context.Context, message string) string {
+	return message
+}
+
+func init() {
+	RegisterToolMetadata((*GeneratedToolkit)(nil), "Echo", ToolMetadata{
+		Description: "Echo returns the message.",
+		Params:      []ParamMetadata{{Name: "text", Description: "The message to return", Required: true}},
+	})
+	// Outdated metadata with a different number of parameters than the method is ignored
+	RegisterToolMetadata((*GeneratedToolkit)(nil), "Outdated", ToolMetadata{Description: "Outdated description"})
+}
+
+func TestParseToolDoc(t *testing.T) {
+	doc := "Search searches the web.\n\nMore details.\n@param query: The search query\n@params [optional] limit: Maximum results\n@param unknown: Not a parameter\n@return The results\n"
+	metadata := ParseToolDoc(doc, []string{"query", "limit", "page"})
+	assert.Equal(t, ToolMetadata{
+		Description: "Search searches the web.",
+		Params: []ParamMetadata{
+			{Name: "query", Description: "The search query", Required: true},
+			{Name: "limit", Description: "Maximum results", Required: false},
+			{Name: "page", Description: "", Required: false},
+		},
+	}, metadata)
+}
+
+func TestCreateToolFromMethodWithGeneratedMetadata(t *testing.T) {
+	tool, err := CreateToolFromMethod(&GeneratedToolkit{}, "Echo")
+	assert.NoError(t, err)
+	assert.Equal(t, "Echo returns the message.", tool.Description)
+	assert.Equal(t, []string{"text"}, tool.Parameters["required"])
+	result, err := tool.Execute(context.Background(), `{"text": "hi"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `"hi"`, result)
+
+	tool, err = CreateToolFromMethod(&GeneratedToolkit{}, "Outdated")
+	assert.NoError(t, err)
+	assert.Equal(t, "Outdated returns the message.", tool.Description, "Outdated metadata should fall back to source parsing")
+	assert.Equal(t, []string{"message"}, tool.Parameters["required"])
+}
+
+func TestGeneratedMetadataIsUpToDate(t *testing.T) {
+	// The metadata generated for the toolkits of this package must match their doc comments
+	for _, toolkit := range []ToolKit{&CalculatorTools{EnableAll: true}, &DataTools{EnableAll: true}, &DateTimeTools{EnableAll: true}, &EmailTools{EnableAll: true, IMAPHost: "imap.example.com"}, &FileSystemTools{EnableAll: true}, &GitTools{EnableAll: true, EnableCommit: true, EnableCreateBranch: true}, &ImageGenerationTools{}, &SearchTools{}, &ShellTools{}, &SQLTools{EnableAll: true}, &WebTools{EnableAll: true}} {
+		for _, tool := range toolkit.Tools() {
+			generated, ok := generatedToolMetadata.Load(methodKey(reflect.TypeOf(toolkit), tool.Name))
+			assert.True(t, ok, "No generated metadata for %s; run go generate", tool.Name)
+			parsed, err := parseToolMetadataFromSource(toolkit, tool.Name)
+			assert.NoError(t, err)
+			assert.Equal(t, parsed, generated, "Generated metadata for %s is outdated; run go generate", tool.Name)
+		}
+	}
+}
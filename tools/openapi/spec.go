@@ -0,0 +1,224 @@
+// Package openapi turns the operations of an OpenAPI 3 specification into agent tools.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is a loaded OpenAPI 3 specification, with its local $ref references resolved.
+type Spec struct {
+	OpenAPI string              `json:"openapi"`
+	Info    Info                `json:"info"`
+	Servers []Server            `json:"servers"`
+	Paths   map[string]PathItem `json:"paths"`
+}
+
+// Info holds the metadata of an API.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+// Server is a server of an API.
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description"`
+}
+
+// PathItem holds the operations of a path. Parameters apply to all its operations.
+type PathItem struct {
+	Parameters []Parameter `json:"parameters"`
+	Get        *Operation  `json:"get"`
+	Put        *Operation  `json:"put"`
+	Post       *Operation  `json:"post"`
+	Delete     *Operation  `json:"delete"`
+	Options    *Operation  `json:"options"`
+	Head       *Operation  `json:"head"`
+	Patch      *Operation  `json:"patch"`
+}
+
+// operations returns the operations of the path item by HTTP method, in a stable order.
+func (p PathItem) operations() []struct {
+	method    string
+	operation *Operation
+} {
+	all := []struct {
+		method    string
+		operation *Operation
+	}{
+		{"GET", p.Get}, {"PUT", p.Put}, {"POST", p.Post}, {"DELETE", p.Delete},
+		{"OPTIONS", p.Options}, {"HEAD", p.Head}, {"PATCH", p.Patch},
+	}
+	result := all[:0]
+	for _, op := range all {
+		if op.operation != nil {
+			result = append(result, op)
+		}
+	}
+	return result
+}
+
+// Operation is an API operation.
+type Operation struct {
+	OperationID string       `json:"operationId"`
+	Summary     string       `json:"summary"`
+	Description string       `json:"description"`
+	Parameters  []Parameter  `json:"parameters"`
+	RequestBody *RequestBody `json:"requestBody"`
+	Deprecated  bool         `json:"deprecated"`
+}
+
+// Parameter is a path, query, header or cookie parameter of an operation.
+type Parameter struct {
+	Name        string                 `json:"name"`
+	In          string                 `json:"in"`
+	Description string                 `json:"description"`
+	Required    bool                   `json:"required"`
+	Schema      map[string]interface{} `json:"schema"`
+}
+
+// RequestBody is the request body of an operation, by media type.
+type RequestBody struct {
+	Description string               `json:"description"`
+	Required    bool                 `json:"required"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+// MediaType holds the schema of a request body media type.
+type MediaType struct {
+	Schema map[string]interface{} `json:"schema"`
+}
+
+// LoadFile loads an OpenAPI 3 specification from a YAML or JSON file.
+func LoadFile(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec: %w", err)
+	}
+	return Load(data)
+}
+
+// Load loads an OpenAPI 3 specification in YAML or JSON. Local references (e.g., "#/components/schemas/Pet")
+// are resolved; recursive references are replaced by an empty schema, and external references are not supported.
+func Load(data []byte) (*Spec, error) {
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+	root, ok := normalize(document).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("failed to parse spec: not an object")
+	}
+	if version, _ := root["openapi"].(string); !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q, only version 3 is supported", version)
+	}
+	resolved, err := resolveRefs(root, root, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode the resolved document into the typed specification through JSON
+	encoded, err := json.Marshal(resolved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode spec: %v", err)
+	}
+	var spec Spec
+	if err := json.Unmarshal(encoded, &spec); err != nil {
+		return nil, fmt.Errorf("failed to decode spec: %v", err)
+	}
+	return &spec, nil
+}
+
+// normalize converts the maps decoded from YAML to map[string]interface{}, as YAML keys are not always strings
+// (e.g., response codes).
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalize(item)
+		}
+		return v
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[fmt.Sprint(key)] = normalize(item)
+		}
+		return result
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalize(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// resolveRefs returns a copy of value with the local $ref references replaced by their targets in root.
+// visiting holds the references being resolved, to detect recursion.
+func resolveRefs(value interface{}, root map[string]interface{}, visiting []string) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok {
+			for _, visited := range visiting {
+				if visited == ref {
+					// Recursive schemas cannot be inlined, so any value is accepted
+					return map[string]interface{}{"description": "Recursive reference to " + ref}, nil
+				}
+			}
+			target, err := lookupRef(root, ref)
+			if err != nil {
+				return nil, err
+			}
+			return resolveRefs(target, root, append(visiting, ref))
+		}
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			resolved, err := resolveRefs(item, root, visiting)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = resolved
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			resolved, err := resolveRefs(item, root, visiting)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = resolved
+		}
+		return result, nil
+	default:
+		return value, nil
+	}
+}
+
+// lookupRef returns the value of a local reference such as "#/components/schemas/Pet".
+func lookupRef(root map[string]interface{}, ref string) (interface{}, error) {
+	pointer, ok := strings.CutPrefix(ref, "#/")
+	if !ok {
+		return nil, fmt.Errorf("unsupported reference %q: only local references are supported", ref)
+	}
+	var current interface{} = root
+	for _, token := range strings.Split(pointer, "/") {
+		// JSON pointer escapes
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid reference %q", ref)
+		}
+		if current, ok = object[token]; !ok {
+			return nil, fmt.Errorf("invalid reference %q: %s not found", ref, token)
+		}
+	}
+	return current, nil
+}
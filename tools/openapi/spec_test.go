@@ -0,0 +1,51 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFile(t *testing.T) {
+	spec, err := LoadFile("testdata/petstore.yaml")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Petstore", spec.Info.Title)
+	assert.Equal(t, "https://petstore.example.com/v1", spec.Servers[0].URL)
+	assert.Len(t, spec.Paths, 2)
+
+	// Parameter references are resolved
+	item := spec.Paths["/pets/{petId}"]
+	assert.Equal(t, Parameter{Name: "petId", In: "path", Required: true, Description: "The id of the pet", Schema: map[string]interface{}{"type": "string"}}, item.Parameters[0])
+	assert.Equal(t, "deletePet", item.Delete.OperationID)
+
+	// Schema references are resolved, and recursive references are replaced
+	schema := spec.Paths["/pets"].Post.RequestBody.Content["application/json"].Schema
+	owner := schema["properties"].(map[string]interface{})["owner"].(map[string]interface{})
+	pets := owner["properties"].(map[string]interface{})["pets"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"description": "Recursive reference to #/components/schemas/NewPet"}, pets["items"])
+}
+
+func TestLoad(t *testing.T) {
+	spec, err := Load([]byte(`{"openapi": "3.1.0", "info": {"title": "JSON API", "version": "1"}, "paths": {}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "JSON API", spec.Info.Title)
+
+	testCases := []struct {
+		name string
+		spec string
+		err  string
+	}{
+		{"Swagger 2", `swagger: "2.0"`, `unsupported OpenAPI version "", only version 3 is supported`},
+		{"Not an object", `- item`, "failed to parse spec: not an object"},
+		{"External reference", `{"openapi": "3.0.0", "paths": {"/a": {"$ref": "other.yaml#/paths/a"}}}`, `unsupported reference "other.yaml#/paths/a": only local references are supported`},
+		{"Missing reference", `{"openapi": "3.0.0", "paths": {"/a": {"$ref": "#/components/pathItems/a"}}}`, `invalid reference "#/components/pathItems/a": components not found`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Load([]byte(tc.spec))
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,394 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/Harsh-2909/hermes-go/tools"
+	"github.com/Harsh-2909/hermes-go/utils"
+)
+
+// ToolKit provides the operations of an OpenAPI 3 specification as tools.
+// Each operation becomes a tool named after its operationId (or method and path), whose parameters are the
+// operation's path, query, header and cookie parameters, and a "body" parameter for the JSON request body.
+// Responses are returned as text; responses with an error status are returned as errors.
+//
+// Example:
+//
+//	spec, err := openapi.LoadFile("petstore.yaml")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	petstore := &openapi.ToolKit{
+//		Spec:       spec,
+//		Headers:    map[string]string{"Authorization": "Bearer " + os.Getenv("PETSTORE_TOKEN")},
+//		Operations: []string{"listPets", "GET /pets/{petId}"},
+//	}
+type ToolKit struct {
+	Spec            *Spec             // Required specification, from Load or LoadFile
+	BaseURL         string            // Base URL of the API. Defaults to the first server of the specification
+	HTTPClient      *http.Client      // HTTP client used for requests. Defaults to http.DefaultClient
+	Headers         map[string]string // Headers sent with each request, e.g. for authentication
+	Operations      []string          // Allow-list of operations, by operationId or "METHOD /path". All operations if empty
+	ToolPrefix      string            // Optional prefix of the tool names, to avoid conflicts between APIs
+	MaxResponseSize int               // Maximum size of a response body in bytes; longer bodies are truncated. Defaults to 100000
+}
+
+// defaultMaxResponseSize is the default maximum size of a response body returned to the model.
+const defaultMaxResponseSize = 100000
+
+// Tools returns a tool for each allowed operation of the specification.
+func (tk *ToolKit) Tools() []tools.Tool {
+	if tk.Spec == nil {
+		utils.Logger.Error("Failed to create OpenAPI tools", "error", "no spec configured")
+		return nil
+	}
+	// Paths are sorted so that the tools are in a stable order
+	paths := make([]string, 0, len(tk.Spec.Paths))
+	for path := range tk.Spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var toolList []tools.Tool
+	for _, path := range paths {
+		item := tk.Spec.Paths[path]
+		for _, op := range item.operations() {
+			if !tk.allowed(op.method, path, op.operation) {
+				continue
+			}
+			tool, err := tk.newTool(op.method, path, item, op.operation)
+			if err != nil {
+				utils.Logger.Error("Failed to create tool", "tool", op.method+" "+path, "error", err)
+				continue
+			}
+			toolList = append(toolList, tool)
+		}
+	}
+	return toolList
+}
+
+// allowed reports whether an operation is in the allow-list.
+func (tk *ToolKit) allowed(method, path string, operation *Operation) bool {
+	if len(tk.Operations) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(tk.Operations, func(allowed string) bool {
+		return (operation.OperationID != "" && allowed == operation.OperationID) || strings.EqualFold(allowed, method+" "+path)
+	})
+}
+
+// invalidNameChars matches the characters not allowed in tool names by model APIs.
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// toolName returns the name of the tool of an operation, which must match ^[a-zA-Z0-9_-]{1,64}$.
+func toolName(prefix, method, path string, operation *Operation) string {
+	name := operation.OperationID
+	if name == "" {
+		// e.g. "get_pets_petId" for GET /pets/{petId}
+		name = strings.ToLower(method) + "_" + strings.Trim(invalidNameChars.ReplaceAllString(path, "_"), "_")
+	}
+	name = strings.Trim(invalidNameChars.ReplaceAllString(prefix+name, "_"), "_")
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+// boundParameter is a parameter of a tool, mapped to the property of its arguments.
+type boundParameter struct {
+	Parameter
+	property string
+}
+
+// newTool returns the tool executing an operation.
+func (tk *ToolKit) newTool(method, path string, item PathItem, operation *Operation) (tools.Tool, error) {
+	// Operation parameters override the path item parameters with the same name and location
+	params := make([]Parameter, 0, len(item.Parameters)+len(operation.Parameters))
+	for _, param := range item.Parameters {
+		if !slices.ContainsFunc(operation.Parameters, func(p Parameter) bool { return p.Name == param.Name && p.In == param.In }) {
+			params = append(params, param)
+		}
+	}
+	params = append(params, operation.Parameters...)
+
+	properties := make(map[string]interface{})
+	required := make([]string, 0)
+	bound := make([]boundParameter, 0, len(params))
+	for _, param := range params {
+		if param.Name == "" || param.In == "" {
+			return tools.Tool{}, fmt.Errorf("parameter without name or location")
+		}
+		property := param.Name
+		if _, exists := properties[property]; exists || property == "body" {
+			property = param.In + "_" + param.Name
+		}
+		schema := convertSchema(param.Schema)
+		if param.Description != "" {
+			schema["description"] = param.Description
+		}
+		properties[property] = schema
+		if param.Required || param.In == "path" {
+			required = append(required, property)
+		}
+		bound = append(bound, boundParameter{Parameter: param, property: property})
+	}
+
+	var contentType string
+	if body := operation.RequestBody; body != nil {
+		var media MediaType
+		contentType, media = selectContentType(body.Content)
+		if contentType == "" {
+			return tools.Tool{}, fmt.Errorf("no request body content type")
+		}
+		schema := convertSchema(media.Schema)
+		if !isJSON(contentType) && contentType != "application/x-www-form-urlencoded" {
+			schema = map[string]interface{}{"type": "string"}
+		}
+		if body.Description != "" {
+			schema["description"] = body.Description
+		}
+		properties["body"] = schema
+		if body.Required {
+			required = append(required, "body")
+		}
+	}
+
+	description := strings.TrimSpace(operation.Summary + "\n" + operation.Description)
+	if description == "" {
+		description = method + " " + path
+	}
+	if operation.Deprecated {
+		description = "Deprecated. " + description
+	}
+	parameters := map[string]interface{}{"type": "object", "properties": properties, "required": required}
+
+	return tools.NewTool(toolName(tk.ToolPrefix, method, path, operation), description, parameters, func(ctx context.Context, args string) (string, error) {
+		return tk.call(ctx, method, path, bound, contentType, args)
+	}), nil
+}
+
+// selectContentType returns the preferred content type of a request body: JSON, then form, then any other.
+func selectContentType(content map[string]MediaType) (string, MediaType) {
+	types := make([]string, 0, len(content))
+	for contentType := range content {
+		types = append(types, contentType)
+	}
+	sort.Strings(types)
+	for _, preferred := range []func(string) bool{
+		isJSON,
+		func(t string) bool { return t == "application/x-www-form-urlencoded" },
+		func(t string) bool { return true },
+	} {
+		for _, contentType := range types {
+			if preferred(contentType) {
+				return contentType, content[contentType]
+			}
+		}
+	}
+	return "", MediaType{}
+}
+
+// isJSON reports whether a content type is JSON (e.g., "application/json" or "application/problem+json").
+func isJSON(contentType string) bool {
+	return contentType == "application/json" || strings.HasSuffix(contentType, "+json")
+}
+
+// openAPIOnlyKeywords are the OpenAPI schema keywords which are not part of JSON Schema.
+var openAPIOnlyKeywords = []string{"nullable", "discriminator", "xml", "externalDocs", "example", "deprecated", "readOnly", "writeOnly"}
+
+// convertSchema converts an OpenAPI schema to a JSON Schema: "nullable: true" adds the "null" type,
+// and OpenAPI-only keywords and extensions are removed.
+func convertSchema(schema map[string]interface{}) map[string]interface{} {
+	if schema == nil {
+		return map[string]interface{}{}
+	}
+	result := make(map[string]interface{}, len(schema))
+	for key, value := range schema {
+		if slices.Contains(openAPIOnlyKeywords, key) || strings.HasPrefix(key, "x-") {
+			continue
+		}
+		switch key {
+		case "properties", "patternProperties", "definitions", "$defs":
+			if properties, ok := value.(map[string]interface{}); ok {
+				converted := make(map[string]interface{}, len(properties))
+				for name, property := range properties {
+					if propertySchema, ok := property.(map[string]interface{}); ok {
+						converted[name] = convertSchema(propertySchema)
+					} else {
+						converted[name] = property
+					}
+				}
+				value = converted
+			}
+		case "items", "additionalProperties", "not":
+			if itemSchema, ok := value.(map[string]interface{}); ok {
+				value = convertSchema(itemSchema)
+			}
+		case "allOf", "anyOf", "oneOf":
+			if schemas, ok := value.([]interface{}); ok {
+				converted := make([]interface{}, len(schemas))
+				for i, item := range schemas {
+					if itemSchema, ok := item.(map[string]interface{}); ok {
+						converted[i] = convertSchema(itemSchema)
+					} else {
+						converted[i] = item
+					}
+				}
+				value = converted
+			}
+		}
+		result[key] = value
+	}
+	if nullable, _ := schema["nullable"].(bool); nullable {
+		if schemaType, ok := result["type"].(string); ok {
+			result["type"] = []interface{}{schemaType, "null"}
+		}
+	}
+	return result
+}
+
+// call executes an operation with the JSON-encoded arguments of a tool call.
+func (tk *ToolKit) call(ctx context.Context, method, path string, params []boundParameter, contentType, args string) (string, error) {
+	var values map[string]interface{}
+	if strings.TrimSpace(args) != "" {
+		if err := json.Unmarshal([]byte(args), &values); err != nil {
+			return "", fmt.Errorf("failed to unmarshal args: %v", err)
+		}
+	}
+
+	baseURL := tk.BaseURL
+	if baseURL == "" && len(tk.Spec.Servers) > 0 {
+		baseURL = tk.Spec.Servers[0].URL
+	}
+	if baseURL == "" {
+		return "", fmt.Errorf("no base URL configured")
+	}
+
+	query := url.Values{}
+	headers := http.Header{}
+	var cookies []*http.Cookie
+	for _, param := range params {
+		value, ok := values[param.property]
+		if !ok || value == nil {
+			if param.In == "path" {
+				return "", fmt.Errorf("missing path parameter %s", param.Name)
+			}
+			continue
+		}
+		switch param.In {
+		case "path":
+			segment := formatValue(value)
+			// PathEscape keeps dots, so these would move the request to another path
+			if segment == "." || segment == ".." {
+				return "", fmt.Errorf("invalid path parameter %s: %q", param.Name, segment)
+			}
+			path = strings.ReplaceAll(path, "{"+param.Name+"}", url.PathEscape(segment))
+		case "query":
+			if items, ok := value.([]interface{}); ok {
+				for _, item := range items {
+					query.Add(param.Name, formatValue(item))
+				}
+			} else {
+				query.Set(param.Name, formatValue(value))
+			}
+		case "header":
+			headers.Set(param.Name, formatValue(value))
+		case "cookie":
+			cookies = append(cookies, &http.Cookie{Name: param.Name, Value: formatValue(value)})
+		}
+	}
+
+	requestURL := strings.TrimRight(baseURL, "/") + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	var body io.Reader
+	if value, ok := values["body"]; ok && contentType != "" {
+		switch {
+		case isJSON(contentType):
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return "", fmt.Errorf("failed to encode body: %v", err)
+			}
+			body = bytes.NewReader(encoded)
+		case contentType == "application/x-www-form-urlencoded":
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return "", fmt.Errorf("body must be an object")
+			}
+			form := url.Values{}
+			for key, field := range object {
+				form.Set(key, formatValue(field))
+			}
+			body = strings.NewReader(form.Encode())
+		default:
+			body = strings.NewReader(formatValue(value))
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %v", err)
+	}
+	for key, value := range headers {
+		req.Header[key] = value
+	}
+	// Configured headers are set last, so that header parameters can't replace credentials
+	for key, value := range tk.Headers {
+		req.Header.Set(key, value)
+	}
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	client := tk.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	utils.Logger.Debug("Calling API operation", "method", method, "url", requestURL)
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	maxSize := tk.MaxResponseSize
+	if maxSize <= 0 {
+		maxSize = defaultMaxResponseSize
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, int64(maxSize)+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %v", err)
+	}
+	result := string(data)
+	if len(data) > maxSize {
+		result = string(data[:maxSize]) + fmt.Sprintf("\n[Response truncated to %d bytes]", maxSize)
+	}
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("%s %s returned %s: %s", method, path, resp.Status, result)
+	}
+	return result, nil
+}
+
+// formatValue formats an argument value for a URL, header or form: strings as is, other values as JSON.
+func formatValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
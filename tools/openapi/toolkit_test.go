@@ -0,0 +1,153 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Harsh-2909/hermes-go/tools"
+	"github.com/stretchr/testify/assert"
+)
+
+// findTool returns the tool with the given name.
+func findTool(t *testing.T, toolList []tools.Tool, name string) tools.Tool {
+	for _, tool := range toolList {
+		if tool.Name == name {
+			return tool
+		}
+	}
+	t.Fatalf("tool %s not found", name)
+	return tools.Tool{}
+}
+
+func TestToolKitTools(t *testing.T) {
+	spec, err := LoadFile("testdata/petstore.yaml")
+	if !assert.NoError(t, err) {
+		return
+	}
+	toolkit := &ToolKit{Spec: spec}
+	toolList := toolkit.Tools()
+	var names []string
+	for _, tool := range toolList {
+		names = append(names, tool.Name)
+	}
+	assert.Equal(t, []string{"listPets", "createPet", "get_pets_petId", "deletePet"}, names)
+
+	listPets := findTool(t, toolList, "listPets")
+	assert.Equal(t, "List all pets", listPets.Description)
+	assert.Equal(t, map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"limit": map[string]interface{}{"type": "integer", "maximum": float64(100), "description": "How many items to return at one time"},
+			"tags":  map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+		},
+		"required": []string{},
+	}, listPets.Parameters)
+
+	// Nullable properties accept null, and OpenAPI-only keywords are removed
+	body := findTool(t, toolList, "createPet").Parameters["properties"].(map[string]interface{})["body"].(map[string]interface{})
+	assert.NotContains(t, body, "x-go-type")
+	properties := body["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": []interface{}{"string", "null"}}, properties["tag"])
+	assert.Equal(t, []string{"body"}, findTool(t, toolList, "createPet").Parameters["required"])
+
+	getPet := findTool(t, toolList, "get_pets_petId")
+	assert.Equal(t, []string{"petId"}, getPet.Parameters["required"])
+	assert.Equal(t, "Deprecated. Delete a pet", findTool(t, toolList, "deletePet").Description)
+
+	// Operations can be allowed by operationId or method and path
+	toolkit.Operations = []string{"listPets", "get /pets/{petId}"}
+	toolkit.ToolPrefix = "petstore_"
+	names = nil
+	for _, tool := range toolkit.Tools() {
+		names = append(names, tool.Name)
+	}
+	assert.Equal(t, []string{"petstore_listPets", "petstore_get_pets_petId"}, names)
+}
+
+func TestToolKitExecute(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, `{"error": "unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/pets":
+			fmt.Fprintf(w, `{"query": %q}`, r.URL.RawQuery)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/pets":
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"content_type": %q, "body": %s}`, r.Header.Get("Content-Type"), body)
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/pets/"):
+			if r.URL.EscapedPath() == "/v1/pets/missing" {
+				http.Error(w, `{"error": "not found"}`, http.StatusNotFound)
+				return
+			}
+			fmt.Fprintf(w, `{"path": %q, "request_id": %q}`, r.URL.EscapedPath(), r.Header.Get("X-Request-Id"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	spec, err := LoadFile("testdata/petstore.yaml")
+	if !assert.NoError(t, err) {
+		return
+	}
+	toolkit := &ToolKit{
+		Spec:            spec,
+		BaseURL:         server.URL + "/v1",
+		HTTPClient:      server.Client(),
+		Headers:         map[string]string{"Authorization": "Bearer secret"},
+		MaxResponseSize: 80,
+	}
+	toolList := toolkit.Tools()
+	ctx := context.Background()
+
+	testCases := []struct {
+		name     string
+		tool     string
+		args     string
+		expected string
+		err      string
+	}{
+		{"Query parameters", "listPets", `{"limit": 10, "tags": ["cat", "dog"]}`, `{"query": "limit=10&tags=cat&tags=dog"}`, ""},
+		{"JSON body", "createPet", `{"body": {"name": "Rex", "tag": null}}`, `{"content_type": "application/json", "body": {"name":"Rex","tag":null}}`, ""},
+		{"Path and header parameters", "get_pets_petId", `{"petId": "a/b", "X-Request-Id": "42"}`, `{"path": "/v1/pets/a%2Fb", "request_id": "42"}`, ""},
+		{"Missing path parameter", "get_pets_petId", `{}`, "", "missing path parameter petId"},
+		{"Dot path parameter", "get_pets_petId", `{"petId": ".."}`, "", `invalid path parameter petId: ".."`},
+		{"Error status", "get_pets_petId", `{"petId": "missing"}`, "", `GET /pets/missing returned 404 Not Found: {"error": "not found"}` + "\n"},
+		{"Truncated response", "listPets", `{"tags": ["a-very-long-tag-name", "another-very-long-tag-name", "a-third-tag"]}`,
+			`{"query": "tags=a-very-long-tag-name&tags=another-very-long-tag-name&tags=a-third-tag"}`[:80] + "\n[Response truncated to 80 bytes]", ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := findTool(t, toolList, tc.tool).Execute(ctx, tc.args)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+
+	// Configured headers take precedence over header parameters
+	toolkit.Headers["X-Request-Id"] = "configured"
+	result, err := findTool(t, toolkit.Tools(), "get_pets_petId").Execute(ctx, `{"petId": "1", "X-Request-Id": "42"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"path": "/v1/pets/1", "request_id": "configured"}`, result)
+
+	// Requests without the auth header are rejected by the server
+	toolkit.Headers = nil
+	_, err = findTool(t, toolkit.Tools(), "listPets").Execute(ctx, `{}`)
+	assert.ErrorContains(t, err, "401 Unauthorized")
+}
+
+func TestToolKitWithoutSpec(t *testing.T) {
+	assert.Nil(t, (&ToolKit{}).Tools())
+}
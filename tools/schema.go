@@ -0,0 +1,233 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// JSONSchemaFor returns the JSON Schema of a Go type, as used for tool parameters.
+// Structs become objects whose properties are named after their json tags. Struct fields support these tags:
+//   - `description:"..."`: description of the property
+//   - `enum:"a,b,c"`: comma separated list of allowed values
+//   - `minimum:"0"` and `maximum:"10"`: range of numeric values
+//
+// Fields are required unless they are pointers or have the omitempty json option.
+// It returns an error for types which cannot be represented in JSON (e.g., channels, functions, recursive types).
+func JSONSchemaFor(t reflect.Type) (map[string]interface{}, error) {
+	return schemaForType(t, map[reflect.Type]bool{})
+}
+
+// schemaForType returns the JSON Schema of t. visiting holds the struct types being processed, to detect recursion.
+func schemaForType(t reflect.Type, visiting map[reflect.Type]bool) (map[string]interface{}, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": float64(0)}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.Interface:
+		return map[string]interface{}{}, nil
+	case reflect.Slice, reflect.Array:
+		// encoding/json encodes byte slices as base64 strings
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "contentEncoding": "base64"}, nil
+		}
+		items, err := schemaForType(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "array", "items": items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type: %v", t.Key())
+		}
+		values, err := schemaForType(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		if visiting[t] {
+			return nil, fmt.Errorf("recursive type not supported: %v", t)
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		properties := make(map[string]interface{})
+		required := make([]string, 0)
+		if err := addStructFields(t, properties, &required, visiting); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"type":       "object",
+			"properties": properties,
+			"required":   required,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type: %v", t)
+	}
+}
+
+// addStructFields adds the schemas of the exported fields of struct t to properties,
+// flattening embedded structs without a json name as encoding/json does.
+func addStructFields(t reflect.Type, properties map[string]interface{}, required *[]string, visiting map[reflect.Type]bool) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		fieldType := field.Type
+		if field.Anonymous && name == "" {
+			for fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				if err := addStructFields(fieldType, properties, required, visiting); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema, err := schemaForType(field.Type, visiting)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if err := applyFieldTags(schema, field); err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		properties[name] = schema
+
+		optional := field.Type.Kind() == reflect.Pointer || slices.Contains(strings.Split(options, ","), "omitempty")
+		if !optional {
+			*required = append(*required, name)
+		}
+	}
+	return nil
+}
+
+// applyFieldTags adds the description, enum, minimum and maximum struct tags of field to its schema.
+func applyFieldTags(schema map[string]interface{}, field reflect.StructField) error {
+	if description := field.Tag.Get("description"); description != "" {
+		schema["description"] = description
+	}
+	if enum := field.Tag.Get("enum"); enum != "" {
+		values := make([]interface{}, 0)
+		for _, value := range strings.Split(enum, ",") {
+			value = strings.TrimSpace(value)
+			switch schema["type"] {
+			case "integer", "number":
+				number, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					return fmt.Errorf("invalid enum value %q: %v", value, err)
+				}
+				values = append(values, number)
+			default:
+				values = append(values, value)
+			}
+		}
+		schema["enum"] = values
+	}
+	for _, key := range []string{"minimum", "maximum"} {
+		if value := field.Tag.Get(key); value != "" {
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s %q: %v", key, value, err)
+			}
+			schema[key] = number
+		}
+	}
+	return nil
+}
+
+// matchesJSONType reports whether a decoded JSON value is of the JSON Schema type.
+func matchesJSONType(schemaType string, value interface{}) bool {
+	switch schemaType {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		f, ok := value.(float64)
+		return ok && f == math.Trunc(f)
+	case "null":
+		return value == nil
+	default:
+		return true
+	}
+}
+
+// jsonTypeName returns the JSON type name of a decoded JSON value.
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// jsonEqual reports whether two values are equal once encoded as JSON, so that e.g. int and float64 enum values match.
+func jsonEqual(a, b interface{}) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(encodedA) == string(encodedB)
+}
+
+// joinPath appends a property name to a path used in error messages.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
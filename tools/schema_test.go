@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type schemaAddress struct {
+	Street string `json:"street"`
+	City   string `json:"city" description:"City name"`
+}
+
+type schemaBase struct {
+	ID string `json:"id"`
+}
+
+type schemaPerson struct {
+	schemaBase
+	Name      string            `json:"name"`
+	Age       uint              `json:"age,omitempty" maximum:"150"`
+	Level     int               `json:"level" enum:"1,2,3"`
+	Address   schemaAddress     `json:"address"`
+	Previous  []*schemaAddress  `json:"previous,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Born      time.Time         `json:"born"`
+	Nickname  *string           `json:"nickname"`
+	Data      []byte            `json:"data,omitempty"`
+	Extra     interface{}       `json:"extra,omitempty"`
+	Ignored   string            `json:"-"`
+	NoTag     bool
+	unexposed string
+}
+
+type schemaNode struct {
+	Children []schemaNode `json:"children"`
+}
+
+func TestJSONSchemaFor(t *testing.T) {
+	schema, err := JSONSchemaFor(reflect.TypeOf(schemaPerson{}))
+	assert.NoError(t, err)
+	assert.Equal(t, "object", schema["type"])
+	assert.Equal(t, []string{"id", "name", "level", "address", "born", "NoTag"}, schema["required"])
+
+	properties := schema["properties"].(map[string]interface{})
+	assert.Len(t, properties, 12)
+	assert.Equal(t, map[string]interface{}{"type": "string"}, properties["id"], "Embedded struct fields should be flattened")
+	assert.Equal(t, map[string]interface{}{"type": "integer", "minimum": float64(0), "maximum": float64(150)}, properties["age"])
+	assert.Equal(t, map[string]interface{}{"type": "integer", "enum": []interface{}{float64(1), float64(2), float64(3)}}, properties["level"])
+	assert.Equal(t, map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"street": map[string]interface{}{"type": "string"},
+			"city":   map[string]interface{}{"type": "string", "description": "City name"},
+		},
+		"required": []string{"street", "city"},
+	}, properties["address"])
+	assert.Equal(t, "array", properties["previous"].(map[string]interface{})["type"])
+	assert.Equal(t, "object", properties["previous"].(map[string]interface{})["items"].(map[string]interface{})["type"])
+	assert.Equal(t, map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{"type": "string"}}, properties["labels"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "date-time"}, properties["born"])
+	assert.Equal(t, map[string]interface{}{"type": "string"}, properties["nickname"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "contentEncoding": "base64"}, properties["data"])
+	assert.Equal(t, map[string]interface{}{}, properties["extra"])
+	assert.Equal(t, map[string]interface{}{"type": "boolean"}, properties["NoTag"])
+
+	_, err = JSONSchemaFor(reflect.TypeOf(schemaNode{}))
+	assert.ErrorContains(t, err, "recursive type")
+	_, err = JSONSchemaFor(reflect.TypeOf(map[int]string{}))
+	assert.ErrorContains(t, err, "unsupported map key type")
+	_, err = JSONSchemaFor(reflect.TypeOf(struct {
+		Level int `enum:"low"`
+	}{}))
+	assert.ErrorContains(t, err, "invalid enum value")
+}
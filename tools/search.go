@@ -0,0 +1,284 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Harsh-2909/hermes-go/utils"
+)
+
+// SearchResult is a normalized web search result.
+type SearchResult struct {
+	Title         string `json:"title"`
+	URL           string `json:"url"`
+	Snippet       string `json:"snippet,omitempty"`
+	PublishedDate string `json:"published_date,omitempty"` // Publication date as returned by the provider, if known
+}
+
+// SearchProvider is a web search backend.
+type SearchProvider interface {
+	// Search returns at most maxResults results for query.
+	Search(ctx context.Context, query string, maxResults int) ([]SearchResult, error)
+}
+
+// SearchTools provides a web search tool backed by a SearchProvider.
+type SearchTools struct {
+	Provider          SearchProvider // Required search backend
+	DefaultMaxResults int            // Number of results returned when the model does not specify it. Defaults to 5
+	MaxResults        int            // Maximum number of results the model can request. Defaults to 20
+}
+
+// Tools returns the list of tools in the toolkit.
+func (s *SearchTools) Tools() []Tool {
+	var tools []Tool
+	if searchTool, err := CreateToolFromMethod(s, "Search"); err == nil {
+		tools = append(tools, searchTool)
+	} else {
+		utils.Logger.Error("Failed to create tool", "tool", "Search", "error", err)
+	}
+	return tools
+}
+
+// Search searches the web and returns the title, URL, snippet and publication date of the results.
+// @param query: Search query
+// @param [optional] max_results: Maximum number of results. Defaults to 5
+// @return JSON array of results with their title, URL, snippet and publication date
+func (s *SearchTools) Search(ctx context.Context, query string, max_results int) ([]SearchResult, error) {
+	if s.Provider == nil {
+		return nil, fmt.Errorf("no search provider configured")
+	}
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("no query provided")
+	}
+	limit := s.MaxResults
+	if limit <= 0 {
+		limit = 20
+	}
+	if max_results <= 0 {
+		max_results = s.DefaultMaxResults
+		if max_results <= 0 {
+			max_results = 5
+		}
+	}
+	max_results = min(max_results, limit)
+
+	utils.Logger.Debug("Searching the web", "query", query, "max_results", max_results)
+	results, err := s.Provider.Search(ctx, query, max_results)
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+	if len(results) > max_results {
+		results = results[:max_results]
+	}
+	if results == nil {
+		results = []SearchResult{} // Encoded as [] rather than null
+	}
+	return results, nil
+}
+
+// SearxNGProvider searches with the JSON API of a SearxNG instance, which must have the json format enabled.
+type SearxNGProvider struct {
+	BaseURL    string       // Required URL of the instance, e.g. "http://localhost:8888"
+	Categories string       // Comma separated categories, e.g. "general,news"
+	Language   string       // Language of the results, e.g. "en"
+	HTTPClient *http.Client // Defaults to http.DefaultClient
+}
+
+// Search implements SearchProvider.
+func (p *SearxNGProvider) Search(ctx context.Context, query string, maxResults int) ([]SearchResult, error) {
+	if p.BaseURL == "" {
+		return nil, fmt.Errorf("no SearxNG base URL configured")
+	}
+	params := url.Values{"q": {query}, "format": {"json"}}
+	if p.Categories != "" {
+		params.Set("categories", p.Categories)
+	}
+	if p.Language != "" {
+		params.Set("language", p.Language)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(p.BaseURL, "/")+"/search?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	var response struct {
+		Results []struct {
+			Title         string `json:"title"`
+			URL           string `json:"url"`
+			Content       string `json:"content"`
+			PublishedDate string `json:"publishedDate"`
+		} `json:"results"`
+	}
+	if err := doSearchRequest(p.HTTPClient, req, &response); err != nil {
+		return nil, err
+	}
+	results := make([]SearchResult, 0, min(len(response.Results), maxResults))
+	for _, r := range response.Results {
+		if len(results) == maxResults {
+			break
+		}
+		results = append(results, SearchResult{Title: r.Title, URL: r.URL, Snippet: r.Content, PublishedDate: r.PublishedDate})
+	}
+	return results, nil
+}
+
+// BraveProvider searches with the Brave Search web search API.
+type BraveProvider struct {
+	ApiKey     string       // Required subscription token
+	BaseURL    string       // URL of the web search endpoint. Defaults to "https://api.search.brave.com/res/v1/web/search"
+	Country    string       // Country of the results, e.g. "us"
+	HTTPClient *http.Client // Defaults to http.DefaultClient
+}
+
+// Search implements SearchProvider.
+func (p *BraveProvider) Search(ctx context.Context, query string, maxResults int) ([]SearchResult, error) {
+	if p.ApiKey == "" {
+		return nil, fmt.Errorf("no Brave API key configured")
+	}
+	params := url.Values{"q": {query}, "count": {strconv.Itoa(maxResults)}}
+	if p.Country != "" {
+		params.Set("country", p.Country)
+	}
+	endpoint := utils.FirstNonEmpty(p.BaseURL, "https://api.search.brave.com/res/v1/web/search")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Subscription-Token", p.ApiKey)
+
+	var response struct {
+		Web struct {
+			Results []struct {
+				Title       string `json:"title"`
+				URL         string `json:"url"`
+				Description string `json:"description"`
+				PageAge     string `json:"page_age"`
+				Age         string `json:"age"`
+			} `json:"results"`
+		} `json:"web"`
+	}
+	if err := doSearchRequest(p.HTTPClient, req, &response); err != nil {
+		return nil, err
+	}
+	results := make([]SearchResult, 0, min(len(response.Web.Results), maxResults))
+	for _, r := range response.Web.Results {
+		if len(results) == maxResults {
+			break
+		}
+		results = append(results, SearchResult{
+			Title:         r.Title,
+			URL:           r.URL,
+			Snippet:       r.Description,
+			PublishedDate: utils.FirstNonEmpty(r.PageAge, r.Age),
+		})
+	}
+	return results, nil
+}
+
+// TavilyProvider searches with the Tavily search API.
+type TavilyProvider struct {
+	ApiKey      string       // Required API key
+	BaseURL     string       // URL of the search endpoint. Defaults to "https://api.tavily.com/search"
+	SearchDepth string       // "basic" or "advanced". Defaults to the API default
+	Topic       string       // "general" or "news". Defaults to the API default
+	HTTPClient  *http.Client // Defaults to http.DefaultClient
+}
+
+// Search implements SearchProvider.
+func (p *TavilyProvider) Search(ctx context.Context, query string, maxResults int) ([]SearchResult, error) {
+	if p.ApiKey == "" {
+		return nil, fmt.Errorf("no Tavily API key configured")
+	}
+	payload, err := json.Marshal(struct {
+		Query       string `json:"query"`
+		MaxResults  int    `json:"max_results"`
+		SearchDepth string `json:"search_depth,omitempty"`
+		Topic       string `json:"topic,omitempty"`
+	}{query, maxResults, p.SearchDepth, p.Topic})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %v", err)
+	}
+	endpoint := utils.FirstNonEmpty(p.BaseURL, "https://api.tavily.com/search")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.ApiKey)
+
+	var response struct {
+		Results []struct {
+			Title         string `json:"title"`
+			URL           string `json:"url"`
+			Content       string `json:"content"`
+			PublishedDate string `json:"published_date"`
+		} `json:"results"`
+	}
+	if err := doSearchRequest(p.HTTPClient, req, &response); err != nil {
+		return nil, err
+	}
+	results := make([]SearchResult, 0, min(len(response.Results), maxResults))
+	for _, r := range response.Results {
+		if len(results) == maxResults {
+			break
+		}
+		results = append(results, SearchResult{Title: r.Title, URL: r.URL, Snippet: r.Content, PublishedDate: r.PublishedDate})
+	}
+	return results, nil
+}
+
+// doSearchRequest sends a search request and decodes its JSON response into v.
+func doSearchRequest(client *http.Client, req *http.Request, v interface{}) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1000))
+		return fmt.Errorf("search API returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %v", err)
+	}
+	return nil
+}
+
+// FakeSearchProvider is a SearchProvider returning fixed results, for tests.
+type FakeSearchProvider struct {
+	Results []SearchResult // Results returned for every query
+	Err     error          // Error returned instead of the results if set
+
+	mu      sync.Mutex
+	queries []string
+}
+
+// Search implements SearchProvider.
+func (p *FakeSearchProvider) Search(ctx context.Context, query string, maxResults int) ([]SearchResult, error) {
+	p.mu.Lock()
+	p.queries = append(p.queries, query)
+	p.mu.Unlock()
+	if p.Err != nil {
+		return nil, p.Err
+	}
+	return p.Results[:min(len(p.Results), maxResults)], nil
+}
+
+// Queries returns the queries searched so far.
+func (p *FakeSearchProvider) Queries() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.queries...)
+}
@@ -0,0 +1,131 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchTools_Search(t *testing.T) {
+	ctx := context.Background()
+	provider := &FakeSearchProvider{}
+	for i := 1; i <= 30; i++ {
+		provider.Results = append(provider.Results, SearchResult{Title: fmt.Sprintf("Result %d", i), URL: fmt.Sprintf("https://example.com/%d", i)})
+	}
+	search := &SearchTools{Provider: provider, MaxResults: 10}
+
+	testCases := []struct {
+		name       string
+		maxResults int
+		expected   int
+	}{
+		{"Default", 0, 5},
+		{"Requested", 3, 3},
+		{"Capped", 50, 10},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			results, err := search.Search(ctx, "golang", tc.maxResults)
+			assert.NoError(t, err)
+			assert.Len(t, results, tc.expected)
+			assert.Equal(t, "Result 1", results[0].Title)
+		})
+	}
+	assert.Equal(t, []string{"golang", "golang", "golang"}, provider.Queries())
+
+	_, err := search.Search(ctx, " ", 0)
+	assert.EqualError(t, err, "no query provided")
+
+	_, err = (&SearchTools{Provider: &FakeSearchProvider{Err: errors.New("quota exceeded")}}).Search(ctx, "golang", 0)
+	assert.EqualError(t, err, "search failed: quota exceeded")
+
+	results, err := (&SearchTools{Provider: &FakeSearchProvider{}}).Search(ctx, "golang", 0)
+	assert.NoError(t, err)
+	encoded, _ := json.Marshal(results)
+	assert.Equal(t, "[]", string(encoded))
+
+	tools := search.Tools()
+	assert.Len(t, tools, 1)
+	assert.Equal(t, []string{"query"}, tools[0].Parameters["required"])
+	assert.Contains(t, tools[0].Parameters["properties"], "max_results")
+}
+
+func TestSearxNGProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/search", r.URL.Path)
+		assert.Equal(t, "golang", r.URL.Query().Get("q"))
+		assert.Equal(t, "json", r.URL.Query().Get("format"))
+		assert.Equal(t, "news", r.URL.Query().Get("categories"))
+		fmt.Fprint(w, `{"query": "golang", "results": [
+			{"title": "Go", "url": "https://go.dev", "content": "The Go language", "publishedDate": "2024-01-02T00:00:00"},
+			{"title": "Tour", "url": "https://go.dev/tour", "content": "A tour of Go"},
+			{"title": "Extra", "url": "https://example.com"}
+		]}`)
+	}))
+	defer server.Close()
+
+	provider := &SearxNGProvider{BaseURL: server.URL + "/", Categories: "news"}
+	results, err := provider.Search(context.Background(), "golang", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []SearchResult{
+		{Title: "Go", URL: "https://go.dev", Snippet: "The Go language", PublishedDate: "2024-01-02T00:00:00"},
+		{Title: "Tour", URL: "https://go.dev/tour", Snippet: "A tour of Go"},
+	}, results)
+
+	_, err = (&SearxNGProvider{}).Search(context.Background(), "golang", 2)
+	assert.EqualError(t, err, "no SearxNG base URL configured")
+}
+
+func TestBraveProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Subscription-Token") != "key" {
+			http.Error(w, `{"error": "invalid token"}`, http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "golang", r.URL.Query().Get("q"))
+		assert.Equal(t, "3", r.URL.Query().Get("count"))
+		fmt.Fprint(w, `{"web": {"results": [
+			{"title": "Go", "url": "https://go.dev", "description": "The Go language", "page_age": "2024-01-02T00:00:00", "age": "January 2, 2024"},
+			{"title": "Tour", "url": "https://go.dev/tour", "description": "A tour of Go", "age": "3 days ago"}
+		]}}`)
+	}))
+	defer server.Close()
+
+	provider := &BraveProvider{ApiKey: "key", BaseURL: server.URL}
+	results, err := provider.Search(context.Background(), "golang", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []SearchResult{
+		{Title: "Go", URL: "https://go.dev", Snippet: "The Go language", PublishedDate: "2024-01-02T00:00:00"},
+		{Title: "Tour", URL: "https://go.dev/tour", Snippet: "A tour of Go", PublishedDate: "3 days ago"},
+	}, results)
+
+	_, err = (&BraveProvider{ApiKey: "wrong", BaseURL: server.URL}).Search(context.Background(), "golang", 3)
+	assert.ErrorContains(t, err, `search API returned 401 Unauthorized: {"error": "invalid token"}`)
+}
+
+func TestTavilyProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{"query": "golang", "max_results": float64(2), "topic": "news"}, body)
+		fmt.Fprint(w, `{"query": "golang", "results": [
+			{"title": "Go 1.24", "url": "https://go.dev/blog", "content": "Go 1.24 is released", "score": 0.9, "published_date": "Tue, 11 Feb 2025 00:00:00 GMT"}
+		]}`)
+	}))
+	defer server.Close()
+
+	provider := &TavilyProvider{ApiKey: "key", BaseURL: server.URL, Topic: "news"}
+	results, err := provider.Search(context.Background(), "golang", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []SearchResult{
+		{Title: "Go 1.24", URL: "https://go.dev/blog", Snippet: "Go 1.24 is released", PublishedDate: "Tue, 11 Feb 2025 00:00:00 GMT"},
+	}, results)
+}
@@ -0,0 +1,165 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Harsh-2909/hermes-go/utils"
+)
+
+// ShellTools provides a tool for running commands in a controlled way.
+// Commands are executed directly, without a shell, so that pipes, redirections and command chaining
+// cannot bypass the allow-list. Allowing a shell (e.g., "sh" or "bash") allows any command.
+type ShellTools struct {
+	WorkingDirectory string        // Required directory in which commands run
+	AllowedCommands  []string      // Names of the binaries which can be run (e.g., "ls", "git"), looked up in PATH. All binaries not denied if empty
+	DeniedCommands   []string      // Names of the binaries which cannot be run, checked after AllowedCommands
+	Env              []string      // Environment variables of the commands, as "KEY=value"
+	PassEnv          []string      // Names of the environment variables passed from the current process. Defaults to PATH
+	Timeout          time.Duration // Maximum duration of a command. Defaults to 30 seconds
+	MaxOutputSize    int           // Maximum size in bytes of stdout and stderr each; longer output is truncated. Defaults to 10000
+}
+
+// CommandResult is the result of a command run by ShellTools.
+type CommandResult struct {
+	ExitCode int    `json:"exit_code"`           // Exit code of the command, or -1 if it was killed
+	Stdout   string `json:"stdout"`              // Standard output, truncated to MaxOutputSize
+	Stderr   string `json:"stderr"`              // Standard error, truncated to MaxOutputSize
+	TimedOut bool   `json:"timed_out,omitempty"` // True if the command was killed after the timeout
+}
+
+// Tools returns the list of tools in the toolkit.
+func (s *ShellTools) Tools() []Tool {
+	var tools []Tool
+	if runTool, err := CreateToolFromMethod(s, "RunCommand"); err == nil {
+		tools = append(tools, runTool)
+	} else {
+		utils.Logger.Error("Failed to create tool", "tool", "RunCommand", "error", err)
+	}
+	return tools
+}
+
+// RunCommand runs a command with arguments and returns its exit code, stdout and stderr.
+// @param command: Name of the binary to run, e.g. "ls". Shell syntax such as pipes and redirections is not supported
+// @param [optional] args: Arguments of the command, e.g. ["-la", "src"]
+// @return JSON object with the exit code, stdout and stderr of the command
+func (s *ShellTools) RunCommand(ctx context.Context, command string, args []string) (CommandResult, error) {
+	if s.WorkingDirectory == "" {
+		return CommandResult{}, fmt.Errorf("no working directory configured")
+	}
+	if err := s.checkCommand(command); err != nil {
+		return CommandResult{}, err
+	}
+
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	maxOutputSize := s.MaxOutputSize
+	if maxOutputSize <= 0 {
+		maxOutputSize = 10000
+	}
+	stdout := &limitedBuffer{limit: maxOutputSize}
+	stderr := &limitedBuffer{limit: maxOutputSize}
+
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Dir = s.WorkingDirectory
+	cmd.Env = s.environment()
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	// Do not wait forever for children of the command holding the output open after it is killed
+	cmd.WaitDelay = time.Second
+
+	utils.Logger.Debug("Running command", "command", command, "args", args, "dir", s.WorkingDirectory)
+	err := cmd.Run()
+	result := CommandResult{Stdout: stdout.String(), Stderr: stderr.String()}
+	if err != nil {
+		var exitErr *exec.ExitError
+		switch {
+		case ctx.Err() == context.DeadlineExceeded:
+			result.ExitCode = -1
+			result.TimedOut = true
+		case errors.As(err, &exitErr):
+			result.ExitCode = exitErr.ExitCode()
+		case ctx.Err() != nil:
+			return CommandResult{}, fmt.Errorf("command cancelled: %w", ctx.Err())
+		default:
+			return CommandResult{}, fmt.Errorf("failed to run command: %v", err)
+		}
+	}
+	return result, nil
+}
+
+// checkCommand returns an error if the binary of command is not allowed.
+func (s *ShellTools) checkCommand(command string) error {
+	if strings.TrimSpace(command) == "" {
+		return fmt.Errorf("no command provided")
+	}
+	name := filepath.Base(command)
+	// A path would run any binary named like an allowed command, so the lists only accept names looked up in PATH
+	if (len(s.AllowedCommands) > 0 || len(s.DeniedCommands) > 0) && strings.ContainsAny(command, `/\`) {
+		return fmt.Errorf("command %s must be a name without a path", command)
+	}
+	if len(s.AllowedCommands) > 0 && !slices.Contains(s.AllowedCommands, name) {
+		return fmt.Errorf("command %s is not allowed; allowed commands: %s", name, strings.Join(s.AllowedCommands, ", "))
+	}
+	if slices.Contains(s.DeniedCommands, name) {
+		return fmt.Errorf("command %s is not allowed", name)
+	}
+	return nil
+}
+
+// environment returns the scrubbed environment of the commands: the PassEnv variables of the current process and Env.
+func (s *ShellTools) environment() []string {
+	passEnv := s.PassEnv
+	if passEnv == nil {
+		passEnv = []string{"PATH"}
+	}
+	env := make([]string, 0, len(passEnv)+len(s.Env))
+	for _, name := range passEnv {
+		if value, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+value)
+		}
+	}
+	return append(env, s.Env...)
+}
+
+// limitedBuffer is an io.Writer keeping the first limit bytes written to it.
+type limitedBuffer struct {
+	buf     bytes.Buffer
+	limit   int
+	dropped int // Number of bytes written after the limit
+}
+
+// Write keeps the bytes within the limit and counts the others, always reporting success so the command is not interrupted.
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - b.buf.Len(); remaining > 0 {
+		if len(p) <= remaining {
+			return b.buf.Write(p)
+		}
+		b.buf.Write(p[:remaining])
+		b.dropped += len(p) - remaining
+		return len(p), nil
+	}
+	b.dropped += len(p)
+	return len(p), nil
+}
+
+// String returns the kept output, with a marker if it was truncated.
+func (b *limitedBuffer) String() string {
+	if b.dropped > 0 {
+		return b.buf.String() + fmt.Sprintf("\n[truncated %d bytes]", b.dropped)
+	}
+	return b.buf.String()
+}
@@ -0,0 +1,95 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShellTools_RunCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+	ctx := context.Background()
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "hello.txt"), []byte("hello"), 0644))
+	t.Setenv("SHELL_TOOLS_SECRET", "secret")
+	shell := &ShellTools{
+		WorkingDirectory: dir,
+		DeniedCommands:   []string{"rm"},
+		Env:              []string{"GREETING=hi"},
+		MaxOutputSize:    20,
+		Timeout:          200 * time.Millisecond,
+	}
+
+	testCases := []struct {
+		name     string
+		command  string
+		args     []string
+		expected CommandResult
+		err      string
+	}{
+		{"Working directory", "cat", []string{"hello.txt"}, CommandResult{Stdout: "hello"}, ""},
+		{"Exit code and stderr", "sh", []string{"-c", "echo oops >&2; exit 3"}, CommandResult{ExitCode: 3, Stderr: "oops\n"}, ""},
+		{"Scrubbed environment", "sh", []string{"-c", "echo $GREETING $SHELL_TOOLS_SECRET"}, CommandResult{Stdout: "hi\n"}, ""},
+		{"Truncated output", "sh", []string{"-c", "printf '%030d' 0"}, CommandResult{Stdout: "00000000000000000000\n[truncated 10 bytes]"}, ""},
+		{"Timeout", "sleep", []string{"5"}, CommandResult{ExitCode: -1, TimedOut: true}, ""},
+		{"Denied command", "rm", []string{"hello.txt"}, CommandResult{}, "command rm is not allowed"},
+		{"Denied command by path", "/bin/rm", []string{"hello.txt"}, CommandResult{}, "command /bin/rm must be a name without a path"},
+		{"Unknown command", "hermes-unknown-command", nil, CommandResult{}, "failed to run command"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := shell.RunCommand(ctx, tc.command, tc.args)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+	_, err := os.Stat(filepath.Join(dir, "hello.txt"))
+	assert.NoError(t, err, "Denied commands should not run")
+}
+
+func TestShellTools_AllowedCommands(t *testing.T) {
+	shell := &ShellTools{WorkingDirectory: t.TempDir(), AllowedCommands: []string{"echo", "ls"}}
+	_, err := shell.RunCommand(context.Background(), "cat", []string{"/etc/passwd"})
+	assert.EqualError(t, err, "command cat is not allowed; allowed commands: echo, ls")
+	// Binaries named like an allowed command are rejected when given by path
+	_, err = shell.RunCommand(context.Background(), "./ls", nil)
+	assert.EqualError(t, err, "command ./ls must be a name without a path")
+	_, err = shell.RunCommand(context.Background(), "/tmp/evil/ls", nil)
+	assert.EqualError(t, err, "command /tmp/evil/ls must be a name without a path")
+	_, err = shell.RunCommand(context.Background(), "", nil)
+	assert.EqualError(t, err, "no command provided")
+	_, err = (&ShellTools{}).RunCommand(context.Background(), "echo", nil)
+	assert.EqualError(t, err, "no working directory configured")
+}
+
+func TestShellTools_Tool(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires echo")
+	}
+	shell := &ShellTools{WorkingDirectory: t.TempDir(), AllowedCommands: []string{"echo"}}
+	toolList := shell.Tools()
+	assert.Len(t, toolList, 1)
+	assert.Equal(t, "RunCommand", toolList[0].Name)
+	assert.Equal(t, []string{"command"}, toolList[0].Parameters["required"])
+
+	// The result is returned as structured JSON
+	output, err := toolList[0].Execute(context.Background(), `{"command": "echo", "args": ["hello", "world"]}`)
+	assert.NoError(t, err)
+	var result map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(output), &result))
+	assert.Equal(t, map[string]interface{}{"exit_code": float64(0), "stdout": "hello world\n", "stderr": ""}, result)
+	assert.True(t, strings.HasPrefix(output, `{"exit_code":0`))
+}
@@ -0,0 +1,529 @@
+package tools
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Harsh-2909/hermes-go/utils"
+)
+
+// SQLTools provides tools for exploring and querying a database.
+// Queries are read-only unless AllowWrites is set: statements are checked before they run, and they run
+// in a read-only transaction which is always rolled back, so that writes missed by the check are undone.
+type SQLTools struct {
+	DB                  *sql.DB       // Required database
+	Dialect             string        // SQL dialect: "sqlite", "postgres" or "mysql". Detected from the driver if empty
+	EnableListTables    bool          // Enable the ListTables tool
+	EnableDescribeTable bool          // Enable the DescribeTable tool
+	EnableRunQuery      bool          // Enable the RunQuery tool
+	EnableAll           bool          // Enable all tools if true
+	AllowWrites         bool          // If true, RunQuery can run statements modifying the database, and commits them
+	MaxRows             int           // Maximum number of rows returned by RunQuery. Defaults to 100
+	DefaultFormat       string        // Format of the RunQuery results when the model does not specify it: "markdown" or "json". Defaults to "markdown"
+	Timeout             time.Duration // Maximum duration of a query. Defaults to 30 seconds
+}
+
+// ColumnInfo describes a column of a table.
+type ColumnInfo struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Nullable   bool   `json:"nullable"`
+	Default    string `json:"default,omitempty"`
+	PrimaryKey bool   `json:"primary_key,omitempty"`
+}
+
+// TableDescription is the result of the DescribeTable tool.
+type TableDescription struct {
+	Table   string       `json:"table"`
+	Columns []ColumnInfo `json:"columns"`
+}
+
+// QueryResult is the result of the RunQuery tool in the json format.
+type QueryResult struct {
+	Columns      []string        `json:"columns,omitempty"`
+	Rows         [][]interface{} `json:"rows,omitempty"`
+	Truncated    bool            `json:"truncated,omitempty"`     // True if there were more than MaxRows rows
+	RowsAffected int64           `json:"rows_affected,omitempty"` // Number of rows modified by a write statement
+}
+
+// Tools returns a list of available tools based on enable flags.
+func (s *SQLTools) Tools() []Tool {
+	var tools []Tool
+
+	if s.EnableListTables || s.EnableAll {
+		if listTool, err := CreateToolFromMethod(s, "ListTables"); err == nil {
+			tools = append(tools, listTool)
+		} else {
+			utils.Logger.Error("Failed to create tool", "tool", "ListTables", "error", err)
+		}
+	}
+
+	if s.EnableDescribeTable || s.EnableAll {
+		if describeTool, err := CreateToolFromMethod(s, "DescribeTable"); err == nil {
+			tools = append(tools, describeTool)
+		} else {
+			utils.Logger.Error("Failed to create tool", "tool", "DescribeTable", "error", err)
+		}
+	}
+
+	if s.EnableRunQuery || s.EnableAll {
+		if queryTool, err := CreateToolFromMethod(s, "RunQuery"); err == nil {
+			tools = append(tools, queryTool)
+		} else {
+			utils.Logger.Error("Failed to create tool", "tool", "RunQuery", "error", err)
+		}
+	}
+
+	return tools
+}
+
+// ListTables lists the tables and views of the database.
+// @return JSON array of table names
+func (s *SQLTools) ListTables(ctx context.Context) ([]string, error) {
+	if s.DB == nil {
+		return nil, fmt.Errorf("no database configured")
+	}
+	var query string
+	switch dialect := s.dialect(); dialect {
+	case "sqlite":
+		query = "SELECT name FROM sqlite_master WHERE type IN ('table', 'view') AND name NOT LIKE 'sqlite_%' ORDER BY name"
+	case "postgres":
+		query = "SELECT table_name FROM information_schema.tables WHERE table_schema = ANY (current_schemas(false)) ORDER BY table_name"
+	case "mysql":
+		query = "SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE() ORDER BY table_name"
+	default:
+		return nil, fmt.Errorf("unsupported SQL dialect %q", dialect)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout())
+	defer cancel()
+	rows, err := s.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %v", err)
+	}
+	defer rows.Close()
+	tables := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to list tables: %v", err)
+		}
+		tables = append(tables, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list tables: %v", err)
+	}
+	return tables, nil
+}
+
+// DescribeTable describes the columns of a table.
+// @param table: Name of the table
+// @return JSON object with the name, type, nullability, default value and primary key flag of each column
+func (s *SQLTools) DescribeTable(ctx context.Context, table string) (TableDescription, error) {
+	tables, err := s.ListTables(ctx)
+	if err != nil {
+		return TableDescription{}, err
+	}
+	if !slices.Contains(tables, table) {
+		return TableDescription{}, fmt.Errorf("table %s not found; available tables: %s", table, strings.Join(tables, ", "))
+	}
+
+	var query string
+	switch s.dialect() {
+	case "sqlite":
+		query = `SELECT name, type, "notnull" = 0, COALESCE(dflt_value, ''), pk > 0 FROM pragma_table_info(?) ORDER BY cid`
+	case "postgres":
+		query = `SELECT c.column_name, c.data_type, c.is_nullable = 'YES', COALESCE(c.column_default, ''),
+			EXISTS (SELECT 1 FROM information_schema.table_constraints tc
+				JOIN information_schema.key_column_usage k ON tc.constraint_name = k.constraint_name AND tc.table_schema = k.table_schema
+				WHERE tc.constraint_type = 'PRIMARY KEY' AND tc.table_name = c.table_name AND tc.table_schema = c.table_schema AND k.column_name = c.column_name)
+			FROM information_schema.columns c
+			WHERE c.table_name = $1 AND c.table_schema = ANY (current_schemas(false)) ORDER BY c.ordinal_position`
+	case "mysql":
+		query = `SELECT column_name, column_type, is_nullable = 'YES', COALESCE(column_default, ''), column_key = 'PRI'
+			FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? ORDER BY ordinal_position`
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout())
+	defer cancel()
+	rows, err := s.DB.QueryContext(ctx, query, table)
+	if err != nil {
+		return TableDescription{}, fmt.Errorf("failed to describe table: %v", err)
+	}
+	defer rows.Close()
+	description := TableDescription{Table: table, Columns: []ColumnInfo{}}
+	for rows.Next() {
+		var column ColumnInfo
+		if err := rows.Scan(&column.Name, &column.Type, &column.Nullable, &column.Default, &column.PrimaryKey); err != nil {
+			return TableDescription{}, fmt.Errorf("failed to describe table: %v", err)
+		}
+		description.Columns = append(description.Columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return TableDescription{}, fmt.Errorf("failed to describe table: %v", err)
+	}
+	return description, nil
+}
+
+// RunQuery runs an SQL query and returns its results.
+// @param query: A single SQL statement, e.g. "SELECT name, price FROM products WHERE price > 10"
+// @param [optional] format: Format of the results. Defaults to markdown
+// @enum format: markdown, json
+// @return Results as a Markdown table, or a JSON object with the columns and rows
+func (s *SQLTools) RunQuery(ctx context.Context, query string, format string) (interface{}, error) {
+	if s.DB == nil {
+		return nil, fmt.Errorf("no database configured")
+	}
+	format = strings.ToLower(utils.FirstNonEmpty(format, s.DefaultFormat, "markdown"))
+	if format != "markdown" && format != "json" {
+		return nil, fmt.Errorf("invalid format %q: expected markdown or json", format)
+	}
+	tokens, err := checkSingleStatement(query, s.dialect())
+	if err != nil {
+		return nil, err
+	}
+	isRead := slices.Contains(readStatements, leadingKeyword(tokens))
+	if !s.AllowWrites {
+		if err := checkReadOnly(tokens); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout())
+	defer cancel()
+	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{ReadOnly: !s.AllowWrites})
+	if err != nil && !s.AllowWrites {
+		// Not all drivers support read-only transactions, and the rollback still undoes writes
+		tx, err = s.DB.BeginTx(ctx, nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	utils.Logger.Debug("Running SQL query", "query", query)
+	var result QueryResult
+	if isRead {
+		result, err = s.query(ctx, tx, query)
+	} else {
+		var res sql.Result
+		if res, err = tx.ExecContext(ctx, query); err == nil {
+			result.RowsAffected, _ = res.RowsAffected()
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %v", err)
+	}
+	if s.AllowWrites {
+		if err := tx.Commit(); err != nil {
+			return nil, fmt.Errorf("failed to commit: %v", err)
+		}
+	}
+
+	if format == "json" {
+		return result, nil
+	}
+	if !isRead {
+		return fmt.Sprintf("Statement executed, %d rows affected", result.RowsAffected), nil
+	}
+	return formatMarkdownTable(result, s.maxRows()), nil
+}
+
+// query runs a query in tx and reads at most MaxRows rows.
+func (s *SQLTools) query(ctx context.Context, tx *sql.Tx, query string) (QueryResult, error) {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return QueryResult{}, err
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return QueryResult{}, err
+	}
+	result := QueryResult{Columns: columns, Rows: [][]interface{}{}}
+	for rows.Next() {
+		if len(result.Rows) == s.maxRows() {
+			result.Truncated = true
+			break
+		}
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return QueryResult{}, err
+		}
+		for i, value := range values {
+			values[i] = normalizeSQLValue(value)
+		}
+		result.Rows = append(result.Rows, values)
+	}
+	return result, rows.Err()
+}
+
+// dialect returns the SQL dialect of the database.
+func (s *SQLTools) dialect() string {
+	if s.Dialect != "" {
+		return strings.ToLower(s.Dialect)
+	}
+	driver := strings.ToLower(fmt.Sprintf("%T", s.DB.Driver()))
+	switch {
+	case strings.Contains(driver, "sqlite"):
+		return "sqlite"
+	case strings.Contains(driver, "pq.") || strings.Contains(driver, "pgx") || strings.Contains(driver, "stdlib.") || strings.Contains(driver, "postgres"):
+		return "postgres"
+	case strings.Contains(driver, "mysql"):
+		return "mysql"
+	}
+	return driver
+}
+
+// maxRows returns the maximum number of rows returned by RunQuery.
+func (s *SQLTools) maxRows() int {
+	if s.MaxRows <= 0 {
+		return 100
+	}
+	return s.MaxRows
+}
+
+// timeout returns the maximum duration of a query.
+func (s *SQLTools) timeout() time.Duration {
+	if s.Timeout <= 0 {
+		return 30 * time.Second
+	}
+	return s.Timeout
+}
+
+// normalizeSQLValue converts a scanned value to a JSON friendly value.
+func normalizeSQLValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []byte:
+		if utf8.Valid(v) {
+			return string(v)
+		}
+		return fmt.Sprintf("<%d bytes>", len(v))
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return v
+	}
+}
+
+// formatMarkdownTable formats query results as a Markdown table.
+func formatMarkdownTable(result QueryResult, maxRows int) string {
+	if len(result.Rows) == 0 {
+		return "No rows"
+	}
+	escape := func(value interface{}) string {
+		if value == nil {
+			return "NULL"
+		}
+		s := strings.ReplaceAll(fmt.Sprint(value), "|", `\|`)
+		return strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", " "), "\n", " ")
+	}
+	var sb strings.Builder
+	header := make([]string, len(result.Columns))
+	for i, column := range result.Columns {
+		header[i] = escape(column)
+	}
+	sb.WriteString("| " + strings.Join(header, " | ") + " |\n")
+	sb.WriteString(strings.Repeat("| --- ", len(result.Columns)) + "|\n")
+	for _, row := range result.Rows {
+		cells := make([]string, len(row))
+		for i, value := range row {
+			cells[i] = escape(value)
+		}
+		sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	if result.Truncated {
+		sb.WriteString(fmt.Sprintf("[Showing the first %d rows]\n", maxRows))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// readStatements are the keywords starting read-only statements.
+var readStatements = []string{"select", "with", "values", "explain", "show", "describe", "desc"}
+
+// writeStatements are the keywords starting statements modifying the database or its schema.
+var writeStatements = []string{
+	"insert", "update", "delete", "merge", "upsert", "replace", "create", "alter", "drop", "truncate", "rename",
+	"grant", "revoke", "attach", "detach", "vacuum", "reindex", "copy", "call", "do", "lock", "set", "pragma",
+}
+
+// leadingKeyword returns the first keyword of a statement, skipping the parentheses of e.g. "(SELECT 1) UNION SELECT 2".
+func leadingKeyword(tokens []string) string {
+	for _, token := range tokens {
+		if token != "(" {
+			return token
+		}
+	}
+	return ""
+}
+
+// checkReadOnly returns an error unless the tokens of a statement make it a read-only statement.
+// Statements are classified by their leading keyword, so that other keywords may be used as identifiers,
+// and statements nested in parentheses, SELECT INTO and row locks are rejected.
+func checkReadOnly(tokens []string) error {
+	statement := leadingKeyword(tokens)
+	if statement == "explain" {
+		// EXPLAIN ANALYZE runs the explained statement, so it is classified instead
+		for _, token := range tokens[1:] {
+			if slices.Contains(readStatements, token) || slices.Contains(writeStatements, token) {
+				statement = token
+				break
+			}
+		}
+	}
+	if statement == "with" {
+		// The main statement follows the closing parenthesis of the last common table expression
+		statement = ""
+		depth := 0
+		for i := 1; i < len(tokens) && statement == ""; i++ {
+			switch token := tokens[i]; {
+			case token == "(":
+				depth++
+			case token == ")":
+				depth--
+			case depth == 0 && tokens[i-1] == ")" && token != "as" && token != ",":
+				statement = token
+			}
+		}
+	}
+	if !slices.Contains(readStatements, statement) {
+		return fmt.Errorf("only read-only statements are allowed, got %s", strings.ToUpper(utils.FirstNonEmpty(statement, leadingKeyword(tokens))))
+	}
+	for i := 1; i < len(tokens); i++ {
+		token, previous := tokens[i], tokens[i-1]
+		switch {
+		case token == "into" && previous != ".":
+			return fmt.Errorf("only read-only statements are allowed, but the query contains INTO")
+		case previous == "(" && slices.Contains([]string{"insert", "update", "delete", "merge"}, token):
+			return fmt.Errorf("only read-only statements are allowed, but the query contains %s", strings.ToUpper(token))
+		case token == "update" && (previous == "for" || previous == "key"):
+			return fmt.Errorf("only read-only statements are allowed, but the query contains FOR UPDATE")
+		}
+	}
+	return nil
+}
+
+// checkSingleStatement returns the tokens of an SQL statement: lowercase keywords and identifiers, parentheses,
+// commas and dots, with "'" in place of string literals and quoted identifiers, including SQLite [identifiers].
+// Comments are skipped, including MySQL # comments.
+// Backslashes escape quotes in MySQL strings and in PostgreSQL escape strings such as E'it\'s'.
+// It returns an error if the query is empty or has several statements.
+func checkSingleStatement(query string, dialect string) ([]string, error) {
+	var tokens []string
+	ended := false // True after a semicolon ending the statement
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case (c == '-' && strings.HasPrefix(query[i:], "--")) || (c == '#' && dialect == "mysql"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			i += end
+			continue
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			i += end + 4
+			continue
+		case c == ';':
+			ended = true
+			i++
+			continue
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+		}
+
+		if ended {
+			return nil, fmt.Errorf("only a single statement is allowed")
+		}
+		switch {
+		case c == '[' && dialect == "sqlite":
+			// SQLite identifier quoted with brackets, which may contain quotes
+			end := strings.IndexByte(query[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quote")
+			}
+			tokens = append(tokens, "'")
+			i += end + 1
+		case c == '\'' || c == '"' || c == '`':
+			// String literal or quoted identifier, where doubled quotes are escaped quotes
+			backslashEscapes := c != '`' && dialect == "mysql"
+			if c == '\'' && dialect == "postgres" && len(tokens) > 0 && tokens[len(tokens)-1] == "e" && i > 0 && (query[i-1] == 'e' || query[i-1] == 'E') {
+				// PostgreSQL escape string, e.g. E'it\'s', read as the "e" word followed by the quote
+				tokens = tokens[:len(tokens)-1]
+				backslashEscapes = true
+			}
+			j := i + 1
+			for ; j < len(query); j++ {
+				if backslashEscapes && query[j] == '\\' {
+					j++
+					continue
+				}
+				if query[j] == c {
+					if j+1 < len(query) && query[j+1] == c {
+						j++
+						continue
+					}
+					break
+				}
+			}
+			if j >= len(query) {
+				return nil, fmt.Errorf("unterminated quote")
+			}
+			tokens = append(tokens, "'")
+			i = j + 1
+		case c == '$' && dollarQuoteTag(query[i:]) != "":
+			// PostgreSQL dollar-quoted string, e.g. $$text$$ or $tag$text$tag$
+			tag := dollarQuoteTag(query[i:])
+			end := strings.Index(query[i+len(tag):], tag)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated dollar-quoted string")
+			}
+			tokens = append(tokens, "'")
+			i += 2*len(tag) + end
+		case c == '_' || unicode.IsLetter(rune(c)):
+			j := i
+			for j < len(query) && (query[j] == '_' || query[j] == '$' || unicode.IsLetter(rune(query[j])) || unicode.IsDigit(rune(query[j]))) {
+				j++
+			}
+			tokens = append(tokens, strings.ToLower(query[i:j]))
+			i = j
+		case c == '(' || c == ')' || c == ',' || c == '.':
+			tokens = append(tokens, string(c))
+			i++
+		default:
+			i++
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("no query provided")
+	}
+	return tokens, nil
+}
+
+// dollarQuoteTag returns the tag opening a dollar-quoted string at the start of s, or "".
+func dollarQuoteTag(s string) string {
+	for j := 1; j < len(s); j++ {
+		if s[j] == '$' {
+			return s[:j+1]
+		}
+		if s[j] != '_' && !unicode.IsLetter(rune(s[j])) && !(j > 1 && unicode.IsDigit(rune(s[j]))) {
+			return ""
+		}
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+//go:build cgo
+
+package tools
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", "file:"+t.TempDir()+"/test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	_, err = db.Exec(`
+		CREATE TABLE products (id INTEGER PRIMARY KEY, name TEXT NOT NULL, price REAL, category TEXT DEFAULT 'misc');
+		CREATE VIEW cheap AS SELECT name FROM products WHERE price < 5;
+		INSERT INTO products (name, price, category) VALUES ('pen', 1.5, 'office'), ('lamp', 25, 'home'), ('mug', 4, NULL);
+	`)
+	require.NoError(t, err)
+	return db
+}
+
+func TestSQLTools_ListAndDescribe(t *testing.T) {
+	ctx := context.Background()
+	sqlTools := &SQLTools{DB: newTestDB(t)}
+
+	tables, err := sqlTools.ListTables(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cheap", "products"}, tables)
+
+	description, err := sqlTools.DescribeTable(ctx, "products")
+	assert.NoError(t, err)
+	assert.Equal(t, TableDescription{Table: "products", Columns: []ColumnInfo{
+		{Name: "id", Type: "INTEGER", Nullable: true, PrimaryKey: true},
+		{Name: "name", Type: "TEXT", Nullable: false},
+		{Name: "price", Type: "REAL", Nullable: true},
+		{Name: "category", Type: "TEXT", Nullable: true, Default: "'misc'"},
+	}}, description)
+
+	_, err = sqlTools.DescribeTable(ctx, "products; DROP TABLE products")
+	assert.EqualError(t, err, "table products; DROP TABLE products not found; available tables: cheap, products")
+}
+
+func TestSQLTools_RunQuery(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	sqlTools := &SQLTools{DB: db, MaxRows: 2}
+
+	result, err := sqlTools.RunQuery(ctx, "SELECT name, price, category FROM products ORDER BY id", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "| name | price | category |\n| --- | --- | --- |\n| pen | 1.5 | office |\n| lamp | 25 | home |\n[Showing the first 2 rows]", result)
+
+	result, err = sqlTools.RunQuery(ctx, "SELECT name, category FROM products WHERE id = 3", "json")
+	assert.NoError(t, err)
+	encoded, _ := json.Marshal(result)
+	assert.JSONEq(t, `{"columns": ["name", "category"], "rows": [["mug", null]]}`, string(encoded))
+
+	result, err = sqlTools.RunQuery(ctx, "SELECT name FROM products WHERE price > 100", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "No rows", result)
+
+	_, err = sqlTools.RunQuery(ctx, "DELETE FROM products", "")
+	assert.EqualError(t, err, "only read-only statements are allowed, got DELETE")
+
+	_, err = sqlTools.RunQuery(ctx, "SELECT missing FROM products", "")
+	assert.ErrorContains(t, err, "query failed: no such column: missing")
+
+	_, err = sqlTools.RunQuery(ctx, "SELECT 1", "xml")
+	assert.EqualError(t, err, `invalid format "xml": expected markdown or json`)
+
+	var count int
+	assert.NoError(t, db.QueryRow("SELECT count(*) FROM products").Scan(&count))
+	assert.Equal(t, 3, count)
+}
+
+func TestSQLTools_AllowWrites(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	sqlTools := &SQLTools{DB: db, AllowWrites: true}
+
+	result, err := sqlTools.RunQuery(ctx, "UPDATE products SET price = price * 2 WHERE price < 5", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "Statement executed, 2 rows affected", result)
+
+	var price float64
+	assert.NoError(t, db.QueryRow("SELECT price FROM products WHERE name = 'pen'").Scan(&price))
+	assert.Equal(t, 3.0, price)
+
+	_, err = sqlTools.RunQuery(ctx, "DELETE FROM products; DROP TABLE products", "")
+	assert.EqualError(t, err, "only a single statement is allowed")
+}
+
+func TestSQLTools_Tools(t *testing.T) {
+	sqlTools := &SQLTools{DB: newTestDB(t), EnableAll: true}
+	tools := sqlTools.Tools()
+	assert.Len(t, tools, 3)
+
+	result, err := tools[2].Execute(context.Background(), `{"query": "SELECT name FROM cheap ORDER BY name", "format": "json"}`)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"columns": ["name"], "rows": [["mug"], ["pen"]]}`, result)
+}
@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckSQLStatement(t *testing.T) {
+	testCases := []struct {
+		name    string
+		dialect string
+		query   string
+		err     string
+	}{
+		{"Select", "", "SELECT * FROM users WHERE name = 'x'", ""},
+		{"Trailing semicolon", "", "SELECT 1;  -- done", ""},
+		{"CTE", "", "WITH recent AS (SELECT * FROM orders) SELECT count(*) FROM recent", ""},
+		{"Keywords in strings and identifiers", "", `SELECT 'DROP TABLE users; DELETE', "update", deleted_at FROM t`, ""},
+		{"Keywords in comments", "", "SELECT 1 /* INSERT */ -- ; DROP TABLE users", ""},
+		{"Escaped quote", "", "SELECT 'it''s; DROP' FROM t", ""},
+		{"Dollar quoted", "", "SELECT $tag$ ; DELETE $tag$", ""},
+		{"Replace function", "", "SELECT replace(name, 'a', 'b') FROM t", ""},
+		{"Insert", "", "INSERT INTO users VALUES (1)", "only read-only statements are allowed, got INSERT"},
+		{"Lowercase", "", "drop table users", "only read-only statements are allowed, got DROP"},
+		{"Multiple statements", "", "SELECT 1; DROP TABLE users", "only a single statement is allowed"},
+		{"Hidden statement", "", "SELECT 1;/* x */DELETE FROM users", "only a single statement is allowed"},
+		{"Writing CTE", "", "WITH gone AS (DELETE FROM users RETURNING *) SELECT * FROM gone", "only read-only statements are allowed, but the query contains DELETE"},
+		{"Select into", "", "SELECT * INTO backup FROM users", "only read-only statements are allowed, but the query contains INTO"},
+		{"Pragma", "", "PRAGMA writable_schema = 1", "only read-only statements are allowed, got PRAGMA"},
+		{"Keywords as identifiers", "", "SELECT set, copy, lock, call, do FROM actions WHERE rename = 1", ""},
+		{"Qualified keywords", "postgres", "SELECT t.update, t.into FROM t", ""},
+		{"Parenthesized union", "", "(SELECT 1) UNION (SELECT 2)", ""},
+		{"Recursive CTE with columns", "", "WITH RECURSIVE n (i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < 5), m AS MATERIALIZED (SELECT * FROM n) SELECT * FROM m", ""},
+		{"CTE before a write", "", "WITH old AS (SELECT id FROM users) DELETE FROM users WHERE id IN (SELECT id FROM old)", "only read-only statements are allowed, got DELETE"},
+		{"Select into variable", "mysql", "SELECT count(*) INTO @total FROM users", "only read-only statements are allowed, but the query contains INTO"},
+		{"Row lock", "", "SELECT * FROM users FOR UPDATE", "only read-only statements are allowed, but the query contains FOR UPDATE"},
+		{"Explain analyze", "postgres", "EXPLAIN (ANALYZE, FORMAT JSON) DELETE FROM users", "only read-only statements are allowed, got DELETE"},
+		{"Explain", "postgres", "EXPLAIN ANALYZE SELECT * FROM users", ""},
+		{"MySQL backslash escape", "mysql", `SELECT 'it\'s; DROP TABLE users; --' FROM t`, ""},
+		{"MySQL backslash hiding a statement", "mysql", `SELECT 'it\'s'; DROP TABLE users; -- '`, "only a single statement is allowed"},
+		{"Backslash without escapes", "sqlite", `SELECT 'C:\'; DROP TABLE users; -- '`, "only a single statement is allowed"},
+		{"PostgreSQL escape string", "postgres", `SELECT E'it\'s'; DROP TABLE users; -- '`, "only a single statement is allowed"},
+		{"SQLite bracket identifiers", "sqlite", "SELECT [order], [it's] FROM [my table]", ""},
+		{"SQLite brackets hiding a statement", "sqlite", "SELECT 1 AS [x'y]; COMMIT; DELETE FROM products RETURNING 1 AS [z'w]", "only a single statement is allowed"},
+		{"MySQL hash comment", "mysql", "SELECT 1 # it's a comment; DROP TABLE users", ""},
+		{"MySQL hash comment hiding a statement", "mysql", "SELECT 1 # it's\n; DROP TABLE users; -- '", "only a single statement is allowed"},
+		{"Empty", "", " -- nothing\n", "no query provided"},
+		{"Unterminated quote", "", "SELECT 'x", "unterminated quote"},
+		{"Unterminated comment", "", "SELECT 1 /* x", "unterminated comment"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tokens, err := checkSingleStatement(tc.query, tc.dialect)
+			if err == nil {
+				err = checkReadOnly(tokens)
+			}
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestFormatMarkdownTable(t *testing.T) {
+	result := QueryResult{
+		Columns:   []string{"name", "note"},
+		Rows:      [][]interface{}{{"a|b", nil}, {"c", "line\nbreak"}},
+		Truncated: true,
+	}
+	expected := "| name | note |\n| --- | --- |\n| a\\|b | NULL |\n| c | line break |\n[Showing the first 2 rows]"
+	assert.Equal(t, expected, formatMarkdownTable(result, 2))
+	assert.Equal(t, "No rows", formatMarkdownTable(QueryResult{Columns: []string{"name"}}, 2))
+}
@@ -0,0 +1,373 @@
+// Code generated by toolgen. DO NOT EDIT.
+
+package tools
+
+func init() {
+	RegisterToolMetadata((*CalculatorTools)(nil), "Add", ToolMetadata{
+		Description: "Add two numbers and return the result.",
+		Params: []ParamMetadata{
+			{Name: "a", Description: "The first number to add", Required: true},
+			{Name: "b", Description: "The second number to add", Required: true},
+		},
+	})
+	RegisterToolMetadata((*CalculatorTools)(nil), "Divide", ToolMetadata{
+		Description: "Divide two numbers and return the result.",
+		Params: []ParamMetadata{
+			{Name: "a", Description: "The first number", Required: true},
+			{Name: "b", Description: "The second number", Required: true},
+		},
+	})
+	RegisterToolMetadata((*CalculatorTools)(nil), "Evaluate", ToolMetadata{
+		Description: "Evaluate evaluates a mathematical expression and returns the result.",
+		Params: []ParamMetadata{
+			{Name: "expression", Description: "Expression with +, -, *, /, % (modulo), ^ (power), ! (factorial) and parentheses, e.g. \"2 * (3 + sqrt(x)) ^ 2\". Functions: sin, cos, tan, asin, acos, atan, atan2, sinh, cosh, tanh, sqrt, cbrt, abs, exp, ln, log (base 10, or log(x, base)), log2, log10, pow, hypot, round (round(x, digits)), floor, ceil, trunc, min, max, sign, factorial, degrees, radians. Constants: pi, e, tau, phi. Angles are in radians", Required: true},
+			{Name: "variables", Description: "Values of the variables used in the expression, e.g. {\"x\": 4}", Required: false},
+		},
+	})
+	RegisterToolMetadata((*CalculatorTools)(nil), "Exponentiate", ToolMetadata{
+		Description: "Exponentiate returns base raised to the power exp.",
+		Params: []ParamMetadata{
+			{Name: "base", Description: "The base number", Required: true},
+			{Name: "exp", Description: "The exponent", Required: true},
+		},
+	})
+	RegisterToolMetadata((*CalculatorTools)(nil), "Factorial", ToolMetadata{
+		Description: "Factorial returns the factorial of n.",
+		Params: []ParamMetadata{
+			{Name: "n", Description: "The number to compute factorial for", Required: true},
+		},
+	})
+	RegisterToolMetadata((*CalculatorTools)(nil), "IsPrime", ToolMetadata{
+		Description: "IsPrime determines if n is a prime number.",
+		Params: []ParamMetadata{
+			{Name: "n", Description: "The number to check", Required: true},
+		},
+	})
+	RegisterToolMetadata((*CalculatorTools)(nil), "Modulus", ToolMetadata{
+		Description: "Modulus two numbers and return the result.",
+		Params: []ParamMetadata{
+			{Name: "a", Description: "The first number", Required: true},
+			{Name: "b", Description: "The second number", Required: true},
+		},
+	})
+	RegisterToolMetadata((*CalculatorTools)(nil), "Multiply", ToolMetadata{
+		Description: "Multiply two numbers and return the result.",
+		Params: []ParamMetadata{
+			{Name: "a", Description: "The first number", Required: true},
+			{Name: "b", Description: "The second number", Required: true},
+		},
+	})
+	RegisterToolMetadata((*CalculatorTools)(nil), "Percentile", ToolMetadata{
+		Description: "Percentile computes a percentile of numbers, interpolating linearly between the closest ranks.",
+		Params: []ParamMetadata{
+			{Name: "numbers", Description: "The numbers, e.g. [1, 2, 3.5]", Required: true},
+			{Name: "percentile", Description: "The percentile between 0 and 100, e.g. 90", Required: true},
+		},
+	})
+	RegisterToolMetadata((*CalculatorTools)(nil), "SquareRoot", ToolMetadata{
+		Description: "SquareRoot returns the square root of x.",
+		Params: []ParamMetadata{
+			{Name: "x", Description: "The number to find the square root of", Required: true},
+		},
+	})
+	RegisterToolMetadata((*CalculatorTools)(nil), "Statistics", ToolMetadata{
+		Description: "Statistics computes descriptive statistics of numbers.",
+		Params: []ParamMetadata{
+			{Name: "numbers", Description: "The numbers, e.g. [1, 2, 3.5]", Required: true},
+		},
+	})
+	RegisterToolMetadata((*CalculatorTools)(nil), "Subtract", ToolMetadata{
+		Description: "Subtract two numbers and return the result.",
+		Params: []ParamMetadata{
+			{Name: "a", Description: "The first number", Required: true},
+			{Name: "b", Description: "The second number", Required: true},
+		},
+	})
+	RegisterToolMetadata((*DataTools)(nil), "DescribeData", ToolMetadata{
+		Description: "DescribeData describes the columns of a loaded table.",
+		Params: []ParamMetadata{
+			{Name: "table", Description: "Name of the table", Required: true},
+		},
+	})
+	RegisterToolMetadata((*DataTools)(nil), "LoadData", ToolMetadata{
+		Description: "LoadData loads a CSV, TSV, JSON or JSON Lines file into an in-memory table.",
+		Params: []ParamMetadata{
+			{Name: "path", Description: "Path of the file, relative to the target directory. JSON files must hold an array of objects", Required: true},
+			{Name: "table", Description: "Name of the table. Defaults to the file name without extension", Required: false},
+		},
+	})
+	RegisterToolMetadata((*DataTools)(nil), "QueryData", ToolMetadata{
+		Description: "QueryData filters, groups, aggregates and sorts the rows of a loaded table.",
+		Params: []ParamMetadata{
+			{Name: "table", Description: "Name of the table", Required: true},
+			{Name: "filter", Description: "Condition on the rows, e.g. \"price > 10 and (category = 'books' or category = 'music')\". Operators: =, !=, <, <=, >, >=, contains, in ('a', 'b'), is null, is not null, and, or, not. Quote column names with spaces with backticks", Required: false},
+			{Name: "columns", Description: "Columns to return when not aggregating. Defaults to all columns", Required: false},
+			{Name: "group_by", Description: "Columns to group the rows by", Required: false},
+			{Name: "aggregates", Description: "Aggregates to compute, over the groups if group_by is provided, e.g. [\"count\", \"sum(price)\", \"avg(price)\"]. Functions: count, count(column), count_distinct, sum, avg, min, max, median. Defaults to [\"count\"] when grouping", Required: false},
+			{Name: "sort_by", Description: "Comma separated columns or aggregates to sort by, with \"desc\" for descending order, e.g. \"sum(price) desc, category\"", Required: false},
+			{Name: "limit", Description: "Maximum number of rows to return", Required: false},
+		},
+	})
+	RegisterToolMetadata((*DateTimeTools)(nil), "AddBusinessDays", ToolMetadata{
+		Description: "AddBusinessDays adds business days to a date, skipping weekends and holidays.",
+		Params: []ParamMetadata{
+			{Name: "date", Description: "Start date, e.g. \"2024-03-08\". Defaults to today", Required: false},
+			{Name: "days", Description: "Number of business days to add. Negative numbers subtract business days", Required: true},
+			{Name: "timezone", Description: "Timezone of the date. Defaults to the configured timezone", Required: false},
+		},
+	})
+	RegisterToolMetadata((*DateTimeTools)(nil), "AddDuration", ToolMetadata{
+		Description: "AddDuration adds a duration to a date and time.",
+		Params: []ParamMetadata{
+			{Name: "datetime", Description: "Date and time, e.g. \"2024-03-08T15:00:00\". Defaults to now", Required: false},
+			{Name: "duration", Description: "Duration to add, e.g. \"90 minutes\", \"2h30m\", \"1 year 2 months\" or \"3 weeks\". Prefix it with \"-\" to subtract it", Required: true},
+			{Name: "timezone", Description: "Timezone of the date and time if it has no UTC offset. Defaults to the configured timezone", Required: false},
+		},
+	})
+	RegisterToolMetadata((*DateTimeTools)(nil), "ConvertTimezone", ToolMetadata{
+		Description: "ConvertTimezone converts a date and time to another timezone.",
+		Params: []ParamMetadata{
+			{Name: "datetime", Description: "Date and time, e.g. \"2024-03-08T15:00:00\", \"2024-03-08 15:00\" or \"tomorrow 9am\"", Required: true},
+			{Name: "from_timezone", Description: "Timezone of the date and time if it has no UTC offset. Defaults to the configured timezone", Required: false},
+			{Name: "to_timezone", Description: "Timezone to convert to, e.g. \"Asia/Tokyo\"", Required: true},
+		},
+	})
+	RegisterToolMetadata((*DateTimeTools)(nil), "CountBusinessDays", ToolMetadata{
+		Description: "CountBusinessDays counts the business days between two dates, excluding weekends and holidays.",
+		Params: []ParamMetadata{
+			{Name: "start", Description: "Start date, included, e.g. \"2024-03-01\"", Required: true},
+			{Name: "end", Description: "End date, excluded, e.g. \"2024-04-01\"", Required: true},
+			{Name: "timezone", Description: "Timezone of the dates. Defaults to the configured timezone", Required: false},
+		},
+	})
+	RegisterToolMetadata((*DateTimeTools)(nil), "DiffDates", ToolMetadata{
+		Description: "DiffDates computes the difference between two dates and times.",
+		Params: []ParamMetadata{
+			{Name: "start", Description: "Start date and time, e.g. \"2024-01-31\" or \"2024-01-31T08:00:00\"", Required: true},
+			{Name: "end", Description: "End date and time. Defaults to now", Required: false},
+			{Name: "timezone", Description: "Timezone of the dates if they have no UTC offset. Defaults to the configured timezone", Required: false},
+		},
+	})
+	RegisterToolMetadata((*DateTimeTools)(nil), "Now", ToolMetadata{
+		Description: "Now returns the current date and time.",
+		Params: []ParamMetadata{
+			{Name: "timezone", Description: "IANA timezone, e.g. \"America/New_York\", or UTC offset, e.g. \"+05:30\". Defaults to the configured timezone", Required: false},
+		},
+	})
+	RegisterToolMetadata((*DateTimeTools)(nil), "ParseNaturalDate", ToolMetadata{
+		Description: "ParseNaturalDate converts a natural language expression to a date and time.",
+		Params: []ParamMetadata{
+			{Name: "expression", Description: "Expression such as \"next friday 3pm\", \"tomorrow at 9:30am\", \"in 2 hours\", \"3 days ago\", \"last monday\", \"march 5 2025\" or \"2025-03-05 noon\"", Required: true},
+			{Name: "timezone", Description: "Timezone of the expression. Defaults to the configured timezone", Required: false},
+		},
+	})
+	RegisterToolMetadata((*EmailTools)(nil), "ListInbox", ToolMetadata{
+		Description: "ListInbox lists the latest emails of a mailbox, newest first.",
+		Params: []ParamMetadata{
+			{Name: "mailbox", Description: "Name of the mailbox. Defaults to INBOX", Required: false},
+			{Name: "limit", Description: "Maximum number of emails. Defaults to 20", Required: false},
+			{Name: "unread_only", Description: "If true, only lists the unread emails", Required: false},
+		},
+	})
+	RegisterToolMetadata((*EmailTools)(nil), "ReadEmail", ToolMetadata{
+		Description: "ReadEmail reads an email without marking it as read.",
+		Params: []ParamMetadata{
+			{Name: "uid", Description: "UID of the email, as returned by ListInbox", Required: true},
+			{Name: "mailbox", Description: "Name of the mailbox. Defaults to INBOX", Required: false},
+		},
+	})
+	RegisterToolMetadata((*EmailTools)(nil), "SendEmail", ToolMetadata{
+		Description: "SendEmail sends an email.",
+		Params: []ParamMetadata{
+			{Name: "to", Description: "Addresses of the recipients, e.g. [\"bob@example.com\", \"Alice <alice@example.com>\"]", Required: true},
+			{Name: "cc", Description: "Addresses of the recipients in copy", Required: false},
+			{Name: "subject", Description: "Subject of the email", Required: true},
+			{Name: "body", Description: "Body of the email", Required: true},
+			{Name: "markdown", Description: "If true, the body is Markdown and the email also has an HTML version of it", Required: false},
+			{Name: "attachments", Description: "Paths of the files to attach, relative to the attachment directory", Required: false},
+		},
+	})
+	RegisterToolMetadata((*FileSystemTools)(nil), "AppendFile", ToolMetadata{
+		Description: "AppendFile appends content to a local file, creating it if needed.",
+		Params: []ParamMetadata{
+			{Name: "path", Description: "Path of the file, relative to the target directory", Required: true},
+			{Name: "content", Description: "Content to append to the file", Required: true},
+		},
+	})
+	RegisterToolMetadata((*FileSystemTools)(nil), "ApplyDiff", ToolMetadata{
+		Description: "ApplyDiff applies a unified diff to a local file, creating it if the diff adds a new file.",
+		Params: []ParamMetadata{
+			{Name: "path", Description: "Path of the file, relative to the target directory", Required: true},
+			{Name: "diff", Description: "Unified diff with \"@@ -start,count +start,count @@\" hunks of context, removed (\"-\") and added (\"+\") lines", Required: true},
+		},
+	})
+	RegisterToolMetadata((*FileSystemTools)(nil), "DeleteFile", ToolMetadata{
+		Description: "DeleteFile deletes a local file.",
+		Params: []ParamMetadata{
+			{Name: "path", Description: "Path of the file, relative to the target directory", Required: true},
+		},
+	})
+	RegisterToolMetadata((*FileSystemTools)(nil), "FileInfo", ToolMetadata{
+		Description: "FileInfo returns the type, size, permissions and modification time of a file or directory.",
+		Params: []ParamMetadata{
+			{Name: "path", Description: "Path of the file or directory, relative to the target directory", Required: true},
+		},
+	})
+	RegisterToolMetadata((*FileSystemTools)(nil), "GlobFiles", ToolMetadata{
+		Description: "GlobFiles finds the files matching a glob pattern.",
+		Params: []ParamMetadata{
+			{Name: "pattern", Description: "Glob pattern matched against paths relative to the target directory, e.g. \"**/*.go\" or \"docs/*.md\". \"**\" matches any number of directories", Required: true},
+		},
+	})
+	RegisterToolMetadata((*FileSystemTools)(nil), "ListDirectory", ToolMetadata{
+		Description: "ListDirectory lists the files and directories of a directory.",
+		Params: []ParamMetadata{
+			{Name: "path", Description: "Path of the directory, relative to the target directory. Uses the target directory if not provided", Required: false},
+			{Name: "recursive", Description: "If true, lists the content of subdirectories too", Required: false},
+		},
+	})
+	RegisterToolMetadata((*FileSystemTools)(nil), "ReadFile", ToolMetadata{
+		Description: "ReadFile reads content from a local file.",
+		Params: []ParamMetadata{
+			{Name: "filename", Description: "Name of the file", Required: true},
+			{Name: "directory", Description: "Directory of the file, relative to the target directory. Uses the target directory if not provided", Required: false},
+			{Name: "start_line", Description: "First line to read, starting at 1. Reads the whole file if neither start_line nor end_line is provided", Required: false},
+			{Name: "end_line", Description: "Last line to read, included. Defaults to the last line of the file", Required: false},
+		},
+	})
+	RegisterToolMetadata((*FileSystemTools)(nil), "ReplaceInFile", ToolMetadata{
+		Description: "ReplaceInFile replaces exact text in a local file.",
+		Params: []ParamMetadata{
+			{Name: "path", Description: "Path of the file, relative to the target directory", Required: true},
+			{Name: "old_text", Description: "Exact text to replace, including whitespace. It must be unique in the file unless replace_all is true", Required: true},
+			{Name: "new_text", Description: "Replacement text", Required: true},
+			{Name: "replace_all", Description: "If true, replaces all occurrences of old_text", Required: false},
+		},
+	})
+	RegisterToolMetadata((*FileSystemTools)(nil), "SearchInFiles", ToolMetadata{
+		Description: "SearchInFiles searches the lines of files matching a regular expression.",
+		Params: []ParamMetadata{
+			{Name: "pattern", Description: "Regular expression in Go syntax, e.g. \"func \\\\w+\\\\(\" or \"(?i)todo\"", Required: true},
+			{Name: "path", Description: "File or directory to search, relative to the target directory. Uses the target directory if not provided", Required: false},
+			{Name: "include", Description: "Glob pattern of the files to search, e.g. \"*.go\". Patterns without \"/\" match file names", Required: false},
+		},
+	})
+	RegisterToolMetadata((*FileSystemTools)(nil), "WriteFile", ToolMetadata{
+		Description: "WriteFile writes content to a local file.",
+		Params: []ParamMetadata{
+			{Name: "content", Description: "Content to write to the file", Required: true},
+			{Name: "filename", Description: "Name of the file. Defaults to UUID if not provided", Required: false},
+			{Name: "directory", Description: "Directory to write file to, relative to the target directory. Uses the target directory if not provided", Required: false},
+			{Name: "extension", Description: "File extension. Uses DefaultExtension if not provided", Required: false},
+		},
+	})
+	RegisterToolMetadata((*GitTools)(nil), "Blame", ToolMetadata{
+		Description: "Blame shows the commit, author and date of the last change of each line of a file.",
+		Params: []ParamMetadata{
+			{Name: "path", Description: "Path of the file, relative to the repository", Required: true},
+			{Name: "start_line", Description: "First line, starting at 1. Defaults to the first line of the file", Required: false},
+			{Name: "end_line", Description: "Last line, included. Defaults to the last line of the file", Required: false},
+		},
+	})
+	RegisterToolMetadata((*GitTools)(nil), "Commit", ToolMetadata{
+		Description: "Commit records changes in a new commit.",
+		Params: []ParamMetadata{
+			{Name: "message", Description: "Commit message", Required: true},
+			{Name: "paths", Description: "Files or directories to stage before committing. Commits the already staged changes if not provided", Required: false},
+			{Name: "all", Description: "If true, stages all changes of tracked files before committing", Required: false},
+		},
+	})
+	RegisterToolMetadata((*GitTools)(nil), "CreateBranch", ToolMetadata{
+		Description: "CreateBranch creates a branch.",
+		Params: []ParamMetadata{
+			{Name: "name", Description: "Name of the branch", Required: true},
+			{Name: "start_point", Description: "Revision the branch starts at. Defaults to HEAD", Required: false},
+			{Name: "checkout", Description: "If true, switches to the new branch", Required: false},
+		},
+	})
+	RegisterToolMetadata((*GitTools)(nil), "Diff", ToolMetadata{
+		Description: "Diff shows changes between the working tree, the index and commits.",
+		Params: []ParamMetadata{
+			{Name: "revision", Description: "Revision or range to compare with, e.g. \"HEAD~1\" or \"main..feature\". Compares the working tree with the index if not provided", Required: false},
+			{Name: "paths", Description: "Only show changes of these files or directories", Required: false},
+			{Name: "staged", Description: "If true, shows the staged changes instead of the working tree changes", Required: false},
+		},
+	})
+	RegisterToolMetadata((*GitTools)(nil), "ListBranches", ToolMetadata{
+		Description: "ListBranches lists the local and remote branches, marking the current branch with \"*\".",
+		Params:      []ParamMetadata{},
+	})
+	RegisterToolMetadata((*GitTools)(nil), "Log", ToolMetadata{
+		Description: "Log shows the commit history.",
+		Params: []ParamMetadata{
+			{Name: "max_count", Description: "Maximum number of commits. Defaults to 20", Required: false},
+			{Name: "revision", Description: "Revision or range to show the history of, e.g. \"main\" or \"v1.0..HEAD\". Defaults to HEAD", Required: false},
+			{Name: "path", Description: "Only show commits changing this file or directory", Required: false},
+		},
+	})
+	RegisterToolMetadata((*GitTools)(nil), "Show", ToolMetadata{
+		Description: "Show shows a commit with its message and changes.",
+		Params: []ParamMetadata{
+			{Name: "revision", Description: "Revision to show, e.g. a commit hash or tag. Defaults to HEAD", Required: false},
+		},
+	})
+	RegisterToolMetadata((*GitTools)(nil), "Status", ToolMetadata{
+		Description: "Status shows the current branch and the changed files of the repository.",
+		Params:      []ParamMetadata{},
+	})
+	RegisterToolMetadata((*ImageGenerationTools)(nil), "GenerateImage", ToolMetadata{
+		Description: "GenerateImage generates images from a text description.",
+		Params: []ParamMetadata{
+			{Name: "prompt", Description: "Detailed description of the image to generate", Required: true},
+			{Name: "size", Description: "Size of the image, e.g. \"1024x1024\", \"1792x1024\" or \"1024x1792\"", Required: false},
+			{Name: "count", Description: "Number of images to generate. Defaults to 1", Required: false},
+			{Name: "quality", Description: "Quality of the image, e.g. \"standard\" or \"hd\"", Required: false},
+		},
+	})
+	RegisterToolMetadata((*SQLTools)(nil), "DescribeTable", ToolMetadata{
+		Description: "DescribeTable describes the columns of a table.",
+		Params: []ParamMetadata{
+			{Name: "table", Description: "Name of the table", Required: true},
+		},
+	})
+	RegisterToolMetadata((*SQLTools)(nil), "ListTables", ToolMetadata{
+		Description: "ListTables lists the tables and views of the database.",
+		Params:      []ParamMetadata{},
+	})
+	RegisterToolMetadata((*SQLTools)(nil), "RunQuery", ToolMetadata{
+		Description: "RunQuery runs an SQL query and returns its results.",
+		Params: []ParamMetadata{
+			{Name: "query", Description: "A single SQL statement, e.g. \"SELECT name, price FROM products WHERE price > 10\"", Required: true},
+			{Name: "format", Description: "Format of the results. Defaults to markdown", Required: false, Enum: []string{"markdown", "json"}},
+		},
+	})
+	RegisterToolMetadata((*SearchTools)(nil), "Search", ToolMetadata{
+		Description: "Search searches the web and returns the title, URL, snippet and publication date of the results.",
+		Params: []ParamMetadata{
+			{Name: "query", Description: "Search query", Required: true},
+			{Name: "max_results", Description: "Maximum number of results. Defaults to 5", Required: false},
+		},
+	})
+	RegisterToolMetadata((*ShellTools)(nil), "RunCommand", ToolMetadata{
+		Description: "RunCommand runs a command with arguments and returns its exit code, stdout and stderr.",
+		Params: []ParamMetadata{
+			{Name: "command", Description: "Name of the binary to run, e.g. \"ls\". Shell syntax such as pipes and redirections is not supported", Required: true},
+			{Name: "args", Description: "Arguments of the command, e.g. [\"-la\", \"src\"]", Required: false},
+		},
+	})
+	RegisterToolMetadata((*WebTools)(nil), "FetchURL", ToolMetadata{
+		Description: "FetchURL sends an HTTP request and returns the status code, headers and body of the response.",
+		Params: []ParamMetadata{
+			{Name: "url", Description: "URL to fetch", Required: true},
+			{Name: "method", Description: "HTTP method. Defaults to GET", Required: false, Enum: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD"}},
+			{Name: "headers", Description: "Headers of the request", Required: false},
+			{Name: "body", Description: "Body of the request", Required: false},
+		},
+	})
+	RegisterToolMetadata((*WebTools)(nil), "ReadPage", ToolMetadata{
+		Description: "ReadPage reads a web page and returns its title, its content as Markdown and its links.",
+		Params: []ParamMetadata{
+			{Name: "url", Description: "URL of the page", Required: true},
+		},
+	})
+}
@@ -0,0 +1,80 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// NewTypedTool creates a Tool from a function taking a struct of arguments.
+// The JSON Schema of the parameters is generated from the In struct with JSONSchemaFor,
+// so its fields can use the json, description, enum, minimum and maximum struct tags.
+// Arguments from the model are validated against the schema and decoded into In before fn is called;
+// invalid arguments return a *ValidationError.
+// The result of fn is returned as is if Out is a string, and encoded as JSON otherwise.
+// It panics if In is not a struct or cannot be represented as a JSON Schema.
+//
+// Example:
+//
+//	type WeatherArgs struct {
+//		City  string `json:"city" description:"Name of the city"`
+//		Units string `json:"units,omitempty" enum:"celsius,fahrenheit"`
+//	}
+//
+//	weatherTool := tools.NewTypedTool("get_weather", "Get the current weather in a city",
+//		func(ctx context.Context, args WeatherArgs) (string, error) {
+//			return fmt.Sprintf("It is sunny in %s", args.City), nil
+//		})
+func NewTypedTool[In, Out any](name, description string, fn func(ctx context.Context, args In) (Out, error)) Tool {
+	inType := reflect.TypeOf((*In)(nil)).Elem()
+	structType := inType
+	for structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("NewTypedTool %s: arguments type must be a struct, got %v", name, inType))
+	}
+	parameters, err := JSONSchemaFor(inType)
+	if err != nil {
+		panic(fmt.Sprintf("NewTypedTool %s: %v", name, err))
+	}
+	schema, err := CompileSchema(parameters)
+	if err != nil {
+		panic(fmt.Sprintf("NewTypedTool %s: %v", name, err))
+	}
+
+	execute := func(ctx context.Context, args string) (string, error) {
+		if err := schema.ValidateJSON(args); err != nil {
+			var validationErr *ValidationError
+			if errors.As(err, &validationErr) {
+				validationErr.Tool = name
+			}
+			return "", err
+		}
+		if strings.TrimSpace(args) == "" {
+			args = "{}"
+		}
+
+		var in In
+		if err := json.Unmarshal([]byte(args), &in); err != nil {
+			return "", fmt.Errorf("failed to decode args: %v", err)
+		}
+		out, err := fn(ctx, in)
+		if err != nil {
+			return "", err
+		}
+		if s, ok := any(out).(string); ok {
+			return s, nil
+		}
+		result, err := json.Marshal(out)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal result: %v", err)
+		}
+		return string(result), nil
+	}
+
+	return NewTool(name, description, parameters, execute)
+}
@@ -0,0 +1,95 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// weatherArgs is a sample arguments struct for testing NewTypedTool.
+type weatherArgs struct {
+	City  string   `json:"city" description:"Name of the city"`
+	Units string   `json:"units,omitempty" enum:"celsius,fahrenheit"`
+	Days  *int     `json:"days" minimum:"1" maximum:"7"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+// weatherReport is a sample output struct for testing NewTypedTool.
+type weatherReport struct {
+	City        string `json:"city"`
+	Temperature int    `json:"temperature"`
+	Days        int    `json:"days"`
+}
+
+func TestNewTypedTool(t *testing.T) {
+	tool := NewTypedTool("get_weather", "Get the weather", func(ctx context.Context, args weatherArgs) (weatherReport, error) {
+		if args.City == "Nowhere" {
+			return weatherReport{}, fmt.Errorf("unknown city")
+		}
+		days := 1
+		if args.Days != nil {
+			days = *args.Days
+		}
+		return weatherReport{City: args.City, Temperature: 20, Days: days}, nil
+	})
+
+	assert.Equal(t, "get_weather", tool.Name)
+	assert.Equal(t, "Get the weather", tool.Description)
+	assert.Equal(t, []string{"city"}, tool.Parameters["required"])
+	properties := tool.Parameters["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "string", "description": "Name of the city"}, properties["city"])
+	assert.Equal(t, []interface{}{"celsius", "fahrenheit"}, properties["units"].(map[string]interface{})["enum"])
+
+	tests := []struct {
+		name    string
+		args    string
+		want    string
+		wantErr string
+	}{
+		{name: "required only", args: `{"city": "Paris"}`, want: `{"city":"Paris","temperature":20,"days":1}`},
+		{name: "all arguments", args: `{"city": "Paris", "units": "celsius", "days": 3, "tags": ["a"]}`, want: `{"city":"Paris","temperature":20,"days":3}`},
+		{name: "missing required", args: `{"units": "celsius"}`, wantErr: "- city: missing required property"},
+		{name: "empty arguments", args: ``, wantErr: "- city: missing required property"},
+		{name: "wrong type", args: `{"city": 42}`, wantErr: "- city: must be of type string, got number 42"},
+		{name: "invalid enum", args: `{"city": "Paris", "units": "kelvin"}`, wantErr: `- units: must be one of "celsius", "fahrenheit", got "kelvin"`},
+		{name: "below minimum", args: `{"city": "Paris", "days": 0}`, wantErr: "- days: must be >= 1, got 0"},
+		{name: "above maximum", args: `{"city": "Paris", "days": 8}`, wantErr: "- days: must be <= 7, got 8"},
+		{name: "not an integer", args: `{"city": "Paris", "days": 1.5}`, wantErr: "- days: must be of type integer, got number 1.5"},
+		{name: "wrong item type", args: `{"city": "Paris", "tags": [1]}`, wantErr: "- tags[0]: must be of type string"},
+		{name: "invalid JSON", args: `{"city":`, wantErr: "- arguments: not valid JSON"},
+		{name: "function error", args: `{"city": "Nowhere"}`, wantErr: "unknown city"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tool.Execute(context.Background(), tt.args)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, result)
+		})
+	}
+}
+
+func TestNewTypedToolStringOutput(t *testing.T) {
+	tool := NewTypedTool("greet", "Greet someone", func(ctx context.Context, args struct {
+		Name string `json:"name"`
+	}) (string, error) {
+		return "Hello, " + args.Name, nil
+	})
+	result, err := tool.Execute(context.Background(), `{"name": "World"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello, World", result, "String results should not be JSON encoded")
+}
+
+func TestNewTypedToolPanics(t *testing.T) {
+	assert.Panics(t, func() {
+		NewTypedTool("bad", "Not a struct", func(ctx context.Context, args string) (string, error) { return args, nil })
+	})
+	assert.Panics(t, func() {
+		NewTypedTool("bad", "Unsupported field", func(ctx context.Context, args struct{ C chan int }) (string, error) { return "", nil })
+	})
+}
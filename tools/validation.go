@@ -0,0 +1,357 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Schema is a compiled JSON Schema used to validate tool arguments before the tool is executed.
+// It supports the type, enum, const, minimum, maximum, exclusiveMinimum, exclusiveMaximum, minLength, maxLength,
+// pattern, items, minItems, maxItems, properties, required and additionalProperties keywords.
+// Other keywords (e.g., format, description) are ignored.
+type Schema struct {
+	types                []string
+	enum                 []interface{}
+	constValue           interface{}
+	hasConst             bool
+	minimum              *float64
+	maximum              *float64
+	exclusiveMinimum     *float64
+	exclusiveMaximum     *float64
+	minLength            *int
+	maxLength            *int
+	pattern              *regexp.Regexp
+	items                *Schema
+	minItems             *int
+	maxItems             *int
+	properties           map[string]*Schema
+	required             []string
+	additionalProperties *Schema
+	noAdditional         bool // additionalProperties is false
+}
+
+// ValidationIssue is a single problem found in tool arguments.
+type ValidationIssue struct {
+	Path    string // Location of the invalid value, e.g. "address.city" or "tags[0]"; empty for the arguments object
+	Message string // Description of the problem
+}
+
+// ValidationError is returned when tool arguments do not match the tool's Parameters schema.
+// Its message lists every issue, so that it can be returned to the model as the tool result to self-correct.
+type ValidationError struct {
+	Tool   string            // Name of the tool
+	Issues []ValidationIssue // Problems found in the arguments
+}
+
+// Error returns a model-friendly description of the invalid arguments.
+func (e *ValidationError) Error() string {
+	var sb strings.Builder
+	if e.Tool != "" {
+		fmt.Fprintf(&sb, "invalid arguments for tool %s:", e.Tool)
+	} else {
+		sb.WriteString("invalid arguments:")
+	}
+	for _, issue := range e.Issues {
+		path := issue.Path
+		if path == "" {
+			path = "arguments"
+		}
+		fmt.Fprintf(&sb, "\n- %s: %s", path, issue.Message)
+	}
+	sb.WriteString("\nFix the arguments and call the tool again.")
+	return sb.String()
+}
+
+// CompileSchema compiles a JSON Schema, such as the Parameters of a Tool.
+// The schema may use any Go values which encode to JSON (e.g., []string for required).
+func CompileSchema(schema map[string]interface{}) (*Schema, error) {
+	// Normalize the schema to decoded JSON values, as schemas are written with various Go types
+	encoded, err := json.Marshal(schema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema: %v", err)
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(encoded, &normalized); err != nil {
+		return nil, fmt.Errorf("invalid schema: %v", err)
+	}
+	return compileSchema(normalized, "")
+}
+
+// compileSchema compiles a decoded JSON Schema. path is used in error messages.
+func compileSchema(value interface{}, path string) (*Schema, error) {
+	raw, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid schema at %q: expected an object", path)
+	}
+	s := &Schema{}
+
+	switch t := raw["type"].(type) {
+	case nil:
+	case string:
+		s.types = []string{t}
+	case []interface{}:
+		for _, item := range t {
+			name, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid type at %q", path)
+			}
+			s.types = append(s.types, name)
+		}
+	default:
+		return nil, fmt.Errorf("invalid type at %q", path)
+	}
+	for _, name := range s.types {
+		switch name {
+		case "object", "array", "string", "number", "integer", "boolean", "null":
+		default:
+			return nil, fmt.Errorf("unknown type %q at %q", name, path)
+		}
+	}
+
+	if enum, ok := raw["enum"]; ok {
+		values, ok := enum.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid enum at %q: expected an array", path)
+		}
+		s.enum = values
+	}
+	s.constValue, s.hasConst = raw["const"]
+
+	numbers := map[string]**float64{
+		"minimum":          &s.minimum,
+		"maximum":          &s.maximum,
+		"exclusiveMinimum": &s.exclusiveMinimum,
+		"exclusiveMaximum": &s.exclusiveMaximum,
+	}
+	for key, target := range numbers {
+		if v, ok := raw[key]; ok {
+			number, ok := v.(float64)
+			if !ok {
+				return nil, fmt.Errorf("invalid %s at %q: expected a number", key, path)
+			}
+			*target = &number
+		}
+	}
+	counts := map[string]**int{
+		"minLength": &s.minLength,
+		"maxLength": &s.maxLength,
+		"minItems":  &s.minItems,
+		"maxItems":  &s.maxItems,
+	}
+	for key, target := range counts {
+		if v, ok := raw[key]; ok {
+			number, ok := v.(float64)
+			if !ok || number < 0 || number != math.Trunc(number) {
+				return nil, fmt.Errorf("invalid %s at %q: expected a non-negative integer", key, path)
+			}
+			count := int(number)
+			*target = &count
+		}
+	}
+
+	if v, ok := raw["pattern"]; ok {
+		pattern, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid pattern at %q: expected a string", path)
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern at %q: %v", path, err)
+		}
+		s.pattern = re
+	}
+
+	if v, ok := raw["items"]; ok {
+		items, err := compileSchema(v, path+"[]")
+		if err != nil {
+			return nil, err
+		}
+		s.items = items
+	}
+
+	if v, ok := raw["properties"]; ok {
+		properties, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid properties at %q: expected an object", path)
+		}
+		s.properties = make(map[string]*Schema, len(properties))
+		for name, property := range properties {
+			compiled, err := compileSchema(property, joinPath(path, name))
+			if err != nil {
+				return nil, err
+			}
+			s.properties[name] = compiled
+		}
+	}
+	if v, ok := raw["required"]; ok && v != nil {
+		required, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid required at %q: expected an array", path)
+		}
+		for _, item := range required {
+			name, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid required at %q: expected strings", path)
+			}
+			s.required = append(s.required, name)
+		}
+	}
+	switch v := raw["additionalProperties"].(type) {
+	case nil:
+	case bool:
+		s.noAdditional = !v
+	default:
+		additional, err := compileSchema(v, joinPath(path, "*"))
+		if err != nil {
+			return nil, err
+		}
+		s.additionalProperties = additional
+	}
+	return s, nil
+}
+
+// ValidateJSON validates JSON-encoded arguments, returning a *ValidationError if they are invalid.
+// Empty arguments are treated as an empty object.
+func (s *Schema) ValidateJSON(args string) error {
+	if strings.TrimSpace(args) == "" {
+		args = "{}"
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(args), &value); err != nil {
+		return &ValidationError{Issues: []ValidationIssue{{Message: fmt.Sprintf("not valid JSON: %v", err)}}}
+	}
+	if issues := s.Validate(value); len(issues) > 0 {
+		return &ValidationError{Issues: issues}
+	}
+	return nil
+}
+
+// Validate validates a decoded JSON value and returns every issue found, or nil if the value is valid.
+func (s *Schema) Validate(value interface{}) []ValidationIssue {
+	var issues []ValidationIssue
+	s.validate(value, "", &issues)
+	return issues
+}
+
+// validate appends the issues of the value at path to issues.
+func (s *Schema) validate(value interface{}, path string, issues *[]ValidationIssue) {
+	report := func(format string, args ...interface{}) {
+		*issues = append(*issues, ValidationIssue{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(s.types) > 0 {
+		matched := false
+		for _, t := range s.types {
+			if matchesJSONType(t, value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			report("must be of type %s, got %s", strings.Join(s.types, " or "), describeJSONValue(value))
+			return // Other keywords are meaningless for a value of the wrong type
+		}
+	}
+
+	if s.enum != nil {
+		found := false
+		allowed := make([]string, 0, len(s.enum))
+		for _, option := range s.enum {
+			if jsonEqual(option, value) {
+				found = true
+				break
+			}
+			allowed = append(allowed, encodeJSON(option))
+		}
+		if !found {
+			report("must be one of %s, got %s", strings.Join(allowed, ", "), encodeJSON(value))
+		}
+	}
+	if s.hasConst && !jsonEqual(s.constValue, value) {
+		report("must be %s, got %s", encodeJSON(s.constValue), encodeJSON(value))
+	}
+
+	switch v := value.(type) {
+	case float64:
+		if s.minimum != nil && v < *s.minimum {
+			report("must be >= %v, got %v", *s.minimum, v)
+		}
+		if s.maximum != nil && v > *s.maximum {
+			report("must be <= %v, got %v", *s.maximum, v)
+		}
+		if s.exclusiveMinimum != nil && v <= *s.exclusiveMinimum {
+			report("must be > %v, got %v", *s.exclusiveMinimum, v)
+		}
+		if s.exclusiveMaximum != nil && v >= *s.exclusiveMaximum {
+			report("must be < %v, got %v", *s.exclusiveMaximum, v)
+		}
+	case string:
+		length := len([]rune(v))
+		if s.minLength != nil && length < *s.minLength {
+			report("must be at least %d characters long, got %d", *s.minLength, length)
+		}
+		if s.maxLength != nil && length > *s.maxLength {
+			report("must be at most %d characters long, got %d", *s.maxLength, length)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			report("must match the pattern %s", s.pattern.String())
+		}
+	case []interface{}:
+		if s.minItems != nil && len(v) < *s.minItems {
+			report("must have at least %d items, got %d", *s.minItems, len(v))
+		}
+		if s.maxItems != nil && len(v) > *s.maxItems {
+			report("must have at most %d items, got %d", *s.maxItems, len(v))
+		}
+		if s.items != nil {
+			for i, item := range v {
+				s.items.validate(item, fmt.Sprintf("%s[%d]", path, i), issues)
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range s.required {
+			if _, ok := v[name]; !ok {
+				*issues = append(*issues, ValidationIssue{Path: joinPath(path, name), Message: "missing required property"})
+			}
+		}
+		// Validate properties in a stable order so that messages are deterministic
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			propertyPath := joinPath(path, name)
+			if property, ok := s.properties[name]; ok {
+				property.validate(v[name], propertyPath, issues)
+			} else if s.additionalProperties != nil {
+				s.additionalProperties.validate(v[name], propertyPath, issues)
+			} else if s.noAdditional {
+				*issues = append(*issues, ValidationIssue{Path: propertyPath, Message: "unknown property"})
+			}
+		}
+	}
+}
+
+// describeJSONValue describes a decoded JSON value for error messages, e.g. "number 1.5" or "object".
+func describeJSONValue(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return jsonTypeName(value)
+	default:
+		return jsonTypeName(value) + " " + encodeJSON(value)
+	}
+}
+
+// encodeJSON encodes a value as JSON for error messages.
+func encodeJSON(value interface{}) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(encoded)
+}
@@ -0,0 +1,125 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  map[string]interface{}
+		wantErr string
+	}{
+		{name: "empty schema", schema: map[string]interface{}{}},
+		{name: "go typed values", schema: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]map[string]interface{}{"a": {"type": "integer", "minimum": 1}},
+			"required":   []string{"a"},
+		}},
+		{name: "unknown type", schema: map[string]interface{}{"type": "decimal"}, wantErr: `unknown type "decimal"`},
+		{name: "invalid minimum", schema: map[string]interface{}{"minimum": "1"}, wantErr: "invalid minimum"},
+		{name: "invalid maxLength", schema: map[string]interface{}{"maxLength": -1}, wantErr: "invalid maxLength"},
+		{name: "invalid pattern", schema: map[string]interface{}{"pattern": "("}, wantErr: "invalid pattern"},
+		{name: "invalid enum", schema: map[string]interface{}{"enum": "a"}, wantErr: "invalid enum"},
+		{name: "invalid nested schema", schema: map[string]interface{}{
+			"properties": map[string]interface{}{"a": map[string]interface{}{"items": "string"}},
+		}, wantErr: `invalid schema at "a[]"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CompileSchema(tt.schema)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSchemaValidate(t *testing.T) {
+	schema, err := CompileSchema(map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name":  map[string]interface{}{"type": "string", "minLength": 2, "maxLength": 5, "pattern": "^[a-z]+$"},
+			"level": map[string]interface{}{"type": "integer", "enum": []int{1, 2, 3}},
+			"score": map[string]interface{}{"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 1},
+			"mode":  map[string]interface{}{"const": "fast"},
+			"tags":  map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "minItems": 1, "maxItems": 2},
+			"note":  map[string]interface{}{"type": []string{"string", "null"}},
+			"address": map[string]interface{}{
+				"type":                 "object",
+				"properties":           map[string]interface{}{"city": map[string]interface{}{"type": "string"}},
+				"required":             []string{"city"},
+				"additionalProperties": false,
+			},
+			"labels": map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{"type": "boolean"}},
+		},
+		"required": []string{"name"},
+	})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		value  string
+		issues []ValidationIssue
+	}{
+		{name: "valid", value: `{"name": "ann", "level": 2, "score": 0.5, "mode": "fast", "tags": ["a"], "note": null, "address": {"city": "x"}, "labels": {"a": true}}`},
+		{name: "not an object", value: `[1]`, issues: []ValidationIssue{{Path: "", Message: "must be of type object, got array"}}},
+		{name: "multiple issues", value: `{"level": 4, "score": 1, "mode": "slow"}`, issues: []ValidationIssue{
+			{Path: "name", Message: "missing required property"},
+			{Path: "level", Message: "must be one of 1, 2, 3, got 4"},
+			{Path: "mode", Message: `must be "fast", got "slow"`},
+			{Path: "score", Message: "must be < 1, got 1"},
+		}},
+		{name: "string constraints", value: `{"name": "A"}`, issues: []ValidationIssue{
+			{Path: "name", Message: "must be at least 2 characters long, got 1"},
+			{Path: "name", Message: "must match the pattern ^[a-z]+$"},
+		}},
+		{name: "string too long", value: `{"name": "abcdef"}`, issues: []ValidationIssue{{Path: "name", Message: "must be at most 5 characters long, got 6"}}},
+		{name: "array constraints", value: `{"name": "ann", "tags": ["a", 1, "c"]}`, issues: []ValidationIssue{
+			{Path: "tags", Message: "must have at most 2 items, got 3"},
+			{Path: "tags[1]", Message: "must be of type string, got number 1"},
+		}},
+		{name: "empty array", value: `{"name": "ann", "tags": []}`, issues: []ValidationIssue{{Path: "tags", Message: "must have at least 1 items, got 0"}}},
+		{name: "union type", value: `{"name": "ann", "note": 1}`, issues: []ValidationIssue{{Path: "note", Message: "must be of type string or null, got number 1"}}},
+		{name: "nested object", value: `{"name": "ann", "address": {"zip": "1"}, "labels": {"a": "yes"}}`, issues: []ValidationIssue{
+			{Path: "address.city", Message: "missing required property"},
+			{Path: "address.zip", Message: "unknown property"},
+			{Path: "labels.a", Message: `must be of type boolean, got string "yes"`},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schema.ValidateJSON(tt.value)
+			if tt.issues == nil {
+				assert.NoError(t, err)
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			assert.True(t, ok, "Expected a *ValidationError, got %v", err)
+			if ok {
+				assert.Equal(t, tt.issues, validationErr.Issues)
+			}
+		})
+	}
+}
+
+func TestValidationErrorMessage(t *testing.T) {
+	schema, err := JSONSchemaFor(reflect.TypeOf(schemaAddress{}))
+	assert.NoError(t, err)
+	compiled, err := CompileSchema(schema)
+	assert.NoError(t, err)
+
+	err = compiled.ValidateJSON(`{"street": 1}`)
+	validationErr := err.(*ValidationError)
+	validationErr.Tool = "save_address"
+	assert.Equal(t, "invalid arguments for tool save_address:\n- city: missing required property\n- street: must be of type string, got number 1\nFix the arguments and call the tool again.", err.Error())
+
+	err = compiled.ValidateJSON(`{"street":`)
+	assert.ErrorContains(t, err, "invalid arguments:\n- arguments: not valid JSON")
+	assert.NoError(t, compiled.ValidateJSON(`{"street": "Main St", "city": "Springfield"}`))
+}
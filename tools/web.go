@@ -0,0 +1,687 @@
+package tools
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Harsh-2909/hermes-go/utils"
+	"golang.org/x/net/html"
+)
+
+// WebTools provides tools for fetching URLs and reading web pages.
+// Only http and https URLs on the allowed domains are fetched, including after redirects,
+// and robots.txt rules are respected unless IgnoreRobots is set.
+type WebTools struct {
+	EnableFetchURL   bool          // Enable the FetchURL tool
+	EnableReadPage   bool          // Enable the ReadPage tool
+	EnableAll        bool          // Enable all tools if true
+	AllowedDomains   []string      // Domains which can be fetched, including their subdomains (e.g., "example.com"). All if empty
+	MaxResponseSize  int           // Maximum size of a response body in bytes; longer bodies are truncated. Defaults to 1000000
+	MaxContentLength int           // Maximum length of the content returned by ReadPage; longer content is truncated. Defaults to 20000
+	MaxRedirects     int           // Maximum number of redirects followed. Defaults to 5
+	Timeout          time.Duration // Maximum duration of a request. Defaults to 30 seconds
+	UserAgent        string        // User agent of the requests, also used for robots.txt rules. Defaults to "hermes-go"
+	IgnoreRobots     bool          // If true, robots.txt rules are not checked
+	HTTPClient       *http.Client  // HTTP client whose transport is used for requests. Defaults to http.DefaultClient
+
+	// Internal fields
+
+	robots sync.Map // Cached robotsEntry by scheme and host
+}
+
+// FetchResult is the result of the FetchURL tool.
+type FetchResult struct {
+	URL        string            `json:"url"`                 // Final URL, after redirects
+	StatusCode int               `json:"status_code"`         // HTTP status code
+	Headers    map[string]string `json:"headers"`             // Response headers
+	Body       string            `json:"body"`                // Response body, truncated to MaxResponseSize
+	Truncated  bool              `json:"truncated,omitempty"` // True if the body was truncated
+}
+
+// PageContent is the result of the ReadPage tool.
+type PageContent struct {
+	URL       string `json:"url"`                 // Final URL, after redirects
+	Title     string `json:"title"`               // Title of the page
+	Content   string `json:"content"`             // Content of the page as Markdown, or as text for non-HTML pages
+	Links     []Link `json:"links,omitempty"`     // Links of the page, with absolute URLs
+	Truncated bool   `json:"truncated,omitempty"` // True if the content was truncated
+}
+
+// Link is a link of a web page.
+type Link struct {
+	Text string `json:"text"`
+	URL  string `json:"url"`
+}
+
+// Tools returns a list of available tools based on enable flags.
+func (w *WebTools) Tools() []Tool {
+	var tools []Tool
+
+	if w.EnableFetchURL || w.EnableAll {
+		if fetchTool, err := CreateToolFromMethod(w, "FetchURL"); err == nil {
+			tools = append(tools, fetchTool)
+		} else {
+			utils.Logger.Error("Failed to create tool", "tool", "FetchURL", "error", err)
+		}
+	}
+
+	if w.EnableReadPage || w.EnableAll {
+		if readTool, err := CreateToolFromMethod(w, "ReadPage"); err == nil {
+			tools = append(tools, readTool)
+		} else {
+			utils.Logger.Error("Failed to create tool", "tool", "ReadPage", "error", err)
+		}
+	}
+
+	return tools
+}
+
+// FetchURL sends an HTTP request and returns the status code, headers and body of the response.
+// @param url: URL to fetch
+// @param [optional] method: HTTP method. Defaults to GET
+// @enum method: GET, POST, PUT, PATCH, DELETE, HEAD
+// @param [optional] headers: Headers of the request
+// @param [optional] body: Body of the request
+// @return JSON object with the final URL, status code, headers and body of the response
+func (w *WebTools) FetchURL(ctx context.Context, url string, method string, headers map[string]string, body string) (FetchResult, error) {
+	if method == "" {
+		method = http.MethodGet
+	}
+	resp, cancel, err := w.do(ctx, strings.ToUpper(method), url, headers, body)
+	if err != nil {
+		return FetchResult{}, err
+	}
+	defer cancel()
+	defer resp.Body.Close()
+
+	data, truncated, err := w.readBody(resp)
+	if err != nil {
+		return FetchResult{}, err
+	}
+	result := FetchResult{
+		URL:        resp.Request.URL.String(),
+		StatusCode: resp.StatusCode,
+		Headers:    make(map[string]string, len(resp.Header)),
+		Body:       string(data),
+		Truncated:  truncated,
+	}
+	for key, values := range resp.Header {
+		result.Headers[key] = strings.Join(values, ", ")
+	}
+	return result, nil
+}
+
+// ReadPage reads a web page and returns its title, its content as Markdown and its links.
+// @param url: URL of the page
+// @return JSON object with the final URL, title, content and links of the page
+func (w *WebTools) ReadPage(ctx context.Context, url string) (PageContent, error) {
+	resp, cancel, err := w.do(ctx, http.MethodGet, url, map[string]string{"Accept": "text/html, text/plain;q=0.9, */*;q=0.5"}, "")
+	if err != nil {
+		return PageContent{}, err
+	}
+	defer cancel()
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return PageContent{}, fmt.Errorf("failed to read page: %s returned %s", url, resp.Status)
+	}
+
+	data, truncated, err := w.readBody(resp)
+	if err != nil {
+		return PageContent{}, err
+	}
+	page := PageContent{URL: resp.Request.URL.String(), Truncated: truncated}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == "text/html" || mediaType == "application/xhtml+xml" || (mediaType == "" && looksLikeHTML(data)) {
+		doc, err := html.Parse(strings.NewReader(string(data)))
+		if err != nil {
+			return PageContent{}, fmt.Errorf("failed to parse page: %v", err)
+		}
+		page.Title, page.Content, page.Links = htmlToMarkdown(doc, resp.Request.URL)
+	} else {
+		page.Content = string(data)
+	}
+
+	maxLength := w.MaxContentLength
+	if maxLength <= 0 {
+		maxLength = 20000
+	}
+	if len(page.Content) > maxLength {
+		page.Content = strings.ToValidUTF8(page.Content[:maxLength], "") + "\n[Content truncated]"
+		page.Truncated = true
+	}
+	return page, nil
+}
+
+// do sends a request after checking the URL, with the timeout, redirect limit and robots.txt rules.
+// The returned cancel function must be called once the response body is read.
+func (w *WebTools) do(ctx context.Context, method, rawURL string, headers map[string]string, body string) (*http.Response, context.CancelFunc, error) {
+	target, err := w.checkURL(rawURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	timeout := w.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+
+	if !w.IgnoreRobots {
+		if allowed := w.robotsAllowed(ctx, target); !allowed {
+			cancel()
+			return nil, nil, fmt.Errorf("fetching %s is disallowed by robots.txt", rawURL)
+		}
+	}
+
+	var bodyReader io.Reader
+	if body != "" {
+		bodyReader = strings.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), bodyReader)
+	if err != nil {
+		cancel()
+		return nil, nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("User-Agent", w.userAgent())
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	utils.Logger.Debug("Fetching URL", "method", method, "url", target.String())
+	resp, err := w.client().Do(req)
+	if err != nil {
+		cancel()
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, nil, fmt.Errorf("request to %s timed out after %v", rawURL, timeout)
+		}
+		return nil, nil, fmt.Errorf("request failed: %v", err)
+	}
+	return resp, cancel, nil
+}
+
+// client returns the HTTP client of the requests, which checks the redirects.
+func (w *WebTools) client() *http.Client {
+	base := w.HTTPClient
+	if base == nil {
+		base = http.DefaultClient
+	}
+	maxRedirects := w.MaxRedirects
+	if maxRedirects <= 0 {
+		maxRedirects = 5
+	}
+	client := *base
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) > maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		if _, err := w.checkURL(req.URL.String()); err != nil {
+			return fmt.Errorf("redirect to %s: %w", req.URL, err)
+		}
+		return nil
+	}
+	return &client
+}
+
+// checkURL parses a URL and returns an error if it is not an http or https URL on an allowed domain.
+func (w *WebTools) checkURL(rawURL string) (*url.URL, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %v", rawURL, err)
+	}
+	if target.Scheme != "http" && target.Scheme != "https" {
+		return nil, fmt.Errorf("invalid URL %q: only http and https URLs are supported", rawURL)
+	}
+	if len(w.AllowedDomains) > 0 {
+		host := strings.ToLower(target.Hostname())
+		allowed := slices.ContainsFunc(w.AllowedDomains, func(domain string) bool {
+			domain = strings.ToLower(strings.TrimPrefix(domain, "."))
+			return host == domain || strings.HasSuffix(host, "."+domain)
+		})
+		if !allowed {
+			return nil, fmt.Errorf("domain %s is not allowed", target.Hostname())
+		}
+	}
+	return target, nil
+}
+
+// readBody reads the response body up to MaxResponseSize bytes, and reports whether it was truncated.
+func (w *WebTools) readBody(resp *http.Response) ([]byte, bool, error) {
+	maxSize := w.MaxResponseSize
+	if maxSize <= 0 {
+		maxSize = 1000000
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, int64(maxSize)+1))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read response: %v", err)
+	}
+	if len(data) > maxSize {
+		return data[:maxSize], true, nil
+	}
+	return data, false, nil
+}
+
+// userAgent returns the user agent of the requests.
+func (w *WebTools) userAgent() string {
+	return utils.FirstNonEmpty(w.UserAgent, "hermes-go")
+}
+
+// robotsRules are the Allow and Disallow rules of robots.txt applying to the user agent.
+type robotsRules struct {
+	allow    []string
+	disallow []string
+}
+
+// robotsRetryInterval is how long a robots.txt that could not be fetched is treated as missing before trying again.
+const robotsRetryInterval = time.Minute
+
+// robotsEntry is a cached robots.txt. Entries of robots.txt files that could not be fetched expire.
+type robotsEntry struct {
+	rules     robotsRules
+	expiresAt time.Time // Zero if the entry does not expire
+}
+
+// robotsAllowed reports whether robots.txt allows fetching target. Missing or unreadable robots.txt files allow everything.
+func (w *WebTools) robotsAllowed(ctx context.Context, target *url.URL) bool {
+	key := target.Scheme + "://" + target.Host
+	cached, ok := w.robots.Load(key)
+	entry, _ := cached.(robotsEntry)
+	if !ok || (!entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt)) {
+		rules, err := w.fetchRobots(ctx, key)
+		entry = robotsEntry{rules: rules}
+		if err != nil {
+			utils.Logger.Debug("Failed to fetch robots.txt", "site", key, "error", err)
+			entry.expiresAt = time.Now().Add(robotsRetryInterval)
+		}
+		w.robots.Store(key, entry)
+	}
+	rules := entry.rules
+
+	path := target.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if target.RawQuery != "" {
+		path += "?" + target.RawQuery
+	}
+	// The longest matching rule wins, and Allow wins ties
+	longestAllow, longestDisallow := -1, -1
+	for _, rule := range rules.allow {
+		if robotsMatch(rule, path) && len(rule) > longestAllow {
+			longestAllow = len(rule)
+		}
+	}
+	for _, rule := range rules.disallow {
+		if robotsMatch(rule, path) && len(rule) > longestDisallow {
+			longestDisallow = len(rule)
+		}
+	}
+	return longestDisallow < 0 || longestAllow >= longestDisallow
+}
+
+// fetchRobots fetches and parses the robots.txt of a site. A 4xx status means there are no rules, while
+// network errors and other statuses are returned as errors.
+func (w *WebTools) fetchRobots(ctx context.Context, site string) (robotsRules, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, site+"/robots.txt", nil)
+	if err != nil {
+		return robotsRules{}, err
+	}
+	req.Header.Set("User-Agent", w.userAgent())
+	resp, err := w.client().Do(req)
+	if err != nil {
+		return robotsRules{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+		return robotsRules{}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return robotsRules{}, fmt.Errorf("robots.txt returned %s", resp.Status)
+	}
+	// The body is read first so that a connection dropped midway does not leave partial rules
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 500000))
+	if err != nil {
+		return robotsRules{}, err
+	}
+	return parseRobots(bytes.NewReader(data), w.userAgent()), nil
+}
+
+// parseRobots parses the rules of robots.txt applying to userAgent: those of the groups naming it if any,
+// or those of the "*" groups.
+func parseRobots(r io.Reader, userAgent string) robotsRules {
+	token := strings.ToLower(strings.SplitN(userAgent, "/", 2)[0])
+	var specific, wildcard robotsRules
+	var agents []string
+	inRules := false // True once the current group has rules, so that a new User-agent line starts a new group
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		field, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		field = strings.ToLower(strings.TrimSpace(field))
+		value = strings.TrimSpace(value)
+		switch field {
+		case "user-agent":
+			if inRules {
+				agents = nil
+				inRules = false
+			}
+			agents = append(agents, strings.ToLower(value))
+		case "allow", "disallow":
+			inRules = true
+			if value == "" {
+				continue // An empty Disallow allows everything
+			}
+			for _, agent := range agents {
+				var rules *robotsRules
+				if agent == "*" {
+					rules = &wildcard
+				} else if strings.Contains(token, agent) {
+					rules = &specific
+				} else {
+					continue
+				}
+				if field == "allow" {
+					rules.allow = append(rules.allow, value)
+				} else {
+					rules.disallow = append(rules.disallow, value)
+				}
+			}
+		}
+	}
+	if len(specific.allow) > 0 || len(specific.disallow) > 0 {
+		return specific
+	}
+	return wildcard
+}
+
+// robotsMatch reports whether a robots.txt rule matches path. Rules are prefixes supporting
+// the "*" wildcard and the "$" end anchor.
+func robotsMatch(rule, path string) bool {
+	if !strings.ContainsAny(rule, "*$") {
+		return strings.HasPrefix(path, rule)
+	}
+	anchored := strings.HasSuffix(rule, "$")
+	rule = strings.TrimSuffix(rule, "$")
+	parts := strings.Split(rule, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	pattern := "^" + strings.Join(parts, ".*")
+	if anchored {
+		pattern += "$"
+	}
+	matched, _ := regexp.MatchString(pattern, path)
+	return matched
+}
+
+// looksLikeHTML reports whether data starts like an HTML document.
+func looksLikeHTML(data []byte) bool {
+	start := strings.ToLower(strings.TrimSpace(string(data[:min(len(data), 512)])))
+	return strings.HasPrefix(start, "<!doctype html") || strings.HasPrefix(start, "<html")
+}
+
+// skippedElements are the HTML elements whose content is not part of the page content.
+var skippedElements = []string{"head", "script", "style", "noscript", "template", "svg", "iframe", "nav", "footer", "aside", "form", "button"}
+
+// markdownWriter converts HTML nodes to Markdown.
+type markdownWriter struct {
+	base  *url.URL
+	out   strings.Builder
+	links []Link
+	seen  map[string]bool // URLs of the links already collected
+	pre   bool            // True inside <pre> elements, where whitespace is kept
+}
+
+// htmlToMarkdown returns the title, content as Markdown and links of an HTML document.
+// The content is taken from the <main> or <article> element if there is one, and the <body> otherwise.
+func htmlToMarkdown(doc *html.Node, base *url.URL) (string, string, []Link) {
+	title := ""
+	if node := findElement(doc, "title"); node != nil {
+		title = strings.TrimSpace(collapseSpaces(textContent(node)))
+	}
+	root := findElement(doc, "main")
+	if root == nil {
+		root = findElement(doc, "article")
+	}
+	if root == nil {
+		root = findElement(doc, "body")
+	}
+	if root == nil {
+		root = doc
+	}
+	w := &markdownWriter{base: base, seen: make(map[string]bool)}
+	w.writeChildren(root)
+	lines := strings.Split(w.out.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	content := blankLinesRegexp.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return title, strings.TrimSpace(content), w.links
+}
+
+// findElement returns the first element named tag in depth-first order.
+func findElement(node *html.Node, tag string) *html.Node {
+	if node.Type == html.ElementNode && node.Data == tag {
+		return node
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if found := findElement(child, tag); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// textContent returns the text of a node and its descendants.
+func textContent(node *html.Node) string {
+	if node.Type == html.TextNode {
+		return node.Data
+	}
+	var sb strings.Builder
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		sb.WriteString(textContent(child))
+	}
+	return sb.String()
+}
+
+// attr returns the value of an attribute of a node.
+func attr(node *html.Node, name string) string {
+	for _, a := range node.Attr {
+		if a.Key == name {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+var (
+	spacesRegexp     = regexp.MustCompile(`\s+`)
+	blankLinesRegexp = regexp.MustCompile(`\n{3,}`)
+)
+
+// collapseSpaces replaces runs of whitespace with a single space, as browsers render them.
+func collapseSpaces(s string) string {
+	return spacesRegexp.ReplaceAllString(s, " ")
+}
+
+// block writes a block separator.
+func (w *markdownWriter) block() {
+	w.out.WriteString("\n\n")
+}
+
+// writeChildren writes the children of a node.
+func (w *markdownWriter) writeChildren(node *html.Node) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		w.write(child)
+	}
+}
+
+// inline returns the Markdown of the children of a node on a single line.
+func (w *markdownWriter) inline(node *html.Node) string {
+	sub := &markdownWriter{base: w.base, seen: w.seen}
+	sub.writeChildren(node)
+	w.links = append(w.links, sub.links...)
+	return strings.TrimSpace(collapseSpaces(sub.out.String()))
+}
+
+// write writes a node as Markdown.
+func (w *markdownWriter) write(node *html.Node) {
+	switch node.Type {
+	case html.TextNode:
+		if w.pre {
+			w.out.WriteString(node.Data)
+		} else {
+			w.out.WriteString(collapseSpaces(node.Data))
+		}
+		return
+	case html.ElementNode:
+	default:
+		w.writeChildren(node)
+		return
+	}
+
+	if slices.Contains(skippedElements, node.Data) || attr(node, "hidden") != "" || attr(node, "aria-hidden") == "true" {
+		return
+	}
+	switch node.Data {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		w.block()
+		w.out.WriteString(strings.Repeat("#", int(node.Data[1]-'0')) + " " + w.inline(node))
+		w.block()
+	case "p", "div", "section", "article", "main", "header", "figure", "blockquote", "dl", "table":
+		w.block()
+		if node.Data == "blockquote" {
+			w.out.WriteString("> " + w.inline(node))
+		} else if node.Data == "table" {
+			w.writeTable(node)
+		} else {
+			w.writeChildren(node)
+		}
+		w.block()
+	case "br":
+		w.out.WriteString("\n")
+	case "hr":
+		w.block()
+		w.out.WriteString("---")
+		w.block()
+	case "ul", "ol":
+		w.block()
+		index := 1
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode || child.Data != "li" {
+				continue
+			}
+			marker := "- "
+			if node.Data == "ol" {
+				marker = fmt.Sprintf("%d. ", index)
+				index++
+			}
+			w.out.WriteString(marker + w.inline(child) + "\n")
+		}
+		w.block()
+	case "li", "dt", "dd":
+		w.out.WriteString("\n- " + w.inline(node) + "\n")
+	case "pre":
+		w.block()
+		w.out.WriteString("```\n")
+		w.pre = true
+		w.writeChildren(node)
+		w.pre = false
+		w.out.WriteString("\n```")
+		w.block()
+	case "code":
+		if w.pre {
+			w.writeChildren(node)
+		} else {
+			w.out.WriteString("`" + textContent(node) + "`")
+		}
+	case "strong", "b":
+		if text := w.inline(node); text != "" {
+			w.out.WriteString("**" + text + "**")
+		}
+	case "em", "i":
+		if text := w.inline(node); text != "" {
+			w.out.WriteString("*" + text + "*")
+		}
+	case "a":
+		text := w.inline(node)
+		href := w.resolve(attr(node, "href"))
+		if href == "" {
+			w.out.WriteString(text)
+			return
+		}
+		if text == "" {
+			text = href
+		}
+		w.out.WriteString("[" + text + "](" + href + ")")
+		if !w.seen[href] {
+			w.seen[href] = true
+			w.links = append(w.links, Link{Text: text, URL: href})
+		}
+	case "img":
+		if alt := strings.TrimSpace(attr(node, "alt")); alt != "" {
+			w.out.WriteString("![" + alt + "]")
+		}
+	default:
+		w.writeChildren(node)
+	}
+}
+
+// writeTable writes the rows of a table, with cells separated by "|".
+func (w *markdownWriter) writeTable(table *html.Node) {
+	var rows func(node *html.Node)
+	first := true
+	rows = func(node *html.Node) {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			if child.Data != "tr" {
+				rows(child) // thead, tbody and tfoot
+				continue
+			}
+			var cells []string
+			for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.Type == html.ElementNode && (cell.Data == "td" || cell.Data == "th") {
+					cells = append(cells, w.inline(cell))
+				}
+			}
+			w.out.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+			if first {
+				w.out.WriteString(strings.Repeat("| --- ", len(cells)) + "|\n")
+				first = false
+			}
+		}
+	}
+	rows(table)
+}
+
+// resolve returns the absolute URL of an http or https link, or "" for other links (e.g., "javascript:" or "#top").
+func (w *markdownWriter) resolve(href string) string {
+	href = strings.TrimSpace(href)
+	if href == "" || strings.HasPrefix(href, "#") {
+		return ""
+	}
+	ref, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+	resolved := w.base.ResolveReference(ref)
+	if resolved.Scheme != "http" && resolved.Scheme != "https" {
+		return ""
+	}
+	return resolved.String()
+}
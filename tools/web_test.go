@@ -0,0 +1,279 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPage = `<!DOCTYPE html>
+<html>
+<head><title> Test  Page </title><style>body { color: red; }</style></head>
+<body>
+<nav><a href="/nav">Navigation</a></nav>
+<main>
+<h1>Welcome</h1>
+<p>Hello, <strong>world</strong>! See the <a href="/docs?page=1">docs</a>
+and <a href="https://other.example/x">another site</a>.</p>
+<script>alert("hidden")</script>
+<ul><li>First</li><li><em>Second</em></li></ul>
+<pre><code>fmt.Println("hi")
+return</code></pre>
+<table><tr><th>Name</th><th>Value</th></tr><tr><td>a</td><td>1</td></tr></table>
+<p><a href="javascript:void(0)">Click</a> <a href="/docs?page=1">docs again</a></p>
+</main>
+<footer>Copyright</footer>
+</body>
+</html>`
+
+func newWebTestServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "User-agent: *\nDisallow: /private\nAllow: /private/public\n\nUser-agent: other-bot\nDisallow: /\n")
+	})
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Method", r.Method)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, "%s %s %s %s", r.Method, r.Header.Get("X-Token"), r.UserAgent(), body)
+	})
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, testPage)
+	})
+	mux.HandleFunc("/text", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprint(w, strings.Repeat("abcdefghij", 10))
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	mux.HandleFunc("/private/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "private")
+	})
+	mux.HandleFunc("/redirect/", func(w http.ResponseWriter, r *http.Request) {
+		var n int
+		fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/redirect/"), "%d", &n)
+		if n == 0 {
+			fmt.Fprint(w, "arrived")
+			return
+		}
+		http.Redirect(w, r, fmt.Sprintf("/redirect/%d", n-1), http.StatusFound)
+	})
+	mux.HandleFunc("/away", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://elsewhere.invalid/", http.StatusFound)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWebTools_FetchURL(t *testing.T) {
+	server := newWebTestServer(t)
+	ctx := context.Background()
+	web := &WebTools{EnableAll: true, MaxRedirects: 2, MaxResponseSize: 50, Timeout: 200 * time.Millisecond}
+
+	result, err := web.FetchURL(ctx, server.URL+"/echo", "post", map[string]string{"X-Token": "secret"}, "payload")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, result.StatusCode)
+	assert.Equal(t, "POST secret hermes-go payload", result.Body)
+	assert.Equal(t, "POST", result.Headers["X-Method"])
+	assert.False(t, result.Truncated)
+
+	result, err = web.FetchURL(ctx, server.URL+"/text", "", nil, "")
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("abcdefghij", 5), result.Body)
+	assert.True(t, result.Truncated)
+
+	// Errors statuses are returned, not failures
+	result, err = web.FetchURL(ctx, server.URL+"/missing", "", nil, "")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, result.StatusCode)
+
+	result, err = web.FetchURL(ctx, server.URL+"/redirect/2", "", nil, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "arrived", result.Body)
+	assert.Equal(t, server.URL+"/redirect/0", result.URL)
+
+	_, err = web.FetchURL(ctx, server.URL+"/redirect/3", "", nil, "")
+	assert.ErrorContains(t, err, "stopped after 2 redirects")
+
+	_, err = web.FetchURL(ctx, server.URL+"/slow", "", nil, "")
+	assert.ErrorContains(t, err, "timed out")
+
+	_, err = web.FetchURL(ctx, "file:///etc/passwd", "", nil, "")
+	assert.ErrorContains(t, err, "only http and https URLs are supported")
+}
+
+func TestWebTools_AllowedDomains(t *testing.T) {
+	server := newWebTestServer(t)
+	ctx := context.Background()
+	host := strings.Split(strings.TrimPrefix(server.URL, "http://"), ":")[0]
+	web := &WebTools{AllowedDomains: []string{host}}
+
+	_, err := web.FetchURL(ctx, server.URL+"/echo", "", nil, "")
+	assert.NoError(t, err)
+
+	_, err = web.FetchURL(ctx, server.URL+"/away", "", nil, "")
+	assert.ErrorContains(t, err, "domain elsewhere.invalid is not allowed")
+
+	_, err = web.FetchURL(ctx, "https://example.com/", "", nil, "")
+	assert.ErrorContains(t, err, "domain example.com is not allowed")
+
+	web = &WebTools{AllowedDomains: []string{"example.com"}}
+	for _, allowed := range []string{"https://example.com/a", "https://docs.EXAMPLE.com/a"} {
+		_, err := web.checkURL(allowed)
+		assert.NoError(t, err, allowed)
+	}
+	_, err = web.checkURL("https://notexample.com/")
+	assert.Error(t, err)
+}
+
+func TestWebTools_Robots(t *testing.T) {
+	server := newWebTestServer(t)
+	ctx := context.Background()
+
+	web := &WebTools{}
+	_, err := web.FetchURL(ctx, server.URL+"/private/secret", "", nil, "")
+	assert.ErrorContains(t, err, "disallowed by robots.txt")
+	_, err = web.FetchURL(ctx, server.URL+"/private/public", "", nil, "")
+	assert.NoError(t, err)
+	_, err = web.FetchURL(ctx, server.URL+"/echo", "", nil, "")
+	assert.NoError(t, err)
+
+	// Rules of the group naming the user agent replace the "*" rules
+	web = &WebTools{UserAgent: "other-bot/1.0"}
+	_, err = web.FetchURL(ctx, server.URL+"/echo", "", nil, "")
+	assert.ErrorContains(t, err, "disallowed by robots.txt")
+
+	web = &WebTools{UserAgent: "other-bot/1.0", IgnoreRobots: true}
+	_, err = web.FetchURL(ctx, server.URL+"/echo", "", nil, "")
+	assert.NoError(t, err)
+}
+
+func TestWebTools_RobotsUnavailable(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		fmt.Fprint(w, "User-agent: *\nDisallow: /\n")
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "page")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	ctx := context.Background()
+
+	// Unavailable robots.txt files allow fetching until they are fetched again
+	web := &WebTools{}
+	_, err := web.FetchURL(ctx, server.URL+"/page", "", nil, "")
+	assert.NoError(t, err)
+	status = http.StatusOK
+	_, err = web.FetchURL(ctx, server.URL+"/page", "", nil, "")
+	assert.NoError(t, err)
+	web.robots.Range(func(key, value interface{}) bool {
+		entry := value.(robotsEntry)
+		assert.False(t, entry.expiresAt.IsZero(), "Failures should expire")
+		entry.expiresAt = time.Now().Add(-time.Second)
+		web.robots.Store(key, entry)
+		return true
+	})
+	_, err = web.FetchURL(ctx, server.URL+"/page", "", nil, "")
+	assert.ErrorContains(t, err, "disallowed by robots.txt")
+
+	// Missing robots.txt files allow everything, and are not fetched again
+	status = http.StatusNotFound
+	web = &WebTools{}
+	_, err = web.FetchURL(ctx, server.URL+"/page", "", nil, "")
+	assert.NoError(t, err)
+	status = http.StatusOK
+	_, err = web.FetchURL(ctx, server.URL+"/page", "", nil, "")
+	assert.NoError(t, err)
+}
+
+func TestRobotsMatch(t *testing.T) {
+	testCases := []struct {
+		rule     string
+		path     string
+		expected bool
+	}{
+		{"/private", "/private/a", true},
+		{"/private", "/public", false},
+		{"/*.pdf$", "/docs/a.pdf", true},
+		{"/*.pdf$", "/docs/a.pdf?x=1", false},
+		{"/a*/c", "/a/b/c", true},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, robotsMatch(tc.rule, tc.path), "%s %s", tc.rule, tc.path)
+	}
+}
+
+func TestWebTools_ReadPage(t *testing.T) {
+	server := newWebTestServer(t)
+	ctx := context.Background()
+	web := &WebTools{EnableReadPage: true}
+
+	page, err := web.ReadPage(ctx, server.URL+"/page")
+	assert.NoError(t, err)
+	assert.Equal(t, "Test Page", page.Title)
+	assert.Equal(t, server.URL+"/page", page.URL)
+	expected := "# Welcome\n\n" +
+		"Hello, **world**! See the [docs](" + server.URL + "/docs?page=1) and [another site](https://other.example/x).\n\n" +
+		"- First\n- *Second*\n\n" +
+		"```\nfmt.Println(\"hi\")\nreturn\n```\n\n" +
+		"| Name | Value |\n| --- | --- |\n| a | 1 |\n\n" +
+		"Click [docs again](" + server.URL + "/docs?page=1)"
+	assert.Equal(t, expected, page.Content)
+	assert.Equal(t, []Link{
+		{Text: "docs", URL: server.URL + "/docs?page=1"},
+		{Text: "another site", URL: "https://other.example/x"},
+	}, page.Links)
+	assert.NotContains(t, page.Content, "alert")
+	assert.NotContains(t, page.Content, "Navigation")
+	assert.NotContains(t, page.Content, "Copyright")
+
+	page, err = (&WebTools{MaxContentLength: 15}).ReadPage(ctx, server.URL+"/text")
+	assert.NoError(t, err)
+	assert.Equal(t, "abcdefghijabcde\n[Content truncated]", page.Content)
+	assert.True(t, page.Truncated)
+
+	_, err = web.ReadPage(ctx, server.URL+"/missing")
+	assert.ErrorContains(t, err, "404")
+}
+
+func TestHTMLToMarkdown_RelativeLinks(t *testing.T) {
+	base, _ := url.Parse("https://example.com/blog/post")
+	web := &WebTools{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><body><p><a href="next">Next</a> <a href="#top">Top</a> <a href="mailto:a@b.c">Mail</a></p></body></html>`)
+	}))
+	defer server.Close()
+
+	page, err := web.ReadPage(context.Background(), server.URL+"/blog/post")
+	assert.NoError(t, err)
+	assert.Equal(t, "[Next]("+server.URL+"/blog/next) Top Mail", page.Content)
+	assert.Equal(t, "https://example.com/blog/next", (&markdownWriter{base: base}).resolve("next"))
+}
+
+func TestWebTools_Tools(t *testing.T) {
+	assert.Len(t, (&WebTools{EnableAll: true}).Tools(), 2)
+	tools := (&WebTools{EnableReadPage: true}).Tools()
+	assert.Len(t, tools, 1)
+	assert.Equal(t, "ReadPage", tools[0].Name)
+	assert.Equal(t, []string{"url"}, tools[0].Parameters["required"])
+}